@@ -0,0 +1,57 @@
+package benchmark
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+var (
+	sizes      = []int{100, 10000, 100000}
+	goroutines = []int{1, 4, 16}
+)
+
+func shuffled(n int) []int {
+	return rand.New(rand.NewSource(int64(n))).Perm(n)
+}
+
+func eachSize(b *testing.B, name string, fn func(b *testing.B, size int)) {
+	for _, size := range sizes {
+		b.Run(fmt.Sprintf("%s/size=%d", name, size), func(b *testing.B) {
+			b.ReportAllocs()
+			fn(b, size)
+		})
+	}
+}
+
+func eachGoroutines(b *testing.B, name string, fn func(b *testing.B, workers int)) {
+	for _, workers := range goroutines {
+		b.Run(fmt.Sprintf("%s/goroutines=%d", name, workers), func(b *testing.B) {
+			b.ReportAllocs()
+			fn(b, workers)
+		})
+	}
+}
+
+func parallel(b *testing.B, workers int, fn func(i int)) {
+	var wg sync.WaitGroup
+
+	per := b.N/workers + 1
+
+	b.ResetTimer()
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < per; i++ {
+				fn(w*per + i)
+			}
+		}(w)
+	}
+
+	wg.Wait()
+}
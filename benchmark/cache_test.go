@@ -0,0 +1,49 @@
+package benchmark
+
+import (
+	"github.com/kucuny/gods/cache"
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkCacheZipf(b *testing.B) {
+	caches := []struct {
+		name string
+		new  func(capacity int) cache.Cache
+	}{
+		{"lru", func(capacity int) cache.Cache { return cache.NewLRUCache(capacity) }},
+		{"lfu", func(capacity int) cache.Cache { return cache.NewLFUCache(capacity) }},
+		{"arc", func(capacity int) cache.Cache { return cache.NewARCCache(capacity) }},
+	}
+
+	for _, c := range caches {
+		c := c
+
+		eachSize(b, c.name, func(b *testing.B, size int) {
+			r := rand.New(rand.NewSource(1))
+			zipf := rand.NewZipf(r, 1.1, 1, uint64(size*10))
+			keys := make([]uint64, 4096)
+
+			for i := range keys {
+				keys[i] = zipf.Uint64()
+			}
+
+			target := c.new(size)
+			hits := 0
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				key := keys[i%len(keys)]
+
+				if _, ok := target.Get(key); ok {
+					hits++
+				} else {
+					target.Put(key, i)
+				}
+			}
+
+			b.ReportMetric(float64(hits)/float64(b.N), "hits/op")
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/queue"
+	"github.com/kucuny/gods/tree"
+	"io"
+	"testing"
+)
+
+func BenchmarkQueueEncode(b *testing.B) {
+	filled := func(size int) *queue.Queue {
+		q := queue.NewQueue(gods.WithCodec(gods.IntCodec))
+
+		for i := 0; i < size; i++ {
+			q.Push(i)
+		}
+
+		return q
+	}
+
+	eachSize(b, "json", func(b *testing.B, size int) {
+		q := filled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			json.NewEncoder(io.Discard).Encode(q)
+		}
+	})
+
+	eachSize(b, "binary", func(b *testing.B, size int) {
+		q := filled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			q.WriteTo(io.Discard)
+		}
+	})
+}
+
+func BenchmarkBinarySearchTreeDecode(b *testing.B) {
+	encoded := func(size int) ([]byte, []byte) {
+		t := tree.NewBinarySearchTree(tree.IntegerComparer, gods.WithCodec(gods.IntCodec))
+
+		for _, value := range shuffled(size) {
+			t.Insert(value)
+		}
+
+		text, _ := json.Marshal(t)
+		data, _ := t.MarshalBinary()
+
+		return text, data
+	}
+
+	eachSize(b, "json", func(b *testing.B, size int) {
+		text, _ := encoded(size)
+		t := tree.NewBinarySearchTree(tree.IntegerComparer, gods.WithElement[int]())
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			json.Unmarshal(text, t)
+		}
+	})
+
+	eachSize(b, "binary", func(b *testing.B, size int) {
+		_, data := encoded(size)
+		t := tree.NewBinarySearchTree(tree.IntegerComparer, gods.WithCodec(gods.IntCodec))
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.UnmarshalBinary(data)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package benchmark
+
+import (
+	"container/list"
+	godslist "github.com/kucuny/gods/list"
+	"testing"
+)
+
+func BenchmarkListPushBack(b *testing.B) {
+	eachSize(b, "gods-linked", func(b *testing.B, size int) {
+		for i := 0; i < b.N; i++ {
+			l := godslist.NewLinkedList()
+
+			for j := 0; j < size; j++ {
+				l.PushBack(&godslist.Node{Value: j})
+			}
+		}
+	})
+
+	eachSize(b, "gods-singly", func(b *testing.B, size int) {
+		for i := 0; i < b.N; i++ {
+			l := godslist.NewSinglyLinkedList()
+
+			for j := 0; j < size; j++ {
+				l.PushBack(&godslist.SinglyNode{Value: j})
+			}
+		}
+	})
+
+	eachSize(b, "container-list", func(b *testing.B, size int) {
+		for i := 0; i < b.N; i++ {
+			l := list.New()
+
+			for j := 0; j < size; j++ {
+				l.PushBack(j)
+			}
+		}
+	})
+
+	eachSize(b, "slice", func(b *testing.B, size int) {
+		for i := 0; i < b.N; i++ {
+			var s []interface{}
+
+			for j := 0; j < size; j++ {
+				s = append(s, j)
+			}
+		}
+	})
+}
+
+func BenchmarkListTraverse(b *testing.B) {
+	eachSize(b, "gods-linked", func(b *testing.B, size int) {
+		l := godslist.NewLinkedList()
+
+		for j := 0; j < size; j++ {
+			l.PushBack(&godslist.Node{Value: j})
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			l.Each(func(value interface{}) bool { return true })
+		}
+	})
+
+	eachSize(b, "gods-circular", func(b *testing.B, size int) {
+		l := godslist.NewCircularList()
+
+		for j := 0; j < size; j++ {
+			l.PushBack(&godslist.SinglyNode{Value: j})
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			l.Each(func(value interface{}) bool { return true })
+		}
+	})
+
+	eachSize(b, "container-list", func(b *testing.B, size int) {
+		l := list.New()
+
+		for j := 0; j < size; j++ {
+			l.PushBack(j)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for e := l.Front(); e != nil; e = e.Next() {
+			}
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package benchmark
+
+import (
+	"container/list"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/queue"
+	"sync"
+	"testing"
+)
+
+func BenchmarkQueuePushPop(b *testing.B) {
+	eachSize(b, "gods", func(b *testing.B, size int) {
+		q := queue.NewQueue()
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < size; j++ {
+				q.Push(j)
+			}
+
+			for j := 0; j < size; j++ {
+				q.Pop()
+			}
+		}
+	})
+
+	eachSize(b, "gods-nolock", func(b *testing.B, size int) {
+		q := queue.NewQueue(gods.WithLock(gods.NoLock))
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < size; j++ {
+				q.Push(j)
+			}
+
+			for j := 0; j < size; j++ {
+				q.Pop()
+			}
+		}
+	})
+
+	eachSize(b, "container-list", func(b *testing.B, size int) {
+		l := list.New()
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < size; j++ {
+				l.PushBack(j)
+			}
+
+			for j := 0; j < size; j++ {
+				l.Remove(l.Front())
+			}
+		}
+	})
+
+	eachSize(b, "slice", func(b *testing.B, size int) {
+		var s []interface{}
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < size; j++ {
+				s = append(s, j)
+			}
+
+			for j := 0; j < size; j++ {
+				s = s[1:]
+			}
+		}
+	})
+}
+
+func BenchmarkQueueConcurrent(b *testing.B) {
+	eachGoroutines(b, "gods", func(b *testing.B, workers int) {
+		q := queue.NewQueue()
+
+		parallel(b, workers, func(i int) {
+			q.Push(i)
+			q.Pop()
+		})
+	})
+
+	eachGoroutines(b, "container-list-mutex", func(b *testing.B, workers int) {
+		l := list.New()
+		mutex := new(sync.Mutex)
+
+		parallel(b, workers, func(i int) {
+			mutex.Lock()
+			l.PushBack(i)
+			mutex.Unlock()
+
+			mutex.Lock()
+			l.Remove(l.Front())
+			mutex.Unlock()
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package benchmark
+
+import (
+	"github.com/kucuny/gods/rangequery"
+	"testing"
+)
+
+func int64Values(size int) []interface{} {
+	values := make([]interface{}, size)
+
+	for i, value := range shuffled(size) {
+		values[i] = int64(value)
+	}
+
+	return values
+}
+
+func BenchmarkRangeSum(b *testing.B) {
+	eachSize(b, "gods-segmenttree", func(b *testing.B, size int) {
+		s := rangequery.NewSegmentTree(int64Values(size), rangequery.Int64Sum)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			s.Query(i%(size/2), size/2+i%(size/2))
+		}
+	})
+
+	eachSize(b, "gods-fenwick", func(b *testing.B, size int) {
+		f := rangequery.NewFenwickTree(int64Values(size), rangequery.Int64SumGroup)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			f.Query(i%(size/2), size/2+i%(size/2))
+		}
+	})
+
+	eachSize(b, "slice-scan", func(b *testing.B, size int) {
+		s := make([]int64, size)
+
+		for i, value := range shuffled(size) {
+			s[i] = int64(value)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			var sum int64
+
+			for _, value := range s[i%(size/2) : size/2+i%(size/2)] {
+				sum += value
+			}
+
+			_ = sum
+		}
+	})
+}
+
+func BenchmarkRangeAdd(b *testing.B) {
+	eachSize(b, "gods-lazy-segmenttree", func(b *testing.B, size int) {
+		s := rangequery.NewLazySegmentTree(int64Values(size), rangequery.Int64Sum, rangequery.Int64AddToSum)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			s.Update(i%(size/2), size/2+i%(size/2), int64(1))
+		}
+	})
+
+	eachSize(b, "slice-scan", func(b *testing.B, size int) {
+		s := make([]int64, size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for j := i % (size / 2); j < size/2+i%(size/2); j++ {
+				s[j]++
+			}
+		}
+	})
+}
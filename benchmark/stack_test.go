@@ -0,0 +1,83 @@
+package benchmark
+
+import (
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/stack"
+	"sync"
+	"testing"
+)
+
+func BenchmarkStackPushPop(b *testing.B) {
+	eachSize(b, "gods", func(b *testing.B, size int) {
+		s := stack.NewStack()
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < size; j++ {
+				s.Push(j)
+			}
+
+			for j := 0; j < size; j++ {
+				s.Pop()
+			}
+		}
+	})
+
+	eachSize(b, "gods-nolock", func(b *testing.B, size int) {
+		s := stack.NewStack(gods.WithLock(gods.NoLock))
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < size; j++ {
+				s.Push(j)
+			}
+
+			for j := 0; j < size; j++ {
+				s.Pop()
+			}
+		}
+	})
+
+	eachSize(b, "slice", func(b *testing.B, size int) {
+		var s []interface{}
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < size; j++ {
+				s = append(s, j)
+			}
+
+			for j := 0; j < size; j++ {
+				s = s[:len(s)-1]
+			}
+		}
+	})
+}
+
+func BenchmarkStackPeekConcurrent(b *testing.B) {
+	eachGoroutines(b, "gods", func(b *testing.B, workers int) {
+		s := stack.NewStack()
+		s.Push(1)
+
+		parallel(b, workers, func(i int) {
+			s.Peek()
+		})
+	})
+
+	eachGoroutines(b, "gods-mutex", func(b *testing.B, workers int) {
+		s := stack.NewStack(gods.WithLock(gods.MutexLock))
+		s.Push(1)
+
+		parallel(b, workers, func(i int) {
+			s.Peek()
+		})
+	})
+
+	eachGoroutines(b, "slice-mutex", func(b *testing.B, workers int) {
+		s := []interface{}{1}
+		mutex := new(sync.Mutex)
+
+		parallel(b, workers, func(i int) {
+			mutex.Lock()
+			_ = s[len(s)-1]
+			mutex.Unlock()
+		})
+	})
+}
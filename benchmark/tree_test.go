@@ -0,0 +1,497 @@
+package benchmark
+
+import (
+	"container/heap"
+	"github.com/kucuny/gods/skiplist"
+	"github.com/kucuny/gods/tree"
+	"sort"
+	"sync"
+	"testing"
+)
+
+type intHeap []int
+
+func (h intHeap) Len() int            { return len(h) }
+func (h intHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func BenchmarkOrderedInsert(b *testing.B) {
+	eachSize(b, "gods-bst", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t := tree.NewBinarySearchTree(tree.IntegerComparer)
+
+			for _, key := range keys {
+				t.Insert(key)
+			}
+		}
+	})
+
+	eachSize(b, "gods-splay", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t := tree.NewSplayTree(tree.IntegerComparer)
+
+			for _, key := range keys {
+				t.Insert(key)
+			}
+		}
+	})
+
+	eachSize(b, "gods-treap", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t := tree.NewTreap(tree.IntegerComparer)
+
+			for _, key := range keys {
+				t.Insert(key)
+			}
+		}
+	})
+
+	eachSize(b, "gods-btree", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t := tree.NewBTree(tree.IntegerComparer, tree.DefaultBTreeDegree)
+
+			for _, key := range keys {
+				t.Insert(key, nil)
+			}
+		}
+	})
+
+	eachSize(b, "gods-skiplist", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			s := skiplist.NewSkipList(tree.IntegerComparer)
+
+			for _, key := range keys {
+				s.Insert(key, nil)
+			}
+		}
+	})
+
+	eachSize(b, "gods-concurrent-skiplist", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			s := skiplist.NewConcurrentSkipList(tree.IntegerComparer)
+
+			for _, key := range keys {
+				s.Insert(key, nil)
+			}
+		}
+	})
+
+	eachSize(b, "map", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			m := make(map[int]struct{})
+
+			for _, key := range keys {
+				m[key] = struct{}{}
+			}
+		}
+	})
+
+	eachSize(b, "sorted-slice", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			var s []int
+
+			for _, key := range keys {
+				at := sort.SearchInts(s, key)
+				s = append(s, 0)
+				copy(s[at+1:], s[at:])
+				s[at] = key
+			}
+		}
+	})
+}
+
+func BenchmarkOrderedSearch(b *testing.B) {
+	eachSize(b, "gods-bst", func(b *testing.B, size int) {
+		t := tree.NewBinarySearchTree(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Search(i % size)
+		}
+	})
+
+	eachSize(b, "gods-splay", func(b *testing.B, size int) {
+		t := tree.NewSplayTree(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Search(i % size)
+		}
+	})
+
+	eachSize(b, "gods-btree", func(b *testing.B, size int) {
+		t := tree.NewBTree(tree.IntegerComparer, tree.DefaultBTreeDegree)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key, nil)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Get(i % size)
+		}
+	})
+
+	eachSize(b, "gods-skiplist", func(b *testing.B, size int) {
+		s := skiplist.NewSkipList(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			s.Insert(key, nil)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			s.Search(i % size)
+		}
+	})
+
+	eachSize(b, "map", func(b *testing.B, size int) {
+		m := make(map[int]struct{})
+
+		for _, key := range shuffled(size) {
+			m[key] = struct{}{}
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = m[i%size]
+		}
+	})
+
+	eachSize(b, "sorted-slice", func(b *testing.B, size int) {
+		s := make([]int, size)
+
+		for i := range s {
+			s[i] = i
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			sort.SearchInts(s, i%size)
+		}
+	})
+}
+
+func BenchmarkOrderedTraverse(b *testing.B) {
+	eachSize(b, "gods-bst", func(b *testing.B, size int) {
+		t := tree.NewBinarySearchTree(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.TraverseInOrder(func(node *tree.Node) {})
+		}
+	})
+
+	eachSize(b, "gods-splay", func(b *testing.B, size int) {
+		t := tree.NewSplayTree(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.TraverseInOrder(func(node *tree.Node) {})
+		}
+	})
+
+	eachSize(b, "gods-btree", func(b *testing.B, size int) {
+		t := tree.NewBTree(tree.IntegerComparer, tree.DefaultBTreeDegree)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key, nil)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Range(nil, nil, func(key, value interface{}) bool { return true })
+		}
+	})
+
+	eachSize(b, "gods-skiplist", func(b *testing.B, size int) {
+		s := skiplist.NewSkipList(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			s.Insert(key, nil)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			s.Traverse(func(node *skiplist.Node) {})
+		}
+	})
+
+	eachSize(b, "sorted-slice", func(b *testing.B, size int) {
+		s := make([]int, size)
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for range s {
+			}
+		}
+	})
+}
+
+// BenchmarkSkewedSearch looks up a small hot set of 16 keys, the access
+// pattern a splay tree is built for.
+func BenchmarkSkewedSearch(b *testing.B) {
+	eachSize(b, "gods-bst", func(b *testing.B, size int) {
+		t := tree.NewBinarySearchTree(tree.IntegerComparer)
+		keys := shuffled(size)
+
+		for _, key := range keys {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Search(keys[i%16])
+		}
+	})
+
+	eachSize(b, "gods-splay", func(b *testing.B, size int) {
+		t := tree.NewSplayTree(tree.IntegerComparer)
+		keys := shuffled(size)
+
+		for _, key := range keys {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Search(keys[i%16])
+		}
+	})
+}
+
+func BenchmarkTreapSplitMerge(b *testing.B) {
+	eachSize(b, "gods-treap", func(b *testing.B, size int) {
+		t := tree.NewTreap(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			left, right := t.Split(i % size)
+			left.Merge(right)
+		}
+	})
+}
+
+func BenchmarkTreapUnion(b *testing.B) {
+	eachSize(b, "gods-treap", func(b *testing.B, size int) {
+		evens, odds := tree.NewTreap(tree.IntegerComparer), tree.NewTreap(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			if key%2 == 0 {
+				evens.Insert(key)
+			} else {
+				odds.Insert(key)
+			}
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			evens.Union(odds)
+		}
+	})
+}
+
+func BenchmarkBTreeLoad(b *testing.B) {
+	eachSize(b, "load", func(b *testing.B, size int) {
+		keys := make([]interface{}, size)
+
+		for i, key := range shuffled(size) {
+			keys[i] = key
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			tree.NewBTree(tree.IntegerComparer, tree.DefaultBTreeDegree).Load(keys, nil)
+		}
+	})
+
+	eachSize(b, "insert", func(b *testing.B, size int) {
+		keys := shuffled(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t := tree.NewBTree(tree.IntegerComparer, tree.DefaultBTreeDegree)
+
+			for _, key := range keys {
+				t.Insert(key, nil)
+			}
+		}
+	})
+}
+
+func BenchmarkBTreeCloneWrite(b *testing.B) {
+	eachSize(b, "gods-btree", func(b *testing.B, size int) {
+		t := tree.NewBTree(tree.IntegerComparer, tree.DefaultBTreeDegree)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key, nil)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Clone().Insert(i%size, i)
+		}
+	})
+}
+
+func BenchmarkOrderedPopMin(b *testing.B) {
+	eachSize(b, "gods-bst", func(b *testing.B, size int) {
+		keys := shuffled(size)
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			t := tree.NewBinarySearchTree(tree.IntegerComparer)
+
+			for _, key := range keys {
+				t.Insert(key)
+			}
+
+			b.StartTimer()
+
+			for !t.IsEmpty() {
+				t.Remove(t.Min().Value)
+			}
+		}
+	})
+
+	eachSize(b, "container-heap", func(b *testing.B, size int) {
+		keys := shuffled(size)
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			h := make(intHeap, len(keys))
+			copy(h, keys)
+			heap.Init(&h)
+			b.StartTimer()
+
+			for h.Len() > 0 {
+				heap.Pop(&h)
+			}
+		}
+	})
+}
+
+func BenchmarkOrderedConcurrentReadWrite(b *testing.B) {
+	const size = 10000
+
+	eachGoroutines(b, "gods-bst", func(b *testing.B, workers int) {
+		t := tree.NewBinarySearchTree(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			t.Insert(key)
+		}
+
+		parallel(b, workers, func(i int) {
+			if i%10 == 0 {
+				t.Insert(size + i)
+			} else {
+				t.Search(i % size)
+			}
+		})
+	})
+
+	eachGoroutines(b, "gods-concurrent-skiplist", func(b *testing.B, workers int) {
+		s := skiplist.NewConcurrentSkipList(tree.IntegerComparer)
+
+		for _, key := range shuffled(size) {
+			s.Insert(key, nil)
+		}
+
+		parallel(b, workers, func(i int) {
+			if i%10 == 0 {
+				s.Insert(size+i, nil)
+			} else {
+				s.Get(i % size)
+			}
+		})
+	})
+
+	eachGoroutines(b, "map-rwmutex", func(b *testing.B, workers int) {
+		m := make(map[int]struct{})
+		mutex := new(sync.RWMutex)
+
+		for _, key := range shuffled(size) {
+			m[key] = struct{}{}
+		}
+
+		parallel(b, workers, func(i int) {
+			if i%10 == 0 {
+				mutex.Lock()
+				m[size+i] = struct{}{}
+				mutex.Unlock()
+			} else {
+				mutex.RLock()
+				_ = m[i%size]
+				mutex.RUnlock()
+			}
+		})
+	})
+}
@@ -0,0 +1,135 @@
+package benchmark
+
+import (
+	"fmt"
+	"github.com/kucuny/gods/tree"
+	"github.com/kucuny/gods/trie"
+	"strings"
+	"testing"
+)
+
+// paths returns size URL-like keys that share long prefixes, the shape of
+// route tables and autocomplete dictionaries.
+func paths(size int) []string {
+	keys := make([]string, size)
+
+	for i, n := range shuffled(size) {
+		keys[i] = fmt.Sprintf("/api/v%d/users/%d/items/%d", n%3, n/100, n)
+	}
+
+	return keys
+}
+
+func BenchmarkStringInsert(b *testing.B) {
+	eachSize(b, "gods-radix", func(b *testing.B, size int) {
+		keys := paths(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t := trie.NewRadixTree()
+
+			for _, key := range keys {
+				t.Insert(key, nil)
+			}
+		}
+	})
+
+	eachSize(b, "gods-bst", func(b *testing.B, size int) {
+		keys := paths(size)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t := tree.NewBinarySearchTree(tree.StringComparer)
+
+			for _, key := range keys {
+				t.Insert(key)
+			}
+		}
+	})
+}
+
+func BenchmarkStringSearch(b *testing.B) {
+	eachSize(b, "gods-radix", func(b *testing.B, size int) {
+		keys := paths(size)
+		t := trie.NewRadixTree()
+
+		for _, key := range keys {
+			t.Insert(key, nil)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Get(keys[i%size])
+		}
+	})
+
+	eachSize(b, "gods-radix-bytes", func(b *testing.B, size int) {
+		keys := paths(size)
+		t := trie.NewRadixTree()
+		raw := make([][]byte, size)
+
+		for i, key := range keys {
+			t.Insert(key, nil)
+			raw[i] = []byte(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.GetBytes(raw[i%size])
+		}
+	})
+
+	eachSize(b, "gods-bst", func(b *testing.B, size int) {
+		keys := paths(size)
+		t := tree.NewBinarySearchTree(tree.StringComparer)
+
+		for _, key := range keys {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.Search(keys[i%size])
+		}
+	})
+}
+
+func BenchmarkStringPrefix(b *testing.B) {
+	eachSize(b, "gods-radix", func(b *testing.B, size int) {
+		t := trie.NewRadixTree()
+
+		for _, key := range paths(size) {
+			t.Insert(key, nil)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			t.WalkPrefix(fmt.Sprintf("/api/v1/users/%d/", i%(size/100+1)), func(key string, value interface{}) bool {
+				return true
+			})
+		}
+	})
+
+	eachSize(b, "gods-bst", func(b *testing.B, size int) {
+		t := tree.NewBinarySearchTree(tree.StringComparer)
+
+		for _, key := range paths(size) {
+			t.Insert(key)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			prefix := fmt.Sprintf("/api/v1/users/%d/", i%(size/100+1))
+
+			t.Each(func(value interface{}) bool {
+				strings.HasPrefix(value.(string), prefix)
+				return true
+			})
+		}
+	})
+}
@@ -0,0 +1,320 @@
+package gods
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Kind identifies the container shape stored in a binary stream.
+type Kind byte
+
+const (
+	KindList Kind = iota + 1
+	KindQueue
+	KindStack
+	KindSet
+	KindMap
+)
+
+// BinaryVersion is the current version of the binary container format.
+const BinaryVersion = 1
+
+// MaxRecordSize bounds the encoded size of a single element, so a corrupt
+// length prefix cannot make a reader allocate or skip unbounded input.
+const MaxRecordSize = 1 << 30
+
+var binaryMagic = [4]byte{'G', 'O', 'D', 'S'}
+
+var (
+	ErrInvalidHeader      = errors.New("gods: invalid binary header")
+	ErrUnsupportedVersion = errors.New("gods: unsupported binary version")
+	ErrKindMismatch       = errors.New("gods: binary stream holds a different container kind")
+	ErrRecordTooLarge     = errors.New("gods: binary record exceeds MaxRecordSize")
+)
+
+// BinaryWriter writes the binary container format: the magic "GODS", a
+// version byte and a kind byte, then one uvarint length-prefixed record per
+// element and a zero length end marker. Records are buffered and flushed as
+// they fill, so large containers are never held in memory.
+type BinaryWriter struct {
+	counter *countingWriter
+	writer  *bufio.Writer
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func NewBinaryWriter(w io.Writer, kind Kind) (*BinaryWriter, error) {
+	counter := &countingWriter{writer: w}
+	writer := &BinaryWriter{counter: counter, writer: bufio.NewWriter(counter)}
+
+	header := append(binaryMagic[:], BinaryVersion, byte(kind))
+
+	if _, err := writer.writer.Write(header); err != nil {
+		return writer, err
+	}
+
+	return writer, nil
+}
+
+func (w *BinaryWriter) Encode(codec Codec, value interface{}) error {
+	data, err := codec.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) > MaxRecordSize {
+		return fmt.Errorf("%w: %d bytes", ErrRecordTooLarge, len(data))
+	}
+
+	n := binary.PutUvarint(w.scratch[:], uint64(len(data))+1)
+
+	if _, err := w.writer.Write(w.scratch[:n]); err != nil {
+		return err
+	}
+
+	_, err = w.writer.Write(data)
+
+	return err
+}
+
+// Close writes the end marker and flushes. It does not close the underlying
+// writer.
+func (w *BinaryWriter) Close() error {
+	if err := w.writer.WriteByte(0); err != nil {
+		return err
+	}
+
+	return w.writer.Flush()
+}
+
+// Written reports the number of bytes flushed to the underlying writer.
+func (w *BinaryWriter) Written() int64 {
+	return w.counter.count
+}
+
+// BinaryReader reads the format written by BinaryWriter. Readers that do not
+// implement io.ByteReader are buffered and may be read past the end marker.
+type BinaryReader struct {
+	reader *countingReader
+	buffer bytes.Buffer
+	done   bool
+}
+
+func NewBinaryReader(r io.Reader, kind Kind) (*BinaryReader, error) {
+	byteReader, ok := r.(byteReader)
+
+	if !ok {
+		byteReader = bufio.NewReader(r)
+	}
+
+	reader := &BinaryReader{reader: &countingReader{reader: byteReader}}
+
+	var header [len(binaryMagic) + 2]byte
+
+	if _, err := io.ReadFull(reader.reader, header[:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return reader, fmt.Errorf("%w: %v", ErrInvalidHeader, err)
+	}
+
+	if !bytes.Equal(header[:len(binaryMagic)], binaryMagic[:]) {
+		return reader, ErrInvalidHeader
+	}
+
+	if version := header[len(binaryMagic)]; version == 0 || version > BinaryVersion {
+		return reader, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	if got := Kind(header[len(binaryMagic)+1]); got != kind {
+		return reader, fmt.Errorf("%w: want %d, got %d", ErrKindMismatch, kind, got)
+	}
+
+	return reader, nil
+}
+
+// Decode returns the next element, or io.EOF after the end marker.
+func (r *BinaryReader) Decode(codec Codec) (interface{}, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+
+	length, err := binary.ReadUvarint(r.reader)
+
+	if err != nil {
+		return nil, unexpected(err)
+	}
+
+	if length == 0 {
+		r.done = true
+		return nil, io.EOF
+	}
+
+	if length-1 > MaxRecordSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrRecordTooLarge, length-1)
+	}
+
+	r.buffer.Reset()
+
+	if _, err := io.CopyN(&r.buffer, r.reader, int64(length-1)); err != nil {
+		return nil, unexpected(err)
+	}
+
+	return codec.Unmarshal(r.buffer.Bytes())
+}
+
+// Read reports the number of bytes consumed from the stream.
+func (r *BinaryReader) Read() int64 {
+	return r.reader.count
+}
+
+// WriteValues streams the values produced by each as a binary container.
+func WriteValues(w io.Writer, kind Kind, codec Codec, each func(fn func(value interface{}) bool)) (int64, error) {
+	writer, err := NewBinaryWriter(w, kind)
+
+	if err == nil {
+		each(func(value interface{}) bool {
+			err = writer.Encode(codec, value)
+			return err == nil
+		})
+	}
+
+	if err == nil {
+		err = writer.Close()
+	}
+
+	return writer.Written(), err
+}
+
+// ReadValues calls fn with each value of a binary container in order.
+func ReadValues(r io.Reader, kind Kind, codec Codec, fn func(value interface{})) (int64, error) {
+	reader, err := NewBinaryReader(r, kind)
+
+	for err == nil {
+		var value interface{}
+
+		if value, err = reader.Decode(codec); err == nil {
+			fn(value)
+		}
+	}
+
+	if err == io.EOF {
+		err = nil
+	}
+
+	return reader.Read(), err
+}
+
+// WriteEntries streams key and value pairs, each pair as two records.
+func WriteEntries(w io.Writer, kind Kind, keyCodec, codec Codec, each func(fn func(key, value interface{}) bool)) (int64, error) {
+	writer, err := NewBinaryWriter(w, kind)
+
+	if err == nil {
+		each(func(key, value interface{}) bool {
+			if err = writer.Encode(keyCodec, key); err == nil {
+				err = writer.Encode(codec, value)
+			}
+
+			return err == nil
+		})
+	}
+
+	if err == nil {
+		err = writer.Close()
+	}
+
+	return writer.Written(), err
+}
+
+func ReadEntries(r io.Reader, kind Kind, keyCodec, codec Codec, fn func(key, value interface{})) (int64, error) {
+	reader, err := NewBinaryReader(r, kind)
+
+	for err == nil {
+		var key, value interface{}
+
+		if key, err = reader.Decode(keyCodec); err != nil {
+			break
+		}
+
+		if value, err = reader.Decode(codec); err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		} else if err == nil {
+			fn(key, value)
+		}
+	}
+
+	if err == io.EOF {
+		err = nil
+	}
+
+	return reader.Read(), err
+}
+
+// MarshalBinary buffers the output of WriteTo, for implementing
+// encoding.BinaryMarshaler and gob.GobEncoder.
+func MarshalBinary(w io.WriterTo) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if _, err := w.WriteTo(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func UnmarshalBinary(r io.ReaderFrom, data []byte) error {
+	_, err := r.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	c.count += int64(n)
+
+	return n, err
+}
+
+type countingReader struct {
+	reader byteReader
+	count  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.reader.ReadByte()
+
+	if err == nil {
+		c.count++
+	}
+
+	return b, err
+}
+
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
@@ -0,0 +1,181 @@
+package gods
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+type BinaryTestSuite struct {
+	suite.Suite
+}
+
+func eachOf(values ...interface{}) func(fn func(value interface{}) bool) {
+	return func(fn func(value interface{}) bool) {
+		for _, value := range values {
+			if !fn(value) {
+				return
+			}
+		}
+	}
+}
+
+func (suite *BinaryTestSuite) TestHeader() {
+	var buffer bytes.Buffer
+
+	n, err := WriteValues(&buffer, KindQueue, IntCodec, eachOf())
+	suite.NoError(err)
+	suite.Equal(int64(7), n)
+	suite.Equal([]byte{'G', 'O', 'D', 'S', BinaryVersion, byte(KindQueue), 0}, buffer.Bytes())
+}
+
+func (suite *BinaryTestSuite) TestValuesRoundTrip() {
+	var buffer bytes.Buffer
+
+	written, err := WriteValues(&buffer, KindList, IntCodec, eachOf(1, -2, 300))
+	suite.NoError(err)
+	suite.Equal(int64(buffer.Len()), written)
+
+	buffer.WriteString("trailing")
+
+	var values []interface{}
+
+	read, err := ReadValues(&buffer, KindList, IntCodec, func(value interface{}) {
+		values = append(values, value)
+	})
+	suite.NoError(err)
+	suite.Equal(written, read)
+	suite.Equal([]interface{}{1, -2, 300}, values)
+	suite.Equal("trailing", buffer.String())
+}
+
+func (suite *BinaryTestSuite) TestValuesFromPlainReader() {
+	var buffer bytes.Buffer
+
+	_, err := WriteValues(&buffer, KindStack, StringCodec, eachOf("a", "b"))
+	suite.NoError(err)
+
+	var values []interface{}
+
+	_, err = ReadValues(iotest.OneByteReader(&buffer), KindStack, StringCodec, func(value interface{}) {
+		values = append(values, value)
+	})
+	suite.NoError(err)
+	suite.Equal([]interface{}{"a", "b"}, values)
+}
+
+func (suite *BinaryTestSuite) TestEntriesRoundTrip() {
+	var buffer bytes.Buffer
+
+	_, err := WriteEntries(&buffer, KindMap, StringCodec, IntCodec, func(fn func(key, value interface{}) bool) {
+		_ = fn("a", 1) && fn("b", 2)
+	})
+	suite.NoError(err)
+
+	entries := map[interface{}]interface{}{}
+
+	_, err = ReadEntries(bytes.NewReader(buffer.Bytes()), KindMap, StringCodec, IntCodec, func(key, value interface{}) {
+		entries[key] = value
+	})
+	suite.NoError(err)
+	suite.Equal(map[interface{}]interface{}{"a": 1, "b": 2}, entries)
+}
+
+func (suite *BinaryTestSuite) TestEntriesMissingValue() {
+	var buffer bytes.Buffer
+
+	_, err := WriteValues(&buffer, KindMap, StringCodec, eachOf("lonely"))
+	suite.NoError(err)
+
+	_, err = ReadEntries(&buffer, KindMap, StringCodec, IntCodec, func(key, value interface{}) {})
+	suite.ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
+func (suite *BinaryTestSuite) TestInvalidStreams() {
+	var buffer bytes.Buffer
+
+	_, err := WriteValues(&buffer, KindList, IntCodec, eachOf(1, 2))
+	suite.NoError(err)
+
+	data := buffer.Bytes()
+	read := func(data []byte, kind Kind) error {
+		_, err := ReadValues(bytes.NewReader(data), kind, IntCodec, func(interface{}) {})
+		return err
+	}
+
+	suite.NoError(read(data, KindList))
+	suite.ErrorIs(read(data, KindQueue), ErrKindMismatch)
+	suite.ErrorIs(read(nil, KindList), ErrInvalidHeader)
+	suite.ErrorIs(read([]byte("JSON{}"), KindList), ErrInvalidHeader)
+
+	future := append([]byte{}, data...)
+	future[4] = BinaryVersion + 1
+	suite.ErrorIs(read(future, KindList), ErrUnsupportedVersion)
+
+	suite.ErrorIs(read(data[:len(data)-1], KindList), io.ErrUnexpectedEOF)
+	suite.ErrorIs(read(data[:len(data)-2], KindList), io.ErrUnexpectedEOF)
+
+	huge := append(append([]byte{}, data[:6]...), 0xff, 0xff, 0xff, 0xff, 0x0f)
+	suite.ErrorIs(read(huge, KindList), ErrRecordTooLarge)
+
+	// A length past math.MaxInt64 must not turn into a negative copy that
+	// silently decodes as an empty record.
+	forged := binary.AppendUvarint(append([]byte{}, data[:6]...), 1<<63+2)
+	forged = append(forged, data[6:]...)
+	suite.ErrorIs(read(forged, KindList), ErrRecordTooLarge)
+
+	oversized := binary.AppendUvarint(append([]byte{}, data[:6]...), MaxRecordSize+2)
+	suite.ErrorIs(read(oversized, KindList), ErrRecordTooLarge)
+
+	limit := binary.AppendUvarint(append([]byte{}, data[:6]...), MaxRecordSize+1)
+	suite.ErrorIs(read(limit, KindList), io.ErrUnexpectedEOF)
+}
+
+func (suite *BinaryTestSuite) TestWriteErrors() {
+	_, err := WriteValues(new(bytes.Buffer), KindList, IntCodec, eachOf("not an int"))
+	suite.Error(err)
+
+	broken := errors.New("disk full")
+	_, err = WriteValues(failingWriter{broken}, KindList, IntCodec, eachOf(1))
+	suite.ErrorIs(err, broken)
+}
+
+func (suite *BinaryTestSuite) TestStreamsWithoutBufferingEverything() {
+	reader, writer := io.Pipe()
+
+	go func() {
+		_, err := WriteValues(writer, KindList, IntCodec, func(fn func(value interface{}) bool) {
+			for i := 0; i < 100000; i++ {
+				if !fn(i) {
+					return
+				}
+			}
+		})
+		writer.CloseWithError(err)
+	}()
+
+	count := 0
+
+	_, err := ReadValues(reader, KindList, IntCodec, func(value interface{}) {
+		suite.Equal(count, value)
+		count++
+	})
+	suite.NoError(err)
+	suite.Equal(100000, count)
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (f failingWriter) Write(p []byte) (int, error) {
+	return 0, f.err
+}
+
+func TestBinaryTestSuite(t *testing.T) {
+	suite.Run(t, new(BinaryTestSuite))
+}
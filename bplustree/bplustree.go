@@ -0,0 +1,1054 @@
+// Package bplustree implements a file-backed B+tree: an ordered key-value
+// index that can be larger than memory. Entries live in fixed-size pages;
+// leaves are linked to their siblings for range scans, recently used pages
+// are kept decoded in an LRU cache, and every write is made durable through
+// a write-ahead log before the main file is touched.
+package bplustree
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/cache"
+	"github.com/kucuny/gods/tree"
+	"os"
+	"sort"
+)
+
+var (
+	ErrClosed       = errors.New("bplustree: tree is closed")
+	ErrCorrupt      = errors.New("bplustree: corrupt page")
+	ErrNotBPlusTree = errors.New("bplustree: file is not a B+tree")
+	ErrTooLarge     = errors.New("bplustree: entry is too large for the page size")
+	ErrPageSize     = fmt.Errorf("bplustree: page size must be between %d and %d", minPageSize, maxPageSize)
+)
+
+// step records which child a descent took, so splits and removals can walk
+// back up.
+type step struct {
+	node  *node
+	index int
+}
+
+// BPlusTree is an ordered map stored in a file. Its methods mirror the
+// in-memory ordered maps, but return an error as well because every call
+// may do I/O.
+//
+// Each Insert, Delete or Clear is a transaction: the changed pages are
+// appended to the write-ahead log at path+"-wal" and synced, then written
+// to the main file. Once the log passes the checkpoint size the main file is
+// synced and the log truncated. Open replays any committed transactions left
+// in the log, so a crash never leaves a half-applied write.
+//
+// Pages that become empty are returned to a free list and reused; partially
+// filled pages are not merged.
+type BPlusTree struct {
+	file     *os.File
+	wal      *wal
+	comparer tree.CompareFunc
+	opts     *Options
+	meta     meta
+	maxEntry int
+	cache    *cache.LRUCache
+	dirty    map[uint64]*node
+	freed    map[uint64]uint64
+	mutex    gods.Locker
+	err      error
+}
+
+// Open opens the tree stored at path, creating it if needed.
+func Open(path string, comparer tree.Comparer, options ...Option) (*BPlusTree, error) {
+	opts := NewOptions(options...)
+
+	if opts.PageSize < minPageSize || opts.PageSize > maxPageSize {
+		return nil, ErrPageSize
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	t := &BPlusTree{
+		file:     file,
+		comparer: tree.OrderOf(comparer, &gods.Options{Compare: opts.Compare}),
+		opts:     opts,
+		mutex:    gods.NewLocker(opts.Lock),
+	}
+
+	if err := t.open(path); err != nil {
+		file.Close()
+
+		if t.wal != nil {
+			t.wal.close()
+		}
+
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *BPlusTree) open(path string) error {
+	info, err := t.file.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	pageSize := t.opts.PageSize
+
+	if info.Size() == 0 {
+		t.meta = meta{pageSize: pageSize, pages: 1}
+
+		if err := t.writeMeta(); err != nil {
+			return err
+		}
+	} else {
+		header := make([]byte, 18)
+
+		if _, err := t.file.ReadAt(header, 0); err != nil {
+			return ErrNotBPlusTree
+		}
+
+		if [8]byte(header[5:13]) != fileMagic {
+			return ErrNotBPlusTree
+		}
+
+		pageSize = int(binary.BigEndian.Uint32(header[14:18]))
+
+		if pageSize < minPageSize || pageSize > maxPageSize {
+			return ErrNotBPlusTree
+		}
+	}
+
+	if t.wal, err = openWAL(path+"-wal", pageSize, !t.opts.NoSync); err != nil {
+		return err
+	}
+
+	if err := t.recover(); err != nil {
+		return err
+	}
+
+	page := make([]byte, pageSize)
+
+	if _, err := t.file.ReadAt(page, 0); err != nil {
+		return err
+	}
+
+	if t.meta, err = decodeMeta(page); err != nil {
+		return err
+	}
+
+	t.maxEntry = (pageSize - leafHeaderSize) / 4
+	t.cache = t.newCache()
+
+	return nil
+}
+
+// recover copies the committed transactions in the log into the main file.
+func (t *BPlusTree) recover() error {
+	if t.wal.size == 0 {
+		return nil
+	}
+
+	err := t.wal.replay(func(id uint64, page []byte) error {
+		_, err := t.file.WriteAt(page, int64(id)*int64(len(page)))
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return t.checkpoint()
+}
+
+func (t *BPlusTree) writeMeta() error {
+	page := make([]byte, t.meta.pageSize)
+	t.meta.encode(page)
+
+	if _, err := t.file.WriteAt(page, 0); err != nil {
+		return err
+	}
+
+	return t.file.Sync()
+}
+
+// Close checkpoints the log and closes the files.
+func (t *BPlusTree) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.err == ErrClosed {
+		return ErrClosed
+	}
+
+	err := t.err
+
+	if err == nil {
+		err = t.checkpoint()
+	}
+
+	t.err = ErrClosed
+
+	return errors.Join(err, t.wal.close(), t.file.Close())
+}
+
+// Checkpoint syncs the main file and truncates the write-ahead log.
+func (t *BPlusTree) Checkpoint() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.err != nil {
+		return t.err
+	}
+
+	return t.checkpoint()
+}
+
+func (t *BPlusTree) PageSize() int {
+	return t.meta.pageSize
+}
+
+func (t *BPlusTree) Len() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return int(t.meta.count)
+}
+
+func (t *BPlusTree) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Insert sets key to value and reports whether the key is new.
+func (t *BPlusTree) Insert(key, value interface{}) (bool, error) {
+	rawKey, err := t.opts.KeyCodec.Marshal(key)
+
+	if err != nil {
+		return false, err
+	}
+
+	rawValue, err := t.opts.Codec.Marshal(value)
+
+	if err != nil {
+		return false, err
+	}
+
+	if leafEntrySize(rawKey, rawValue) > t.maxEntry {
+		return false, ErrTooLarge
+	}
+
+	// Store the key as it will read back from disk, so that cached and
+	// reloaded pages compare the same way.
+	if key, err = t.opts.KeyCodec.Unmarshal(rawKey); err != nil {
+		return false, err
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	inserted := false
+
+	err = t.update(func() error {
+		if t.meta.root == 0 {
+			root, err := t.allocate(true)
+
+			if err != nil {
+				return err
+			}
+
+			t.meta.root = root.id
+		}
+
+		leaf, path, err := t.descend(key)
+
+		if err != nil {
+			return err
+		}
+
+		i, found := t.find(leaf, key)
+		t.touch(leaf)
+
+		if found {
+			leaf.values[i] = rawValue
+		} else {
+			leaf.keys = insertAt(leaf.keys, i, key)
+			leaf.rawKeys = insertAt(leaf.rawKeys, i, rawKey)
+			leaf.values = insertAt(leaf.values, i, rawValue)
+			t.meta.count++
+			inserted = true
+		}
+
+		// A larger value can overflow the page as well as a new entry.
+		return t.split(leaf, path)
+	})
+
+	return inserted, err
+}
+
+func (t *BPlusTree) Get(key interface{}) (interface{}, bool, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.err != nil {
+		return nil, false, t.err
+	}
+
+	if t.meta.root == 0 {
+		return nil, false, nil
+	}
+
+	leaf, _, err := t.descend(key)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	i, found := t.find(leaf, key)
+
+	if !found {
+		return nil, false, nil
+	}
+
+	value, err := t.opts.Codec.Unmarshal(leaf.values[i])
+
+	return value, err == nil, err
+}
+
+func (t *BPlusTree) Contains(key interface{}) (bool, error) {
+	_, ok, err := t.Get(key)
+	return ok, err
+}
+
+// Delete removes key and reports whether it was present.
+func (t *BPlusTree) Delete(key interface{}) (bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	deleted := false
+
+	err := t.update(func() error {
+		if t.meta.root == 0 {
+			return nil
+		}
+
+		leaf, path, err := t.descend(key)
+
+		if err != nil {
+			return err
+		}
+
+		i, found := t.find(leaf, key)
+
+		if !found {
+			return nil
+		}
+
+		t.touch(leaf)
+		leaf.keys = removeAt(leaf.keys, i)
+		leaf.rawKeys = removeAt(leaf.rawKeys, i)
+		leaf.values = removeAt(leaf.values, i)
+		t.meta.count--
+		deleted = true
+
+		return t.prune(leaf, path)
+	})
+
+	return deleted, err
+}
+
+// Clear removes every entry and shrinks the file to its meta page.
+func (t *BPlusTree) Clear() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	err := t.update(func() error {
+		t.meta.root, t.meta.count, t.meta.pages, t.meta.free = 0, 0, 1, 0
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	t.cache = t.newCache()
+
+	return t.file.Truncate(int64(t.meta.pageSize))
+}
+
+func (t *BPlusTree) Keys() ([]interface{}, error) {
+	keys := make([]interface{}, 0, t.Len())
+
+	err := t.Range(nil, nil, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys, err
+}
+
+func (t *BPlusTree) Values() ([]interface{}, error) {
+	values := make([]interface{}, 0, t.Len())
+
+	err := t.Range(nil, nil, func(key, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values, err
+}
+
+func (t *BPlusTree) Each(fn func(key interface{}) bool) error {
+	return t.Range(nil, nil, func(key, value interface{}) bool {
+		return fn(key)
+	})
+}
+
+func (t *BPlusTree) First() (interface{}, bool, error) {
+	return t.edge(false)
+}
+
+func (t *BPlusTree) Last() (interface{}, bool, error) {
+	return t.edge(true)
+}
+
+// Range calls fn in key order for each entry with from <= key < to until fn
+// returns false, following the leaf sibling links. A nil bound is
+// unbounded. fn must not modify the tree.
+func (t *BPlusTree) Range(from, to interface{}, fn func(key, value interface{}) bool) error {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.err != nil {
+		return t.err
+	}
+
+	if t.meta.root == 0 {
+		return nil
+	}
+
+	leaf, _, err := t.descend(from)
+
+	if err != nil {
+		return err
+	}
+
+	i := 0
+
+	if from != nil {
+		i, _ = t.find(leaf, from)
+	}
+
+	for {
+		for ; i < len(leaf.keys); i++ {
+			if to != nil && t.comparer.Compare(leaf.keys[i], to) >= 0 {
+				return nil
+			}
+
+			value, err := t.opts.Codec.Unmarshal(leaf.values[i])
+
+			if err != nil {
+				return err
+			}
+
+			if !fn(leaf.keys[i], value) {
+				return nil
+			}
+		}
+
+		if leaf.next == 0 {
+			return nil
+		}
+
+		if leaf, err = t.node(leaf.next); err != nil {
+			return err
+		}
+
+		i = 0
+	}
+}
+
+func (t *BPlusTree) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "BPlusTree", t.Len(), func(fn func(key, value interface{}) bool) {
+		_ = t.Range(nil, nil, fn)
+	})
+}
+
+func (t *BPlusTree) String() string {
+	return fmt.Sprint(t)
+}
+
+// Validate checks key order, separators, uniform leaf depth, sibling links,
+// page sizes, the free list and the count.
+func (t *BPlusTree) Validate() error {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.err != nil {
+		return t.err
+	}
+
+	seen := make(map[uint64]bool)
+	var leaves []*node
+	var count uint64
+
+	if t.meta.root != 0 {
+		var walk func(id uint64, lower, upper interface{}, depth int) (int, error)
+
+		walk = func(id uint64, lower, upper interface{}, depth int) (int, error) {
+			if id == 0 || id >= t.meta.pages || seen[id] {
+				return 0, fmt.Errorf("bplustree: page %d is out of range or reachable twice", id)
+			}
+
+			seen[id] = true
+
+			n, err := t.node(id)
+
+			if err != nil {
+				return 0, err
+			}
+
+			if n.size() > t.meta.pageSize {
+				return 0, fmt.Errorf("bplustree: page %d overflows", id)
+			}
+
+			for i, key := range n.keys {
+				if lower != nil && t.comparer.Compare(key, lower) < 0 {
+					return 0, fmt.Errorf("bplustree: %v on page %d is below %v", key, id, lower)
+				}
+
+				if upper != nil && t.comparer.Compare(key, upper) >= 0 {
+					return 0, fmt.Errorf("bplustree: %v on page %d is not below %v", key, id, upper)
+				}
+
+				if i > 0 && t.comparer.Compare(n.keys[i-1], key) >= 0 {
+					return 0, fmt.Errorf("bplustree: keys out of order on page %d", id)
+				}
+			}
+
+			if n.leaf {
+				if len(n.keys) == 0 {
+					return 0, fmt.Errorf("bplustree: leaf %d is empty", id)
+				}
+
+				leaves = append(leaves, n)
+				count += uint64(len(n.keys))
+
+				return depth, nil
+			}
+
+			if len(n.children) != len(n.keys)+1 || len(n.keys) == 0 && id == t.meta.root {
+				return 0, fmt.Errorf("bplustree: page %d has %d keys and %d children", id, len(n.keys), len(n.children))
+			}
+
+			leafDepth := -1
+
+			for i, child := range n.children {
+				low, high := lower, upper
+
+				if i > 0 {
+					low = n.keys[i-1]
+				}
+
+				if i < len(n.keys) {
+					high = n.keys[i]
+				}
+
+				d, err := walk(child, low, high, depth+1)
+
+				if err != nil {
+					return 0, err
+				}
+
+				if leafDepth != -1 && d != leafDepth {
+					return 0, fmt.Errorf("bplustree: leaves at depths %d and %d", leafDepth, d)
+				}
+
+				leafDepth = d
+			}
+
+			return leafDepth, nil
+		}
+
+		if _, err := walk(t.meta.root, nil, nil, 0); err != nil {
+			return err
+		}
+	}
+
+	for i, leaf := range leaves {
+		var prev, next uint64
+
+		if i > 0 {
+			prev = leaves[i-1].id
+		}
+
+		if i < len(leaves)-1 {
+			next = leaves[i+1].id
+		}
+
+		if leaf.prev != prev || leaf.next != next {
+			return fmt.Errorf("bplustree: leaf %d is linked to %d and %d, expected %d and %d", leaf.id, leaf.prev, leaf.next, prev, next)
+		}
+	}
+
+	if count != t.meta.count {
+		return fmt.Errorf("bplustree: count is %d but %d entries are reachable", t.meta.count, count)
+	}
+
+	for id := t.meta.free; id != 0; {
+		if id >= t.meta.pages || seen[id] {
+			return fmt.Errorf("bplustree: free page %d is out of range or in use", id)
+		}
+
+		seen[id] = true
+
+		next, err := t.readFree(id)
+
+		if err != nil {
+			return err
+		}
+
+		id = next
+	}
+
+	return nil
+}
+
+// update runs fn as one transaction. If fn or the commit fails before the
+// log record is durable, the in-memory state is rolled back; if the main
+// file cannot be written afterwards the tree refuses further calls until it
+// is reopened, which replays the log.
+func (t *BPlusTree) update(fn func() error) error {
+	if t.err != nil {
+		return t.err
+	}
+
+	saved := t.meta
+	t.dirty = make(map[uint64]*node)
+	t.freed = make(map[uint64]uint64)
+
+	err := fn()
+
+	if err == nil {
+		err = t.commit(saved)
+	}
+
+	if err != nil && t.err == nil {
+		t.meta = saved
+		t.cache = t.newCache()
+	}
+
+	t.dirty, t.freed = nil, nil
+
+	return err
+}
+
+func (t *BPlusTree) commit(saved meta) error {
+	if len(t.dirty) == 0 && len(t.freed) == 0 && t.meta == saved {
+		return nil
+	}
+
+	pages := make([]walPage, 0, len(t.dirty)+len(t.freed)+1)
+
+	for id, n := range t.dirty {
+		page := make([]byte, t.meta.pageSize)
+		n.encode(page)
+		pages = append(pages, walPage{id: id, data: page})
+	}
+
+	for id, next := range t.freed {
+		page := make([]byte, t.meta.pageSize)
+		encodeFree(page, next)
+		pages = append(pages, walPage{id: id, data: page})
+	}
+
+	page := make([]byte, t.meta.pageSize)
+	t.meta.encode(page)
+	pages = append(pages, walPage{id: 0, data: page})
+
+	if err := t.wal.append(pages); err != nil {
+		return err
+	}
+
+	for _, page := range pages {
+		if _, err := t.file.WriteAt(page.data, int64(page.id)*int64(t.meta.pageSize)); err != nil {
+			t.err = err
+			return err
+		}
+	}
+
+	for id, n := range t.dirty {
+		t.cache.Put(id, n)
+	}
+
+	for id := range t.freed {
+		t.cache.Remove(id)
+	}
+
+	if t.wal.size >= t.opts.CheckpointSize {
+		if err := t.checkpoint(); err != nil {
+			t.err = err
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *BPlusTree) checkpoint() error {
+	if !t.opts.NoSync {
+		if err := t.file.Sync(); err != nil {
+			return err
+		}
+	}
+
+	return t.wal.reset()
+}
+
+func (t *BPlusTree) newCache() *cache.LRUCache {
+	return cache.NewLRUCache(t.opts.CacheSize)
+}
+
+// node returns the page with the given id, from the current transaction,
+// the cache or the file.
+func (t *BPlusTree) node(id uint64) (*node, error) {
+	if n, ok := t.dirty[id]; ok {
+		return n, nil
+	}
+
+	if n, ok := t.cache.Get(id); ok {
+		return n.(*node), nil
+	}
+
+	page := make([]byte, t.meta.pageSize)
+
+	if _, err := t.file.ReadAt(page, int64(id)*int64(t.meta.pageSize)); err != nil {
+		return nil, err
+	}
+
+	n, err := decodeNode(id, page, t.opts.KeyCodec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.cache.Put(id, n)
+
+	return n, nil
+}
+
+func (t *BPlusTree) readFree(id uint64) (uint64, error) {
+	page := make([]byte, t.meta.pageSize)
+
+	if _, err := t.file.ReadAt(page, int64(id)*int64(t.meta.pageSize)); err != nil {
+		return 0, err
+	}
+
+	return decodeFree(id, page)
+}
+
+// touch marks n as changed by the current transaction.
+func (t *BPlusTree) touch(n *node) {
+	t.dirty[n.id] = n
+}
+
+// allocate takes a page from the free list, or grows the file.
+func (t *BPlusTree) allocate(leaf bool) (*node, error) {
+	id := t.meta.free
+
+	if id == 0 {
+		id = t.meta.pages
+		t.meta.pages++
+	} else if next, ok := t.freed[id]; ok {
+		delete(t.freed, id)
+		t.meta.free = next
+	} else {
+		next, err := t.readFree(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		t.meta.free = next
+	}
+
+	n := &node{id: id, leaf: leaf}
+	t.touch(n)
+
+	return n, nil
+}
+
+func (t *BPlusTree) release(n *node) {
+	delete(t.dirty, n.id)
+	t.freed[n.id] = t.meta.free
+	t.meta.free = n.id
+}
+
+// descend returns the leaf that holds or would hold key, and the path to
+// it. A nil key leads to the first leaf.
+func (t *BPlusTree) descend(key interface{}) (*node, []step, error) {
+	var path []step
+
+	n, err := t.node(t.meta.root)
+
+	for err == nil && !n.leaf {
+		i := 0
+
+		if key != nil {
+			i = sort.Search(len(n.keys), func(i int) bool {
+				return t.comparer.Compare(n.keys[i], key) > 0
+			})
+		}
+
+		path = append(path, step{node: n, index: i})
+		n, err = t.node(n.children[i])
+	}
+
+	return n, path, err
+}
+
+// edge returns the smallest or, if last is set, the largest key.
+func (t *BPlusTree) edge(last bool) (interface{}, bool, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.err != nil {
+		return nil, false, t.err
+	}
+
+	if t.meta.root == 0 {
+		return nil, false, nil
+	}
+
+	n, err := t.node(t.meta.root)
+
+	for err == nil && !n.leaf {
+		child := n.children[0]
+
+		if last {
+			child = n.children[len(n.children)-1]
+		}
+
+		n, err = t.node(child)
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if last {
+		return n.keys[len(n.keys)-1], true, nil
+	}
+
+	return n.keys[0], true, nil
+}
+
+// find returns the index of the first key in leaf not less than key and
+// whether it equals key.
+func (t *BPlusTree) find(leaf *node, key interface{}) (int, bool) {
+	i := sort.Search(len(leaf.keys), func(i int) bool {
+		return t.comparer.Compare(leaf.keys[i], key) >= 0
+	})
+
+	return i, i < len(leaf.keys) && t.comparer.Compare(leaf.keys[i], key) == 0
+}
+
+// split divides n while it overflows its page, pushing separators up the
+// path and growing a new root when the old one splits.
+func (t *BPlusTree) split(n *node, path []step) error {
+	for n.size() > t.meta.pageSize {
+		middle := t.middle(n)
+		right, err := t.allocate(n.leaf)
+
+		if err != nil {
+			return err
+		}
+
+		var key interface{}
+		var rawKey []byte
+
+		if n.leaf {
+			right.keys = append(right.keys, n.keys[middle:]...)
+			right.rawKeys = append(right.rawKeys, n.rawKeys[middle:]...)
+			right.values = append(right.values, n.values[middle:]...)
+			n.keys, n.rawKeys, n.values = n.keys[:middle:middle], n.rawKeys[:middle:middle], n.values[:middle:middle]
+			key, rawKey = right.keys[0], right.rawKeys[0]
+
+			right.prev, right.next = n.id, n.next
+
+			if n.next != 0 {
+				next, err := t.node(n.next)
+
+				if err != nil {
+					return err
+				}
+
+				t.touch(next)
+				next.prev = right.id
+			}
+
+			n.next = right.id
+		} else {
+			key, rawKey = n.keys[middle], n.rawKeys[middle]
+			right.keys = append(right.keys, n.keys[middle+1:]...)
+			right.rawKeys = append(right.rawKeys, n.rawKeys[middle+1:]...)
+			right.children = append(right.children, n.children[middle+1:]...)
+			n.keys, n.rawKeys, n.children = n.keys[:middle:middle], n.rawKeys[:middle:middle], n.children[:middle+1:middle+1]
+		}
+
+		if len(path) == 0 {
+			root, err := t.allocate(false)
+
+			if err != nil {
+				return err
+			}
+
+			root.keys = []interface{}{key}
+			root.rawKeys = [][]byte{rawKey}
+			root.children = []uint64{n.id, right.id}
+			t.meta.root = root.id
+
+			return nil
+		}
+
+		parent := path[len(path)-1]
+		path = path[:len(path)-1]
+
+		t.touch(parent.node)
+		parent.node.keys = insertAt(parent.node.keys, parent.index, key)
+		parent.node.rawKeys = insertAt(parent.node.rawKeys, parent.index, rawKey)
+		parent.node.children = insertAt(parent.node.children, parent.index+1, right.id)
+
+		n = parent.node
+	}
+
+	return nil
+}
+
+// middle picks the entry to split n at so that both halves hold about the
+// same number of bytes. Leaves keep at least one entry on each side;
+// internal nodes also lift the middle entry, so they keep one on each side
+// of it.
+func (t *BPlusTree) middle(n *node) int {
+	total := 0
+
+	for i := range n.rawKeys {
+		total += n.entrySize(i)
+	}
+
+	low, high := 1, len(n.rawKeys)-1
+
+	if !n.leaf {
+		high--
+	}
+
+	half, middle := 0, low
+
+	for i := range n.rawKeys {
+		if half += n.entrySize(i); half*2 >= total {
+			middle = i
+			break
+		}
+	}
+
+	return min(max(middle, low), high)
+}
+
+// prune frees n if the deletion emptied it, unlinks it from its siblings
+// and parent, and repeats for parents left without children. Finally it
+// shrinks the root while it is empty or has a single child.
+func (t *BPlusTree) prune(n *node, path []step) error {
+	for len(path) > 0 && (n.leaf && len(n.keys) == 0 || !n.leaf && len(n.children) == 0) {
+		if n.leaf {
+			if err := t.unlink(n); err != nil {
+				return err
+			}
+		}
+
+		t.release(n)
+
+		parent := path[len(path)-1]
+		path = path[:len(path)-1]
+
+		t.touch(parent.node)
+		parent.node.children = removeAt(parent.node.children, parent.index)
+
+		if len(parent.node.keys) > 0 {
+			i := max(parent.index-1, 0)
+			parent.node.keys = removeAt(parent.node.keys, i)
+			parent.node.rawKeys = removeAt(parent.node.rawKeys, i)
+		}
+
+		n = parent.node
+	}
+
+	for t.meta.root != 0 {
+		root, err := t.node(t.meta.root)
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case root.leaf && len(root.keys) == 0, !root.leaf && len(root.children) == 0:
+			t.release(root)
+			t.meta.root = 0
+		case !root.leaf && len(root.children) == 1:
+			t.release(root)
+			t.meta.root = root.children[0]
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (t *BPlusTree) unlink(leaf *node) error {
+	if leaf.prev != 0 {
+		prev, err := t.node(leaf.prev)
+
+		if err != nil {
+			return err
+		}
+
+		t.touch(prev)
+		prev.next = leaf.next
+	}
+
+	if leaf.next != 0 {
+		next, err := t.node(leaf.next)
+
+		if err != nil {
+			return err
+		}
+
+		t.touch(next)
+		next.prev = leaf.prev
+	}
+
+	return nil
+}
+
+func insertAt[T any](s []T, i int, value T) []T {
+	var zero T
+
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = value
+
+	return s
+}
+
+func removeAt[T any](s []T, i int) []T {
+	var zero T
+
+	copy(s[i:], s[i+1:])
+	s[len(s)-1] = zero
+
+	return s[:len(s)-1]
+}
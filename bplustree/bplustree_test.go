@@ -0,0 +1,424 @@
+package bplustree
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/tree"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type BPlusTreeTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func (suite *BPlusTreeTestSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "index")
+}
+
+func (suite *BPlusTreeTestSuite) open(options ...Option) *BPlusTree {
+	options = append([]Option{
+		WithPageSize(minPageSize),
+		WithKeyCodec(gods.IntCodec),
+		WithCodec(gods.StringCodec),
+	}, options...)
+
+	t, err := Open(suite.path, tree.IntegerComparer, options...)
+	suite.Require().NoError(err)
+
+	return t
+}
+
+func (suite *BPlusTreeTestSuite) TestInsertGetDelete() {
+	t := suite.open()
+	defer t.Close()
+
+	inserted, err := t.Insert(5, "a")
+	suite.NoError(err)
+	suite.True(inserted)
+
+	inserted, err = t.Insert(5, "b")
+	suite.NoError(err)
+	suite.False(inserted)
+	suite.Equal(1, t.Len())
+
+	value, ok, err := t.Get(5)
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Equal("b", value)
+
+	_, ok, err = t.Get(6)
+	suite.NoError(err)
+	suite.False(ok)
+
+	deleted, err := t.Delete(5)
+	suite.NoError(err)
+	suite.True(deleted)
+
+	deleted, err = t.Delete(5)
+	suite.NoError(err)
+	suite.False(deleted)
+	suite.True(t.IsEmpty())
+	suite.NoError(t.Validate())
+}
+
+func (suite *BPlusTreeTestSuite) TestWithCompare() {
+	t, err := Open(suite.path, nil, WithKeyCodec(gods.IntCodec), WithCodec(gods.StringCodec),
+		WithCompare(func(a, b int) int { return cmp.Compare(b, a) }))
+	suite.Require().NoError(err)
+	defer t.Close()
+
+	for _, key := range []int{1, 3, 2} {
+		_, err := t.Insert(key, "")
+		suite.NoError(err)
+	}
+
+	keys, err := t.Keys()
+	suite.NoError(err)
+	suite.Equal([]interface{}{3, 2, 1}, keys)
+}
+
+func (suite *BPlusTreeTestSuite) TestRandomizedAgainstModel() {
+	t := suite.open(WithCacheSize(8), WithNoSync())
+	defer t.Close()
+
+	r := rand.New(rand.NewSource(1))
+	model := make(map[int]string)
+
+	for i := 0; i < 4000; i++ {
+		key := r.Intn(1500)
+
+		if r.Intn(3) == 0 {
+			_, present := model[key]
+			deleted, err := t.Delete(key)
+			suite.Require().NoError(err)
+			suite.Equal(present, deleted)
+			delete(model, key)
+		} else {
+			value := strings.Repeat("v", r.Intn(40)) + fmt.Sprint(i)
+			_, present := model[key]
+			inserted, err := t.Insert(key, value)
+			suite.Require().NoError(err)
+			suite.Equal(!present, inserted)
+			model[key] = value
+		}
+
+		if i%500 == 0 {
+			suite.Require().NoError(t.Validate())
+		}
+	}
+
+	suite.Require().NoError(t.Validate())
+	suite.checkModel(t, model)
+}
+
+func (suite *BPlusTreeTestSuite) checkModel(t *BPlusTree, model map[int]string) {
+	keys := make([]int, 0, len(model))
+
+	for key := range model {
+		keys = append(keys, key)
+	}
+
+	sort.Ints(keys)
+
+	suite.Equal(len(model), t.Len())
+
+	i := 0
+
+	suite.NoError(t.Range(nil, nil, func(key, value interface{}) bool {
+		suite.Equal(keys[i], key)
+		suite.Equal(model[keys[i]], value)
+		i++
+		return true
+	}))
+
+	suite.Equal(len(keys), i)
+}
+
+func (suite *BPlusTreeTestSuite) TestRangeFollowsSiblings() {
+	t := suite.open()
+	defer t.Close()
+
+	for i := 0; i < 500; i++ {
+		_, err := t.Insert(i*2, fmt.Sprint(i*2))
+		suite.Require().NoError(err)
+	}
+
+	collect := func(from, to interface{}, limit int) []interface{} {
+		var visited []interface{}
+
+		suite.NoError(t.Range(from, to, func(key, value interface{}) bool {
+			visited = append(visited, key)
+			return len(visited) < limit
+		}))
+
+		return visited
+	}
+
+	suite.Equal([]interface{}{10, 12, 14}, collect(9, 15, 100))
+	suite.Equal([]interface{}{0, 2}, collect(nil, 3, 100))
+	suite.Equal([]interface{}{996, 998}, collect(995, nil, 100))
+	suite.Equal([]interface{}{400, 402}, collect(400, nil, 2))
+	suite.Empty(collect(1000, nil, 100))
+	suite.Len(collect(nil, nil, 1000), 500)
+
+	first, ok, err := t.First()
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Equal(0, first)
+
+	last, ok, err := t.Last()
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Equal(998, last)
+
+	keys, err := t.Keys()
+	suite.NoError(err)
+	suite.Len(keys, 500)
+
+	values, err := t.Values()
+	suite.NoError(err)
+	suite.Equal("998", values[499])
+}
+
+func (suite *BPlusTreeTestSuite) TestPersistence() {
+	t := suite.open()
+	model := make(map[int]string)
+
+	for i := 0; i < 1000; i++ {
+		_, err := t.Insert(i, fmt.Sprint(i))
+		suite.Require().NoError(err)
+		model[i] = fmt.Sprint(i)
+	}
+
+	for i := 0; i < 1000; i += 3 {
+		_, err := t.Delete(i)
+		suite.Require().NoError(err)
+		delete(model, i)
+	}
+
+	suite.NoError(t.Close())
+	suite.ErrorIs(t.Close(), ErrClosed)
+
+	_, _, err := t.Get(1)
+	suite.ErrorIs(err, ErrClosed)
+
+	t = suite.open(WithPageSize(8192))
+	defer t.Close()
+
+	suite.Equal(minPageSize, t.PageSize())
+	suite.NoError(t.Validate())
+	suite.checkModel(t, model)
+}
+
+func (suite *BPlusTreeTestSuite) TestPagesAreReused() {
+	t := suite.open()
+	defer t.Close()
+
+	for i := 0; i < 500; i++ {
+		t.Insert(i, "value")
+	}
+
+	pages := t.meta.pages
+
+	for i := 0; i < 500; i++ {
+		t.Delete(i)
+	}
+
+	suite.NoError(t.Validate())
+	suite.Equal(uint64(0), t.meta.root)
+
+	for i := 0; i < 500; i++ {
+		t.Insert(i, "value")
+	}
+
+	suite.NoError(t.Validate())
+	suite.LessOrEqual(t.meta.pages, pages+1)
+}
+
+func (suite *BPlusTreeTestSuite) TestClear() {
+	t := suite.open()
+	defer t.Close()
+
+	for i := 0; i < 300; i++ {
+		t.Insert(i, "value")
+	}
+
+	suite.NoError(t.Clear())
+	suite.True(t.IsEmpty())
+	suite.NoError(t.Validate())
+
+	info, err := os.Stat(suite.path)
+	suite.NoError(err)
+	suite.Equal(int64(minPageSize), info.Size())
+
+	t.Insert(1, "one")
+
+	value, _, _ := t.Get(1)
+	suite.Equal("one", value)
+}
+
+func (suite *BPlusTreeTestSuite) TestRecoveryReplaysLog() {
+	t := suite.open(WithCheckpointSize(1 << 30))
+	model := make(map[int]string)
+
+	for i := 0; i < 300; i++ {
+		t.Insert(i, fmt.Sprint(i))
+		model[i] = fmt.Sprint(i)
+	}
+
+	// Simulate a crash after the log was synced but before any page reached
+	// the main file: keep the log and roll the main file back to empty.
+	wal, err := os.ReadFile(suite.path + "-wal")
+	suite.Require().NoError(err)
+	suite.NotEmpty(wal)
+
+	empty := make([]byte, minPageSize)
+	(&meta{pageSize: minPageSize, pages: 1}).encode(empty)
+
+	crashed := filepath.Join(suite.T().TempDir(), "crashed")
+	suite.Require().NoError(os.WriteFile(crashed, empty, 0o644))
+	suite.Require().NoError(os.WriteFile(crashed+"-wal", append(wal, "torn record"...), 0o644))
+
+	t.Close()
+
+	recovered, err := Open(crashed, tree.IntegerComparer, WithKeyCodec(gods.IntCodec), WithCodec(gods.StringCodec))
+	suite.Require().NoError(err)
+	defer recovered.Close()
+
+	suite.NoError(recovered.Validate())
+	suite.checkModel(recovered, model)
+
+	info, err := os.Stat(crashed + "-wal")
+	suite.NoError(err)
+	suite.Zero(info.Size())
+}
+
+func (suite *BPlusTreeTestSuite) TestCheckpoint() {
+	t := suite.open(WithCheckpointSize(1 << 30))
+	defer t.Close()
+
+	t.Insert(1, "one")
+	suite.NotZero(t.wal.size)
+	suite.NoError(t.Checkpoint())
+	suite.Zero(t.wal.size)
+
+	small := suite.open(WithCheckpointSize(1))
+	defer small.Close()
+
+	small.Insert(2, "two")
+	suite.Zero(small.wal.size)
+}
+
+func (suite *BPlusTreeTestSuite) TestTooLarge() {
+	t := suite.open()
+	defer t.Close()
+
+	_, err := t.Insert(1, strings.Repeat("x", minPageSize))
+	suite.ErrorIs(err, ErrTooLarge)
+	suite.True(t.IsEmpty())
+
+	_, err = t.Insert("not an int", "x")
+	suite.Error(err)
+}
+
+func (suite *BPlusTreeTestSuite) TestOpenErrors() {
+	_, err := Open(suite.path, tree.IntegerComparer, WithPageSize(100))
+	suite.ErrorIs(err, ErrPageSize)
+
+	suite.Require().NoError(os.WriteFile(suite.path, []byte("not a tree at all, just some text"), 0o644))
+
+	_, err = Open(suite.path, tree.IntegerComparer)
+	suite.ErrorIs(err, ErrNotBPlusTree)
+}
+
+func (suite *BPlusTreeTestSuite) TestCorruptPage() {
+	t := suite.open()
+
+	for i := 0; i < 100; i++ {
+		t.Insert(i, "value")
+	}
+
+	root := t.meta.root
+	suite.NoError(t.Close())
+
+	file, err := os.OpenFile(suite.path, os.O_RDWR, 0)
+	suite.Require().NoError(err)
+	_, err = file.WriteAt([]byte{0xff, 0xff}, int64(root)*minPageSize+20)
+	suite.Require().NoError(err)
+	suite.Require().NoError(file.Close())
+
+	t = suite.open()
+	defer t.Close()
+
+	_, _, err = t.Get(1)
+	suite.ErrorIs(err, ErrCorrupt)
+}
+
+func (suite *BPlusTreeTestSuite) TestStringKeys() {
+	t, err := Open(suite.path, tree.StringComparer, WithKeyCodec(gods.StringCodec), WithCodec(gods.IntCodec))
+	suite.Require().NoError(err)
+	defer t.Close()
+
+	for i, word := range []string{"pear", "apple", "fig"} {
+		t.Insert(word, i)
+	}
+
+	keys, err := t.Keys()
+	suite.NoError(err)
+	suite.Equal([]interface{}{"apple", "fig", "pear"}, keys)
+	suite.Equal("BPlusTree[apple:1 fig:2 pear:0]", t.String())
+}
+
+func (suite *BPlusTreeTestSuite) TestConcurrentReaders() {
+	t := suite.open(WithCacheSize(4))
+	defer t.Close()
+
+	for i := 0; i < 500; i++ {
+		t.Insert(i, fmt.Sprint(i))
+	}
+
+	done := make(chan error)
+
+	for r := 0; r < 4; r++ {
+		go func(r int) {
+			for i := r; i < 500; i += 4 {
+				value, ok, err := t.Get(i)
+
+				if err == nil && (!ok || value != fmt.Sprint(i)) {
+					err = errors.New("wrong value")
+				}
+
+				if err != nil {
+					done <- err
+					return
+				}
+			}
+
+			done <- nil
+		}(r)
+	}
+
+	for i := 500; i < 600; i++ {
+		t.Insert(i, fmt.Sprint(i))
+	}
+
+	for r := 0; r < 4; r++ {
+		suite.NoError(<-done)
+	}
+}
+
+func TestBPlusTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(BPlusTreeTestSuite))
+}
+
+var _ io.Closer = (*BPlusTree)(nil)
@@ -0,0 +1,105 @@
+package bplustree
+
+import (
+	"github.com/kucuny/gods"
+)
+
+const (
+	DefaultPageSize       = 4096
+	DefaultCacheSize      = 1024
+	DefaultCheckpointSize = 4 << 20
+
+	minPageSize = 512
+	maxPageSize = 65536
+)
+
+type Options struct {
+	PageSize       int
+	CacheSize      int
+	CheckpointSize int64
+	NoSync         bool
+	Lock           gods.LockMode
+	KeyCodec       gods.Codec
+	Codec          gods.Codec
+	Compare        func(a, b interface{}) int
+}
+
+type Option func(options *Options)
+
+// WithPageSize sets the page size of a new file. An existing file keeps the
+// page size it was created with.
+func WithPageSize(size int) Option {
+	return func(options *Options) {
+		options.PageSize = size
+	}
+}
+
+// WithCacheSize sets how many decoded pages the LRU page cache keeps.
+func WithCacheSize(pages int) Option {
+	return func(options *Options) {
+		options.CacheSize = pages
+	}
+}
+
+// WithCheckpointSize sets how large the write-ahead log may grow before its
+// pages are synced to the main file and the log is truncated.
+func WithCheckpointSize(bytes int64) Option {
+	return func(options *Options) {
+		options.CheckpointSize = bytes
+	}
+}
+
+// WithNoSync skips every fsync. Writes become much faster, but a crash of
+// the machine (not just the process) may lose or corrupt data.
+func WithNoSync() Option {
+	return func(options *Options) {
+		options.NoSync = true
+	}
+}
+
+func WithLock(mode gods.LockMode) Option {
+	return func(options *Options) {
+		options.Lock = mode
+	}
+}
+
+// WithKeyCodec sets the codec keys are stored with. Keys are compared after
+// decoding, so the codec only has to round-trip them.
+func WithKeyCodec(codec gods.Codec) Option {
+	return func(options *Options) {
+		options.KeyCodec = codec
+	}
+}
+
+func WithCodec(codec gods.Codec) Option {
+	return func(options *Options) {
+		options.Codec = codec
+	}
+}
+
+// WithCompare orders keys by a three-way function such as cmp.Compare[int]
+// instead of the comparer passed to Open, which may then be nil.
+func WithCompare[T any](compare func(a, b T) int) Option {
+	order := gods.NewOptions(gods.WithCompare(compare)).Compare
+
+	return func(options *Options) {
+		options.Compare = order
+	}
+}
+
+func NewOptions(options ...Option) *Options {
+	opts := &Options{
+		PageSize:       DefaultPageSize,
+		CacheSize:      DefaultCacheSize,
+		CheckpointSize: DefaultCheckpointSize,
+		Lock:           gods.RWMutexLock,
+		KeyCodec:       gods.GobCodec[interface{}](),
+		Codec:          gods.GobCodec[interface{}](),
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
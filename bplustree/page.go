@@ -0,0 +1,275 @@
+package bplustree
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/kucuny/gods"
+	"hash/crc32"
+)
+
+// Every page starts with a CRC-32 of the rest of the page, a type byte and,
+// for leaf and internal pages, a two byte entry count.
+//
+//	meta:     crc | type | magic[8] | version | page size u32 | root | count | pages | free
+//	leaf:     crc | type | count | prev | next | (uvarint len, key, uvarint len, value)*
+//	internal: crc | type | count | child0 | (uvarint len, key, child)*
+//	free:     crc | type | next free page
+//
+// Page ids and counts are big endian uint64s. Page 0 is the meta page, so 0
+// also means "no page" in links.
+const (
+	pageMeta byte = iota + 1
+	pageLeaf
+	pageInternal
+	pageFree
+)
+
+const (
+	headerSize         = 4 + 1 + 2
+	leafHeaderSize     = headerSize + 8 + 8
+	internalHeaderSize = headerSize + 8
+	fileVersion        = 1
+)
+
+var fileMagic = [8]byte{'G', 'O', 'D', 'S', 'B', 'P', 'T', '+'}
+
+type meta struct {
+	pageSize int
+	root     uint64
+	count    uint64
+	pages    uint64
+	free     uint64
+}
+
+func (m *meta) encode(page []byte) {
+	clear(page)
+	page[4] = pageMeta
+	copy(page[5:13], fileMagic[:])
+	page[13] = fileVersion
+	binary.BigEndian.PutUint32(page[14:18], uint32(m.pageSize))
+	binary.BigEndian.PutUint64(page[18:26], m.root)
+	binary.BigEndian.PutUint64(page[26:34], m.count)
+	binary.BigEndian.PutUint64(page[34:42], m.pages)
+	binary.BigEndian.PutUint64(page[42:50], m.free)
+	seal(page)
+}
+
+func decodeMeta(page []byte) (meta, error) {
+	if err := verify(0, page, pageMeta); err != nil {
+		return meta{}, err
+	}
+
+	if [8]byte(page[5:13]) != fileMagic || page[13] != fileVersion {
+		return meta{}, ErrNotBPlusTree
+	}
+
+	return meta{
+		pageSize: int(binary.BigEndian.Uint32(page[14:18])),
+		root:     binary.BigEndian.Uint64(page[18:26]),
+		count:    binary.BigEndian.Uint64(page[26:34]),
+		pages:    binary.BigEndian.Uint64(page[34:42]),
+		free:     binary.BigEndian.Uint64(page[42:50]),
+	}, nil
+}
+
+func encodeFree(page []byte, next uint64) {
+	clear(page)
+	page[4] = pageFree
+	binary.BigEndian.PutUint64(page[headerSize:], next)
+	seal(page)
+}
+
+func decodeFree(id uint64, page []byte) (uint64, error) {
+	if err := verify(id, page, pageFree); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(page[headerSize:]), nil
+}
+
+// node is the decoded form of a leaf or internal page. Keys are kept both
+// encoded, for writing the page back, and decoded, for comparisons. Leaf
+// values stay encoded until they are read.
+type node struct {
+	id       uint64
+	leaf     bool
+	keys     []interface{}
+	rawKeys  [][]byte
+	values   [][]byte
+	children []uint64
+	prev     uint64
+	next     uint64
+}
+
+func leafEntrySize(key, value []byte) int {
+	return uvarintSize(len(key)) + len(key) + uvarintSize(len(value)) + len(value)
+}
+
+func internalEntrySize(key []byte) int {
+	return uvarintSize(len(key)) + len(key) + 8
+}
+
+func (n *node) entrySize(i int) int {
+	if n.leaf {
+		return leafEntrySize(n.rawKeys[i], n.values[i])
+	}
+
+	return internalEntrySize(n.rawKeys[i])
+}
+
+func (n *node) size() int {
+	size := internalHeaderSize
+
+	if n.leaf {
+		size = leafHeaderSize
+	}
+
+	for i := range n.rawKeys {
+		size += n.entrySize(i)
+	}
+
+	return size
+}
+
+func (n *node) encode(page []byte) {
+	clear(page)
+	binary.BigEndian.PutUint16(page[5:7], uint16(len(n.rawKeys)))
+
+	offset := headerSize
+
+	if n.leaf {
+		page[4] = pageLeaf
+		binary.BigEndian.PutUint64(page[offset:], n.prev)
+		binary.BigEndian.PutUint64(page[offset+8:], n.next)
+		offset += 16
+	} else {
+		page[4] = pageInternal
+		binary.BigEndian.PutUint64(page[offset:], n.children[0])
+		offset += 8
+	}
+
+	for i, key := range n.rawKeys {
+		offset += binary.PutUvarint(page[offset:], uint64(len(key)))
+		offset += copy(page[offset:], key)
+
+		if n.leaf {
+			offset += binary.PutUvarint(page[offset:], uint64(len(n.values[i])))
+			offset += copy(page[offset:], n.values[i])
+		} else {
+			binary.BigEndian.PutUint64(page[offset:], n.children[i+1])
+			offset += 8
+		}
+	}
+
+	seal(page)
+}
+
+func decodeNode(id uint64, page []byte, keyCodec gods.Codec) (*node, error) {
+	if err := verify(id, page, 0); err != nil {
+		return nil, err
+	}
+
+	n := &node{id: id}
+	count := int(binary.BigEndian.Uint16(page[5:7]))
+	offset := headerSize
+
+	switch page[4] {
+	case pageLeaf:
+		n.leaf = true
+		n.prev = binary.BigEndian.Uint64(page[offset:])
+		n.next = binary.BigEndian.Uint64(page[offset+8:])
+		n.values = make([][]byte, 0, count)
+		offset += 16
+	case pageInternal:
+		n.children = make([]uint64, 1, count+1)
+		n.children[0] = binary.BigEndian.Uint64(page[offset:])
+		offset += 8
+	default:
+		return nil, fmt.Errorf("%w: page %d has type %d", ErrCorrupt, id, page[4])
+	}
+
+	n.keys = make([]interface{}, 0, count)
+	n.rawKeys = make([][]byte, 0, count)
+
+	for i := 0; i < count; i++ {
+		raw, next, err := readBytes(id, page, offset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := keyCodec.Unmarshal(raw)
+
+		if err != nil {
+			return nil, err
+		}
+
+		n.keys = append(n.keys, key)
+		n.rawKeys = append(n.rawKeys, raw)
+		offset = next
+
+		if n.leaf {
+			value, next, err := readBytes(id, page, offset)
+
+			if err != nil {
+				return nil, err
+			}
+
+			n.values = append(n.values, value)
+			offset = next
+		} else {
+			if offset+8 > len(page) {
+				return nil, fmt.Errorf("%w: page %d is truncated", ErrCorrupt, id)
+			}
+
+			n.children = append(n.children, binary.BigEndian.Uint64(page[offset:]))
+			offset += 8
+		}
+	}
+
+	return n, nil
+}
+
+// readBytes reads a uvarint length-prefixed field and returns a copy, so
+// that nodes never alias page buffers.
+func readBytes(id uint64, page []byte, offset int) ([]byte, int, error) {
+	length, n := binary.Uvarint(page[offset:])
+
+	if n <= 0 || uint64(len(page)-offset-n) < length {
+		return nil, 0, fmt.Errorf("%w: page %d is truncated", ErrCorrupt, id)
+	}
+
+	start := offset + n
+	data := make([]byte, length)
+	copy(data, page[start:])
+
+	return data, start + int(length), nil
+}
+
+func seal(page []byte) {
+	binary.BigEndian.PutUint32(page[:4], crc32.ChecksumIEEE(page[4:]))
+}
+
+// verify checks the checksum and, unless kind is 0, the page type.
+func verify(id uint64, page []byte, kind byte) error {
+	if binary.BigEndian.Uint32(page[:4]) != crc32.ChecksumIEEE(page[4:]) {
+		return fmt.Errorf("%w: page %d fails its checksum", ErrCorrupt, id)
+	}
+
+	if kind != 0 && page[4] != kind {
+		return fmt.Errorf("%w: page %d has type %d, expected %d", ErrCorrupt, id, page[4], kind)
+	}
+
+	return nil
+}
+
+func uvarintSize(n int) int {
+	size := 1
+
+	for n >= 0x80 {
+		n >>= 7
+		size++
+	}
+
+	return size
+}
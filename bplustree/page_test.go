@@ -0,0 +1,103 @@
+package bplustree
+
+import (
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PageTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PageTestSuite) TestLeafRoundTrip() {
+	n := &node{id: 3, leaf: true, prev: 2, next: 9}
+
+	for i, word := range []string{"a", "bb", "ccc"} {
+		key, _ := gods.StringCodec.Marshal(word)
+		n.keys = append(n.keys, word)
+		n.rawKeys = append(n.rawKeys, key)
+		n.values = append(n.values, []byte{byte(i)})
+	}
+
+	page := make([]byte, minPageSize)
+	n.encode(page)
+
+	decoded, err := decodeNode(3, page, gods.StringCodec)
+	suite.NoError(err)
+	suite.Equal(n, decoded)
+	suite.Equal(n.size(), decoded.size())
+}
+
+func (suite *PageTestSuite) TestInternalRoundTrip() {
+	n := &node{id: 4, children: []uint64{1, 2, 3}}
+
+	for _, value := range []int{10, 20} {
+		key, _ := gods.IntCodec.Marshal(value)
+		n.keys = append(n.keys, value)
+		n.rawKeys = append(n.rawKeys, key)
+	}
+
+	page := make([]byte, minPageSize)
+	n.encode(page)
+
+	decoded, err := decodeNode(4, page, gods.IntCodec)
+	suite.NoError(err)
+	suite.Equal(n.keys, decoded.keys)
+	suite.Equal(n.children, decoded.children)
+	suite.False(decoded.leaf)
+}
+
+func (suite *PageTestSuite) TestMetaAndFreeRoundTrip() {
+	m := meta{pageSize: 1024, root: 5, count: 42, pages: 9, free: 7}
+	page := make([]byte, 1024)
+	m.encode(page)
+
+	decoded, err := decodeMeta(page)
+	suite.NoError(err)
+	suite.Equal(m, decoded)
+
+	encodeFree(page, 8)
+
+	next, err := decodeFree(7, page)
+	suite.NoError(err)
+	suite.Equal(uint64(8), next)
+
+	_, err = decodeMeta(page)
+	suite.ErrorIs(err, ErrCorrupt)
+}
+
+func (suite *PageTestSuite) TestChecksum() {
+	page := make([]byte, minPageSize)
+	(&node{id: 1, leaf: true}).encode(page)
+	page[100] ^= 1
+
+	_, err := decodeNode(1, page, gods.IntCodec)
+	suite.ErrorIs(err, ErrCorrupt)
+
+	encodeFree(page, 0)
+
+	_, err = decodeNode(1, page, gods.IntCodec)
+	suite.ErrorIs(err, ErrCorrupt)
+}
+
+func (suite *PageTestSuite) TestUvarintSize() {
+	for _, n := range []int{0, 1, 127, 128, 16383, 16384, 1 << 30} {
+		suite.Equal(len(appendUvarint(n)), uvarintSize(n), "n = %d", n)
+	}
+}
+
+func appendUvarint(n int) []byte {
+	var out []byte
+
+	for n >= 0x80 {
+		out = append(out, byte(n)|0x80)
+		n >>= 7
+	}
+
+	return append(out, byte(n))
+}
+
+func TestPageTestSuite(t *testing.T) {
+	suite.Run(t, new(PageTestSuite))
+}
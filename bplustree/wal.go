@@ -0,0 +1,142 @@
+package bplustree
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+)
+
+// The write-ahead log holds whole page images. Each transaction is one
+// record:
+//
+//	magic u32 | page count u32 | (page id u64 | page)* | crc u32
+//
+// where the CRC covers everything before it. A record is only acted on once
+// it has been written completely, so a torn record at the end of the log is
+// a transaction that never committed.
+const walMagic = 0x57414c31
+
+type walPage struct {
+	id   uint64
+	data []byte
+}
+
+type wal struct {
+	file     *os.File
+	size     int64
+	pageSize int
+	sync     bool
+}
+
+func openWAL(path string, pageSize int, sync bool) (*wal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &wal{file: file, size: info.Size(), pageSize: pageSize, sync: sync}, nil
+}
+
+// append writes and syncs one transaction. On failure the log is cut back
+// so that a later record never follows a torn one.
+func (w *wal) append(pages []walPage) error {
+	record := make([]byte, 8, 8+len(pages)*(8+w.pageSize)+4)
+	binary.BigEndian.PutUint32(record[0:4], walMagic)
+	binary.BigEndian.PutUint32(record[4:8], uint32(len(pages)))
+
+	for _, page := range pages {
+		record = binary.BigEndian.AppendUint64(record, page.id)
+		record = append(record, page.data...)
+	}
+
+	record = binary.BigEndian.AppendUint32(record, crc32.ChecksumIEEE(record))
+
+	_, err := w.file.WriteAt(record, w.size)
+
+	if err == nil && w.sync {
+		err = w.file.Sync()
+	}
+
+	if err != nil {
+		if truncateErr := w.file.Truncate(w.size); truncateErr != nil {
+			return truncateErr
+		}
+
+		return err
+	}
+
+	w.size += int64(len(record))
+
+	return nil
+}
+
+// replay calls fn for every page of every complete record, oldest first,
+// and stops silently at the first torn or corrupt record.
+func (w *wal) replay(fn func(id uint64, page []byte) error) error {
+	header := make([]byte, 8)
+
+	for offset := int64(0); ; {
+		if _, err := w.file.ReadAt(header, offset); err != nil {
+			return nil
+		}
+
+		if binary.BigEndian.Uint32(header[0:4]) != walMagic {
+			return nil
+		}
+
+		length := int64(binary.BigEndian.Uint32(header[4:8]))*int64(8+w.pageSize) + 4
+
+		if offset+8+length > w.size {
+			return nil
+		}
+
+		body := make([]byte, length)
+
+		if _, err := w.file.ReadAt(body, offset+8); err != nil {
+			return nil
+		}
+
+		count := int(binary.BigEndian.Uint32(header[4:8]))
+		offset += 8 + length
+
+		checksum := crc32.Update(crc32.ChecksumIEEE(header), crc32.IEEETable, body[:len(body)-4])
+
+		if checksum != binary.BigEndian.Uint32(body[len(body)-4:]) {
+			return nil
+		}
+
+		for i := 0; i < count; i++ {
+			entry := body[i*(8+w.pageSize):]
+
+			if err := fn(binary.BigEndian.Uint64(entry), entry[8:8+w.pageSize]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (w *wal) reset() error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+
+	w.size = 0
+
+	if w.sync {
+		return w.file.Sync()
+	}
+
+	return nil
+}
+
+func (w *wal) close() error {
+	return w.file.Close()
+}
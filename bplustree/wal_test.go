@@ -0,0 +1,70 @@
+package bplustree
+
+import (
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type WALTestSuite struct {
+	suite.Suite
+}
+
+func (suite *WALTestSuite) replayed(w *wal) map[uint64]byte {
+	pages := make(map[uint64]byte)
+
+	suite.NoError(w.replay(func(id uint64, page []byte) error {
+		suite.Len(page, 8)
+		pages[id] = page[0]
+		return nil
+	}))
+
+	return pages
+}
+
+func (suite *WALTestSuite) TestAppendReplay() {
+	path := filepath.Join(suite.T().TempDir(), "wal")
+	w, err := openWAL(path, 8, true)
+	suite.Require().NoError(err)
+
+	suite.NoError(w.append([]walPage{{id: 1, data: make([]byte, 8)}, {id: 2, data: []byte{2, 0, 0, 0, 0, 0, 0, 0}}}))
+	suite.NoError(w.append([]walPage{{id: 1, data: []byte{7, 0, 0, 0, 0, 0, 0, 0}}}))
+	suite.Equal(map[uint64]byte{1: 7, 2: 2}, suite.replayed(w))
+	suite.NoError(w.close())
+
+	data, err := os.ReadFile(path)
+	suite.Require().NoError(err)
+
+	// Drop the last byte of the second record: only the first survives.
+	suite.Require().NoError(os.WriteFile(path, data[:len(data)-1], 0o644))
+
+	w, err = openWAL(path, 8, true)
+	suite.Require().NoError(err)
+	suite.Equal(map[uint64]byte{1: 0, 2: 2}, suite.replayed(w))
+
+	suite.NoError(w.reset())
+	suite.Empty(suite.replayed(w))
+	suite.NoError(w.close())
+}
+
+func (suite *WALTestSuite) TestCorruptRecordStopsReplay() {
+	path := filepath.Join(suite.T().TempDir(), "wal")
+	w, err := openWAL(path, 8, false)
+	suite.Require().NoError(err)
+
+	suite.NoError(w.append([]walPage{{id: 1, data: make([]byte, 8)}}))
+	suite.NoError(w.append([]walPage{{id: 2, data: make([]byte, 8)}}))
+	suite.NoError(w.append([]walPage{{id: 3, data: make([]byte, 8)}}))
+
+	// Flip a byte inside the second record's page.
+	_, err = w.file.WriteAt([]byte{9}, 24+8+8+4)
+	suite.Require().NoError(err)
+
+	suite.Equal(map[uint64]byte{1: 0}, suite.replayed(w))
+	suite.NoError(w.close())
+}
+
+func TestWALTestSuite(t *testing.T) {
+	suite.Run(t, new(WALTestSuite))
+}
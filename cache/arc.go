@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/list"
+)
+
+type arcEntry struct {
+	entry
+	owner *list.LinkedList
+	node  *list.Node
+}
+
+type ARCCache struct {
+	capacity int
+	target   int
+	items    map[interface{}]*arcEntry
+	t1, t2   *list.LinkedList
+	b1, b2   *list.LinkedList
+	mutex    gods.Locker
+}
+
+func NewARCCache(capacity int, options ...gods.Option) *ARCCache {
+	return &ARCCache{
+		capacity: capacity,
+		target:   0,
+		items:    make(map[interface{}]*arcEntry),
+		t1:       list.NewLinkedList(gods.WithLock(gods.NoLock)),
+		t2:       list.NewLinkedList(gods.WithLock(gods.NoLock)),
+		b1:       list.NewLinkedList(gods.WithLock(gods.NoLock)),
+		b2:       list.NewLinkedList(gods.WithLock(gods.NoLock)),
+		mutex:    gods.NewOptions(options...).Locker(),
+	}
+}
+
+func (c *ARCCache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.t1.Len() + c.t2.Len()
+}
+
+func (c *ARCCache) Cap() int {
+	return c.capacity
+}
+
+// Format prints the resident entries, frequently used (T2) before recently
+// used (T1), each from most to least recent. Ghost entries are not shown.
+func (c *ARCCache) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "ARCCache", c.Len(), c.entries)
+}
+
+func (c *ARCCache) String() string {
+	return fmt.Sprint(c)
+}
+
+func (c *ARCCache) Get(key interface{}) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.items[key]
+
+	if !ok || !c.resident(e) {
+		return nil, false
+	}
+
+	c.move(e, c.t2)
+
+	return e.value, true
+}
+
+func (c *ARCCache) Put(key, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.capacity < 1 {
+		return
+	}
+
+	e, ok := c.items[key]
+
+	if ok && c.resident(e) {
+		e.value = value
+		c.move(e, c.t2)
+		return
+	}
+
+	if ok && e.owner == c.b1 {
+		c.target = minInt(c.capacity, c.target+maxInt(c.b2.Len()/c.b1.Len(), 1))
+		c.replace(false)
+		e.value = value
+		c.move(e, c.t2)
+		return
+	}
+
+	if ok && e.owner == c.b2 {
+		c.target = maxInt(0, c.target-maxInt(c.b1.Len()/c.b2.Len(), 1))
+		c.replace(true)
+		e.value = value
+		c.move(e, c.t2)
+		return
+	}
+
+	l1 := c.t1.Len() + c.b1.Len()
+	total := l1 + c.t2.Len() + c.b2.Len()
+
+	if l1 >= c.capacity {
+		if c.t1.Len() < c.capacity {
+			c.drop(c.b1)
+			c.replace(false)
+		} else {
+			c.drop(c.t1)
+		}
+	} else if total >= c.capacity {
+		if total >= 2*c.capacity {
+			c.drop(c.b2)
+		}
+		c.replace(false)
+	}
+
+	e = &arcEntry{entry: entry{key: key, value: value}, owner: c.t1}
+	e.node = c.t1.PushFront(&list.Node{Value: e})
+	c.items[key] = e
+}
+
+func (c *ARCCache) Remove(key interface{}) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.items[key]
+
+	if !ok {
+		return false
+	}
+
+	e.owner.Remove(e.node)
+	delete(c.items, key)
+
+	return c.resident(e)
+}
+
+func (c *ARCCache) Contains(key interface{}) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	e, ok := c.items[key]
+
+	return ok && c.resident(e)
+}
+
+func (c *ARCCache) resident(e *arcEntry) bool {
+	return e.owner == c.t1 || e.owner == c.t2
+}
+
+func (c *ARCCache) move(e *arcEntry, to *list.LinkedList) {
+	e.owner.Remove(e.node)
+	e.owner = to
+	to.PushFront(e.node)
+}
+
+func (c *ARCCache) replace(inB2 bool) {
+	if c.t1.Len()+c.t2.Len() < c.capacity {
+		return
+	}
+
+	t1Len := c.t1.Len()
+
+	if t1Len > 0 && (t1Len > c.target || (inB2 && t1Len == c.target) || c.t2.Len() == 0) {
+		e := c.t1.Back().Value.(*arcEntry)
+		e.value = nil
+		c.move(e, c.b1)
+	} else if c.t2.Len() > 0 {
+		e := c.t2.Back().Value.(*arcEntry)
+		e.value = nil
+		c.move(e, c.b2)
+	}
+}
+
+func (c *ARCCache) drop(from *list.LinkedList) {
+	back := from.Back()
+
+	if back == nil {
+		return
+	}
+
+	from.Remove(back)
+	delete(c.items, back.Value.(*arcEntry).key)
+}
+
+func (c *ARCCache) entries(fn func(key, value interface{}) bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	more := true
+
+	for _, l := range []*list.LinkedList{c.t2, c.t1} {
+		if !more {
+			return
+		}
+
+		l.Each(func(value interface{}) bool {
+			e := value.(*arcEntry)
+			more = fn(e.key, e.value)
+			return more
+		})
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ARCCacheTestSuite struct {
+	suite.Suite
+	c *ARCCache
+}
+
+func (suite *ARCCacheTestSuite) SetupTest() {
+	suite.c = NewARCCache(4)
+}
+
+func (suite *ARCCacheTestSuite) TestARCCachePromotesOnSecondAccess() {
+	suite.c.Put(1, "one")
+	suite.c.Put(2, "two")
+
+	suite.Equal(2, suite.c.t1.Len())
+	suite.Equal(0, suite.c.t2.Len())
+
+	value, ok := suite.c.Get(1)
+	suite.True(ok)
+	suite.Equal("one", value)
+
+	suite.Equal(1, suite.c.t1.Len())
+	suite.Equal(1, suite.c.t2.Len())
+}
+
+func (suite *ARCCacheTestSuite) TestARCCacheFrequentSurvivesScan() {
+	suite.c.Put(1, 1)
+	suite.c.Put(2, 2)
+	suite.c.Get(1)
+	suite.c.Get(2)
+
+	for i := 100; i < 120; i++ {
+		suite.c.Put(i, i)
+	}
+
+	suite.Equal(4, suite.c.Len())
+	suite.True(suite.c.Contains(1))
+	suite.True(suite.c.Contains(2))
+}
+
+func (suite *ARCCacheTestSuite) TestARCCacheGhostHitAdaptsTarget() {
+	for i := 0; i < 4; i++ {
+		suite.c.Put(i, i)
+	}
+
+	suite.c.Get(3)
+	suite.c.Put(4, 4)
+
+	suite.False(suite.c.Contains(0))
+	suite.Equal(1, suite.c.b1.Len())
+	suite.Equal(0, suite.c.target)
+
+	suite.c.Put(0, 0)
+
+	suite.True(suite.c.Contains(0))
+	suite.Equal(1, suite.c.target)
+	suite.Equal(2, suite.c.t2.Len())
+	suite.Equal(4, suite.c.Len())
+}
+
+func (suite *ARCCacheTestSuite) TestARCCacheRemove() {
+	suite.c.Put(1, 1)
+
+	suite.True(suite.c.Remove(1))
+	suite.False(suite.c.Remove(1))
+	suite.Equal(0, suite.c.Len())
+}
+
+func (suite *ARCCacheTestSuite) TestARCCacheFormat() {
+	c := NewARCCache(2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+
+	suite.Equal("ARCCache[a:1 b:2]", c.String())
+
+	c.Put("c", 3)
+
+	suite.Equal(2, c.Len())
+	suite.Equal("ARCCache[a:1 c:3]", fmt.Sprint(c))
+}
+
+func TestARCCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(ARCCacheTestSuite))
+}
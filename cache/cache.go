@@ -0,0 +1,14 @@
+package cache
+
+type Cache interface {
+	Get(key interface{}) (interface{}, bool)
+	Put(key, value interface{})
+	Remove(key interface{}) bool
+	Contains(key interface{}) bool
+	Len() int
+	Cap() int
+}
+
+type entry struct {
+	key, value interface{}
+}
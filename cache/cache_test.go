@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"testing"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+	caches map[string]func(capacity int) Cache
+}
+
+func (suite *CacheTestSuite) SetupSuite() {
+	suite.caches = map[string]func(capacity int) Cache{
+		"LRU": func(capacity int) Cache { return NewLRUCache(capacity) },
+		"LFU": func(capacity int) Cache { return NewLFUCache(capacity) },
+		"ARC": func(capacity int) Cache { return NewARCCache(capacity) },
+	}
+}
+
+func (suite *CacheTestSuite) TestCacheNeverExceedsCapacity() {
+	for name, newCache := range suite.caches {
+		c := newCache(16)
+		r := rand.New(rand.NewSource(1))
+		values := make(map[interface{}]interface{})
+
+		for i := 0; i < 10000; i++ {
+			key := r.Intn(64)
+
+			switch r.Intn(3) {
+			case 0:
+				c.Put(key, i)
+				values[key] = i
+			case 1:
+				if value, ok := c.Get(key); ok {
+					suite.Equal(values[key], value, name)
+				}
+			case 2:
+				c.Remove(key)
+			}
+
+			suite.True(c.Len() <= c.Cap(), name)
+		}
+	}
+}
+
+func (suite *CacheTestSuite) TestCacheZeroCapacity() {
+	for name, newCache := range suite.caches {
+		c := newCache(0)
+		c.Put(1, 1)
+
+		suite.Equal(0, c.Len(), name)
+		suite.False(c.Contains(1), name)
+	}
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+func FuzzCache(f *testing.F) {
+	f.Add(uint8(3), []byte{0, 1, 0, 2, 0, 3, 1, 1, 0, 4, 2, 2, 0, 1})
+
+	f.Fuzz(func(t *testing.T, capacity uint8, ops []byte) {
+		caches := map[string]Cache{
+			"LRU": NewLRUCache(int(capacity % 16)),
+			"LFU": NewLFUCache(int(capacity % 16)),
+			"ARC": NewARCCache(int(capacity % 16)),
+		}
+
+		for name, c := range caches {
+			values := make(map[int]int)
+
+			for i := 0; i+1 < len(ops); i += 2 {
+				key := int(ops[i+1] % 32)
+
+				switch ops[i] % 3 {
+				case 0:
+					c.Put(key, i)
+					values[key] = i
+				case 1:
+					value, ok := c.Get(key)
+
+					if ok != c.Contains(key) || (ok && value != values[key]) {
+						t.Fatalf("%s: get %d returned stale value %v", name, key, value)
+					}
+				case 2:
+					c.Remove(key)
+
+					if c.Contains(key) {
+						t.Fatalf("%s: %d survived removal", name, key)
+					}
+				}
+
+				if c.Len() > c.Cap() || c.Len() < 0 {
+					t.Fatalf("%s: length %d exceeds capacity %d", name, c.Len(), c.Cap())
+				}
+			}
+		}
+	})
+}
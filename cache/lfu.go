@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/list"
+)
+
+type lfuBucket struct {
+	freq    int
+	entries *list.LinkedList
+	node    *list.Node
+}
+
+type lfuEntry struct {
+	entry
+	bucket *lfuBucket
+}
+
+type LFUCache struct {
+	capacity int
+	items    map[interface{}]*list.Node
+	freqs    *list.LinkedList
+	mutex    gods.Locker
+}
+
+func NewLFUCache(capacity int, options ...gods.Option) *LFUCache {
+	return &LFUCache{
+		capacity: capacity,
+		items:    make(map[interface{}]*list.Node),
+		freqs:    list.NewLinkedList(gods.WithLock(gods.NoLock)),
+		mutex:    gods.NewOptions(options...).Locker(),
+	}
+}
+
+func (c *LFUCache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return len(c.items)
+}
+
+func (c *LFUCache) Cap() int {
+	return c.capacity
+}
+
+// Format prints the entries from most to least frequently used.
+func (c *LFUCache) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "LFUCache", c.Len(), c.entries)
+}
+
+func (c *LFUCache) String() string {
+	return fmt.Sprint(c)
+}
+
+func (c *LFUCache) Get(key interface{}) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.items[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	c.touch(node)
+
+	return node.Value.(*lfuEntry).value, true
+}
+
+func (c *LFUCache) Put(key, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.capacity < 1 {
+		return
+	}
+
+	if node, ok := c.items[key]; ok {
+		node.Value.(*lfuEntry).value = value
+		c.touch(node)
+		return
+	}
+
+	if len(c.items) >= c.capacity {
+		c.evict()
+	}
+
+	front := c.freqs.Front()
+
+	var bucket *lfuBucket
+
+	if front != nil && front.Value.(*lfuBucket).freq == 1 {
+		bucket = front.Value.(*lfuBucket)
+	} else {
+		bucket = &lfuBucket{freq: 1, entries: list.NewLinkedList(gods.WithLock(gods.NoLock))}
+		bucket.node = c.freqs.PushFront(&list.Node{Value: bucket})
+	}
+
+	e := &lfuEntry{entry: entry{key: key, value: value}, bucket: bucket}
+	c.items[key] = bucket.entries.PushFront(&list.Node{Value: e})
+}
+
+func (c *LFUCache) Remove(key interface{}) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.items[key]
+
+	if !ok {
+		return false
+	}
+
+	c.unlink(node)
+	delete(c.items, key)
+
+	return true
+}
+
+func (c *LFUCache) Contains(key interface{}) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.items[key]
+
+	return ok
+}
+
+func (c *LFUCache) Frequency(key interface{}) int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	node, ok := c.items[key]
+
+	if !ok {
+		return 0
+	}
+
+	return node.Value.(*lfuEntry).bucket.freq
+}
+
+func (c *LFUCache) touch(node *list.Node) {
+	e := node.Value.(*lfuEntry)
+	current := e.bucket
+
+	var next *lfuBucket
+
+	if n := current.node.Next(); n != nil && n.Value != nil && n.Value.(*lfuBucket).freq == current.freq+1 {
+		next = n.Value.(*lfuBucket)
+	} else {
+		next = &lfuBucket{freq: current.freq + 1, entries: list.NewLinkedList(gods.WithLock(gods.NoLock))}
+		next.node = c.freqs.InsertAfter(&list.Node{Value: next}, current.node)
+	}
+
+	c.unlink(node)
+	next.entries.PushFront(node)
+	e.bucket = next
+}
+
+func (c *LFUCache) unlink(node *list.Node) {
+	bucket := node.Value.(*lfuEntry).bucket
+	bucket.entries.Remove(node)
+
+	if bucket.entries.Len() == 0 {
+		c.freqs.Remove(bucket.node)
+	}
+}
+
+func (c *LFUCache) evict() {
+	front := c.freqs.Front()
+
+	if front == nil {
+		return
+	}
+
+	victim := front.Value.(*lfuBucket).entries.Back()
+	c.unlink(victim)
+	delete(c.items, victim.Value.(*lfuEntry).key)
+}
+
+func (c *LFUCache) entries(fn func(key, value interface{}) bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	buckets := c.freqs.Values()
+	more := true
+
+	for i := len(buckets) - 1; i >= 0 && more; i-- {
+		buckets[i].(*lfuBucket).entries.Each(func(value interface{}) bool {
+			e := value.(*lfuEntry)
+			more = fn(e.key, e.value)
+			return more
+		})
+	}
+}
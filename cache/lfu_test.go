@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type LFUCacheTestSuite struct {
+	suite.Suite
+	c *LFUCache
+}
+
+func (suite *LFUCacheTestSuite) SetupTest() {
+	suite.c = NewLFUCache(2)
+}
+
+func (suite *LFUCacheTestSuite) TestLFUCacheEvictsLeastFrequentlyUsed() {
+	suite.c.Put("a", 1)
+	suite.c.Put("b", 2)
+	suite.c.Get("a")
+	suite.c.Get("a")
+	suite.c.Get("b")
+
+	suite.Equal(3, suite.c.Frequency("a"))
+	suite.Equal(2, suite.c.Frequency("b"))
+
+	suite.c.Put("c", 3)
+
+	suite.Equal(2, suite.c.Len())
+	suite.True(suite.c.Contains("a"))
+	suite.False(suite.c.Contains("b"))
+	suite.True(suite.c.Contains("c"))
+	suite.Equal(1, suite.c.Frequency("c"))
+}
+
+func (suite *LFUCacheTestSuite) TestLFUCacheTieBreaksOnRecency() {
+	suite.c.Put("a", 1)
+	suite.c.Put("b", 2)
+	suite.c.Put("c", 3)
+
+	suite.False(suite.c.Contains("a"))
+	suite.True(suite.c.Contains("b"))
+	suite.True(suite.c.Contains("c"))
+}
+
+func (suite *LFUCacheTestSuite) TestLFUCacheUpdateCountsAsAccess() {
+	suite.c.Put("a", 1)
+	suite.c.Put("b", 2)
+	suite.c.Put("a", 10)
+	suite.c.Put("c", 3)
+
+	value, ok := suite.c.Get("a")
+	suite.True(ok)
+	suite.Equal(10, value)
+	suite.False(suite.c.Contains("b"))
+}
+
+func (suite *LFUCacheTestSuite) TestLFUCacheRemove() {
+	suite.c.Put("a", 1)
+	suite.c.Get("a")
+
+	suite.True(suite.c.Remove("a"))
+	suite.False(suite.c.Remove("a"))
+	suite.Equal(0, suite.c.Len())
+	suite.Equal(0, suite.c.Frequency("a"))
+	suite.Equal(0, suite.c.freqs.Len())
+}
+
+func (suite *LFUCacheTestSuite) TestLFUCacheFormat() {
+	c := NewLFUCache(3)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("b")
+	c.Get("b")
+	c.Get("c")
+
+	suite.Equal("LFUCache[b:2 c:3 a:1]", c.String())
+	suite.Equal("LFUCache[b:2 ...2 more]", fmt.Sprintf("%.1v", c))
+}
+
+func TestLFUCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(LFUCacheTestSuite))
+}
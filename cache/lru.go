@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/list"
+)
+
+type LRUCache struct {
+	capacity int
+	items    map[interface{}]*list.Node
+	order    *list.LinkedList
+	mutex    gods.Locker
+}
+
+func NewLRUCache(capacity int, options ...gods.Option) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		items:    make(map[interface{}]*list.Node),
+		order:    list.NewLinkedList(gods.WithLock(gods.NoLock)),
+		mutex:    gods.NewOptions(options...).Locker(),
+	}
+}
+
+func (c *LRUCache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return len(c.items)
+}
+
+func (c *LRUCache) Cap() int {
+	return c.capacity
+}
+
+// Format prints the entries from most to least recently used.
+func (c *LRUCache) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "LRUCache", c.Len(), c.entries)
+}
+
+func (c *LRUCache) String() string {
+	return fmt.Sprint(c)
+}
+
+func (c *LRUCache) Get(key interface{}) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.items[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	c.order.Remove(node)
+	c.order.PushFront(node)
+
+	return node.Value.(*entry).value, true
+}
+
+func (c *LRUCache) Put(key, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.capacity < 1 {
+		return
+	}
+
+	if node, ok := c.items[key]; ok {
+		node.Value.(*entry).value = value
+		c.order.Remove(node)
+		c.order.PushFront(node)
+		return
+	}
+
+	if len(c.items) >= c.capacity {
+		back := c.order.Back()
+		c.order.Remove(back)
+		delete(c.items, back.Value.(*entry).key)
+	}
+
+	c.items[key] = c.order.PushFront(&list.Node{Value: &entry{key: key, value: value}})
+}
+
+func (c *LRUCache) Remove(key interface{}) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.items[key]
+
+	if !ok {
+		return false
+	}
+
+	c.order.Remove(node)
+	delete(c.items, key)
+
+	return true
+}
+
+func (c *LRUCache) Contains(key interface{}) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.items[key]
+
+	return ok
+}
+
+func (c *LRUCache) entries(fn func(key, value interface{}) bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	c.order.Each(func(value interface{}) bool {
+		e := value.(*entry)
+		return fn(e.key, e.value)
+	})
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type LRUCacheTestSuite struct {
+	suite.Suite
+	c *LRUCache
+}
+
+func (suite *LRUCacheTestSuite) SetupTest() {
+	suite.c = NewLRUCache(2)
+}
+
+func (suite *LRUCacheTestSuite) TestLRUCacheEvictsLeastRecentlyUsed() {
+	suite.Equal(2, suite.c.Cap())
+	suite.Equal(0, suite.c.Len())
+
+	suite.c.Put("a", 1)
+	suite.c.Put("b", 2)
+
+	value, ok := suite.c.Get("a")
+	suite.True(ok)
+	suite.Equal(1, value)
+
+	suite.c.Put("c", 3)
+
+	suite.Equal(2, suite.c.Len())
+	suite.True(suite.c.Contains("a"))
+	suite.False(suite.c.Contains("b"))
+	suite.True(suite.c.Contains("c"))
+}
+
+func (suite *LRUCacheTestSuite) TestLRUCacheUpdateExisting() {
+	suite.c.Put("a", 1)
+	suite.c.Put("b", 2)
+	suite.c.Put("a", 10)
+	suite.c.Put("c", 3)
+
+	value, ok := suite.c.Get("a")
+	suite.True(ok)
+	suite.Equal(10, value)
+	suite.False(suite.c.Contains("b"))
+}
+
+func (suite *LRUCacheTestSuite) TestLRUCacheRemove() {
+	suite.c.Put("a", 1)
+
+	suite.True(suite.c.Remove("a"))
+	suite.False(suite.c.Remove("a"))
+	suite.Equal(0, suite.c.Len())
+
+	value, ok := suite.c.Get("a")
+	suite.False(ok)
+	suite.Nil(value)
+}
+
+func (suite *LRUCacheTestSuite) TestLRUCacheFormat() {
+	suite.Equal("LRUCache[]", suite.c.String())
+
+	suite.c.Put("a", 1)
+	suite.c.Put("b", 2)
+	suite.c.Get("a")
+
+	suite.Equal("LRUCache[a:1 b:2]", suite.c.String())
+	suite.Equal("LRUCache[a:1 ...1 more]", fmt.Sprintf("%.1v", suite.c))
+}
+
+func TestLRUCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(LRUCacheTestSuite))
+}
@@ -0,0 +1,199 @@
+package gods
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Codec converts single elements to and from bytes for the binary container
+// format. Unmarshal must not retain data after it returns.
+type Codec interface {
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(data []byte) (interface{}, error)
+}
+
+var (
+	IntCodec     Codec = intCodec{}
+	Int64Codec   Codec = int64Codec{}
+	Uint64Codec  Codec = uint64Codec{}
+	Float64Codec Codec = float64Codec{}
+	StringCodec  Codec = stringCodec{}
+	BytesCodec   Codec = bytesCodec{}
+)
+
+var errVarint = errors.New("gods: malformed varint")
+
+type intCodec struct{}
+
+func (intCodec) Marshal(value interface{}) ([]byte, error) {
+	v, ok := value.(int)
+
+	if !ok {
+		return nil, mismatch("IntCodec", value)
+	}
+
+	return binary.AppendVarint(nil, int64(v)), nil
+}
+
+func (intCodec) Unmarshal(data []byte) (interface{}, error) {
+	v, err := varint(data)
+	return int(v), err
+}
+
+type int64Codec struct{}
+
+func (int64Codec) Marshal(value interface{}) ([]byte, error) {
+	v, ok := value.(int64)
+
+	if !ok {
+		return nil, mismatch("Int64Codec", value)
+	}
+
+	return binary.AppendVarint(nil, v), nil
+}
+
+func (int64Codec) Unmarshal(data []byte) (interface{}, error) {
+	return varint(data)
+}
+
+type uint64Codec struct{}
+
+func (uint64Codec) Marshal(value interface{}) ([]byte, error) {
+	v, ok := value.(uint64)
+
+	if !ok {
+		return nil, mismatch("Uint64Codec", value)
+	}
+
+	return binary.AppendUvarint(nil, v), nil
+}
+
+func (uint64Codec) Unmarshal(data []byte) (interface{}, error) {
+	v, n := binary.Uvarint(data)
+
+	if n <= 0 || n != len(data) {
+		return nil, errVarint
+	}
+
+	return v, nil
+}
+
+type float64Codec struct{}
+
+func (float64Codec) Marshal(value interface{}) ([]byte, error) {
+	v, ok := value.(float64)
+
+	if !ok {
+		return nil, mismatch("Float64Codec", value)
+	}
+
+	return binary.LittleEndian.AppendUint64(nil, math.Float64bits(v)), nil
+}
+
+func (float64Codec) Unmarshal(data []byte) (interface{}, error) {
+	if len(data) != 8 {
+		return nil, fmt.Errorf("gods: Float64Codec expects 8 bytes, got %d", len(data))
+	}
+
+	return math.Float64frombits(binary.LittleEndian.Uint64(data)), nil
+}
+
+type stringCodec struct{}
+
+func (stringCodec) Marshal(value interface{}) ([]byte, error) {
+	v, ok := value.(string)
+
+	if !ok {
+		return nil, mismatch("StringCodec", value)
+	}
+
+	return []byte(v), nil
+}
+
+func (stringCodec) Unmarshal(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+type bytesCodec struct{}
+
+func (bytesCodec) Marshal(value interface{}) ([]byte, error) {
+	v, ok := value.([]byte)
+
+	if !ok {
+		return nil, mismatch("BytesCodec", value)
+	}
+
+	return v, nil
+}
+
+func (bytesCodec) Unmarshal(data []byte) (interface{}, error) {
+	return append([]byte{}, data...), nil
+}
+
+type gobCodec[T any] struct{}
+
+// GobCodec encodes each element with encoding/gob, so types implementing
+// encoding.BinaryMarshaler or gob.GobEncoder use their own encoding. With
+// T = interface{} the concrete types must be registered with gob.Register.
+func GobCodec[T any]() Codec {
+	return gobCodec[T]{}
+}
+
+func (gobCodec[T]) Marshal(value interface{}) ([]byte, error) {
+	v, ok := value.(T)
+
+	if !ok && value != nil {
+		return nil, mismatch("GobCodec", value)
+	}
+
+	var buffer bytes.Buffer
+
+	if err := gob.NewEncoder(&buffer).Encode(&v); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (gobCodec[T]) Unmarshal(data []byte) (interface{}, error) {
+	var v T
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+type jsonCodec[T any] struct{}
+
+func JSONCodec[T any]() Codec {
+	return jsonCodec[T]{}
+}
+
+func (jsonCodec[T]) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCodec[T]) Unmarshal(data []byte) (interface{}, error) {
+	return DecodeAs[T](data)
+}
+
+func varint(data []byte) (int64, error) {
+	v, n := binary.Varint(data)
+
+	if n <= 0 || n != len(data) {
+		return 0, errVarint
+	}
+
+	return v, nil
+}
+
+func mismatch(codec string, value interface{}) error {
+	return fmt.Errorf("gods: %s cannot encode %T", codec, value)
+}
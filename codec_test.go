@@ -0,0 +1,81 @@
+package gods
+
+import (
+	"github.com/stretchr/testify/suite"
+	"math"
+	"testing"
+	"time"
+)
+
+type CodecTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CodecTestSuite) roundTrip(codec Codec, value interface{}) {
+	data, err := codec.Marshal(value)
+	suite.NoError(err)
+
+	decoded, err := codec.Unmarshal(data)
+	suite.NoError(err)
+	suite.Equal(value, decoded)
+}
+
+func (suite *CodecTestSuite) TestCodecsRoundTrip() {
+	suite.roundTrip(IntCodec, math.MinInt)
+	suite.roundTrip(IntCodec, 300)
+	suite.roundTrip(Int64Codec, int64(math.MaxInt64))
+	suite.roundTrip(Uint64Codec, uint64(math.MaxUint64))
+	suite.roundTrip(Float64Codec, math.Inf(-1))
+	suite.roundTrip(Float64Codec, 0.1)
+	suite.roundTrip(StringCodec, "")
+	suite.roundTrip(StringCodec, "héllo")
+	suite.roundTrip(BytesCodec, []byte{0, 1, 2})
+	suite.roundTrip(GobCodec[interface{}](), 7)
+	suite.roundTrip(GobCodec[interface{}](), "seven")
+	suite.roundTrip(GobCodec[time.Time](), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	suite.roundTrip(JSONCodec[int](), 7)
+}
+
+func (suite *CodecTestSuite) TestCodecsRejectWrongTypes() {
+	for _, codec := range []Codec{IntCodec, Int64Codec, Uint64Codec, Float64Codec, StringCodec, BytesCodec, GobCodec[int]()} {
+		_, err := codec.Marshal(struct{}{})
+		suite.Error(err)
+	}
+}
+
+func (suite *CodecTestSuite) TestCodecsRejectMalformedData() {
+	_, err := IntCodec.Unmarshal([]byte{0x80})
+	suite.Error(err)
+
+	_, err = Int64Codec.Unmarshal([]byte{0x02, 0x02})
+	suite.Error(err)
+
+	_, err = Uint64Codec.Unmarshal(nil)
+	suite.Error(err)
+
+	_, err = Float64Codec.Unmarshal([]byte{1, 2, 3})
+	suite.Error(err)
+
+	_, err = GobCodec[int]().Unmarshal([]byte{1})
+	suite.Error(err)
+}
+
+func (suite *CodecTestSuite) TestBytesCodecCopies() {
+	data := []byte{1, 2}
+
+	decoded, err := BytesCodec.Unmarshal(data)
+	suite.NoError(err)
+
+	data[0] = 9
+	suite.Equal([]byte{1, 2}, decoded)
+}
+
+func (suite *CodecTestSuite) TestIntCodecIsCompact() {
+	data, err := IntCodec.Marshal(1)
+	suite.NoError(err)
+	suite.Len(data, 1)
+}
+
+func TestCodecTestSuite(t *testing.T) {
+	suite.Run(t, new(CodecTestSuite))
+}
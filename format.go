@@ -0,0 +1,102 @@
+package gods
+
+import (
+	"fmt"
+	"io"
+)
+
+// FormatLimit is the number of elements printed by %v before the rest are
+// elided. Use a precision such as %.50v to print more, or %+v to print all.
+const FormatLimit = 10
+
+// FormatValues implements fmt.Formatter for containers of values, printing
+// them as name[a b c]. Each element is formatted with the same verb and
+// flags, so %d and %q apply to the elements.
+func FormatValues(f fmt.State, verb rune, name string, length int, each func(fn func(value interface{}) bool)) {
+	format, limit := elementFormat(f, verb)
+	printed := 0
+
+	io.WriteString(f, name+"[")
+
+	each(func(value interface{}) bool {
+		if printed == limit {
+			return false
+		}
+
+		if printed > 0 {
+			io.WriteString(f, " ")
+		}
+
+		fmt.Fprintf(f, format, value)
+		printed++
+
+		return true
+	})
+
+	if printed == limit {
+		elided(f, printed, length)
+	}
+
+	io.WriteString(f, "]")
+}
+
+// FormatEntries is FormatValues for maps, printing name[k:v k:v].
+func FormatEntries(f fmt.State, verb rune, name string, length int, each func(fn func(key, value interface{}) bool)) {
+	format, limit := elementFormat(f, verb)
+	printed := 0
+
+	io.WriteString(f, name+"[")
+
+	each(func(key, value interface{}) bool {
+		if printed == limit {
+			return false
+		}
+
+		if printed > 0 {
+			io.WriteString(f, " ")
+		}
+
+		fmt.Fprintf(f, format+":"+format, key, value)
+		printed++
+
+		return true
+	})
+
+	if printed == limit {
+		elided(f, printed, length)
+	}
+
+	io.WriteString(f, "]")
+}
+
+func elementFormat(f fmt.State, verb rune) (string, int) {
+	format := "%"
+
+	for _, flag := range "+# " {
+		if f.Flag(int(flag)) {
+			format += string(flag)
+		}
+	}
+
+	format += string(verb)
+
+	if precision, ok := f.Precision(); ok {
+		return format, precision
+	}
+
+	if f.Flag('+') {
+		return format, -1
+	}
+
+	return format, FormatLimit
+}
+
+func elided(w io.Writer, printed, length int) {
+	if more := length - printed; more > 0 {
+		if printed > 0 {
+			io.WriteString(w, " ")
+		}
+
+		fmt.Fprintf(w, "...%d more", more)
+	}
+}
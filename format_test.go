@@ -0,0 +1,64 @@
+package gods
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type values []interface{}
+
+func (v values) Format(f fmt.State, verb rune) {
+	FormatValues(f, verb, "values", len(v), eachOf(v...))
+}
+
+type entries [][2]interface{}
+
+func (e entries) Format(f fmt.State, verb rune) {
+	FormatEntries(f, verb, "entries", len(e), func(fn func(key, value interface{}) bool) {
+		for _, entry := range e {
+			if !fn(entry[0], entry[1]) {
+				return
+			}
+		}
+	})
+}
+
+type FormatTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FormatTestSuite) TestFormatValues() {
+	suite.Equal("values[]", fmt.Sprint(values{}))
+	suite.Equal("values[1 a <nil>]", fmt.Sprint(values{1, "a", nil}))
+	suite.Equal(`values["a" "b"]`, fmt.Sprintf("%q", values{"a", "b"}))
+	suite.Equal("values[ff 10]", fmt.Sprintf("%x", values{255, 16}))
+	suite.Equal("values[{X:1}]", fmt.Sprintf("%+v", values{struct{ X int }{1}}))
+}
+
+func (suite *FormatTestSuite) TestFormatValuesTruncates() {
+	long := make(values, 25)
+
+	for i := range long {
+		long[i] = i
+	}
+
+	suite.Equal("values[0 1 2 3 4 5 6 7 8 9 ...15 more]", fmt.Sprint(long))
+	suite.Equal("values[0 1 ...23 more]", fmt.Sprintf("%.2v", long))
+	suite.Equal("values[...25 more]", fmt.Sprintf("%.0v", long))
+	suite.Equal(fmt.Sprint([]interface{}(long)), fmt.Sprintf("%+v", long)[len("values"):])
+	suite.Equal("values[0 1 2]", fmt.Sprintf("%.3v", long[:3]))
+}
+
+func (suite *FormatTestSuite) TestFormatEntries() {
+	e := entries{{1, "a"}, {2, "b"}, {3, "c"}}
+
+	suite.Equal("entries[1:a 2:b 3:c]", fmt.Sprint(e))
+	suite.Equal(`entries["x":"y"]`, fmt.Sprintf("%q", entries{{"x", "y"}}))
+	suite.Equal("entries[1:a ...2 more]", fmt.Sprintf("%.1v", e))
+	suite.Equal("entries[]", fmt.Sprint(entries{}))
+}
+
+func TestFormatTestSuite(t *testing.T) {
+	suite.Run(t, new(FormatTestSuite))
+}
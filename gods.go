@@ -1 +1,53 @@
 package gods
+
+type Container interface {
+	Len() int
+	IsEmpty() bool
+	Clear()
+	Values() []interface{}
+}
+
+// Iterable visits elements in container order until fn returns false.
+// Maps visit their keys.
+type Iterable interface {
+	Each(fn func(value interface{}) bool)
+}
+
+// Ordered containers keep their elements sorted by a comparer. First and
+// Last return keys for maps and values otherwise.
+type Ordered interface {
+	Container
+	Iterable
+	First() (interface{}, bool)
+	Last() (interface{}, bool)
+}
+
+type Set interface {
+	Container
+	Insert(value interface{}) bool
+	Delete(value interface{}) bool
+	Contains(value interface{}) bool
+}
+
+type Map interface {
+	Container
+	Insert(key, value interface{}) bool
+	Get(key interface{}) (interface{}, bool)
+	Delete(key interface{}) bool
+	Contains(key interface{}) bool
+	Keys() []interface{}
+}
+
+type Queue interface {
+	Container
+	Push(value interface{})
+	Pop() interface{}
+	Peek() interface{}
+}
+
+type Stack interface {
+	Container
+	Push(value interface{})
+	Pop() interface{}
+	Peek() interface{}
+}
@@ -0,0 +1,76 @@
+package godstest
+
+import (
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"sync"
+)
+
+const (
+	modelIterations = 2000
+	modelKeySpace   = 64
+	goroutines      = 8
+	perGoroutine    = 250
+)
+
+func checkIterable(s *suite.Suite, container gods.Container, expected []interface{}) {
+	iterable, ok := container.(gods.Iterable)
+
+	if !ok {
+		return
+	}
+
+	visited := make([]interface{}, 0, len(expected))
+
+	iterable.Each(func(value interface{}) bool {
+		visited = append(visited, value)
+		return true
+	})
+
+	equalValues(s, expected, visited)
+
+	if len(expected) > 1 {
+		count := 0
+
+		iterable.Each(func(value interface{}) bool {
+			count++
+			return false
+		})
+
+		s.Equal(1, count)
+	}
+}
+
+func equalValues(s *suite.Suite, expected, actual []interface{}) {
+	if len(expected) == 0 {
+		s.Empty(actual)
+		return
+	}
+
+	s.Equal(expected, actual)
+}
+
+func runParallel(fn func(worker int)) {
+	var wg sync.WaitGroup
+
+	for w := 0; w < goroutines; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+			fn(w)
+		}(w)
+	}
+
+	wg.Wait()
+}
+
+func ints(from, to int) []interface{} {
+	values := make([]interface{}, 0, to-from)
+
+	for i := from; i < to; i++ {
+		values = append(values, i)
+	}
+
+	return values
+}
@@ -0,0 +1,183 @@
+package godstest
+
+import (
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+)
+
+type MapSuite struct {
+	suite.Suite
+	New             func() gods.Map
+	SkipConcurrency bool
+}
+
+func (s *MapSuite) TestMapEmpty() {
+	m := s.New()
+
+	s.Equal(0, m.Len())
+	s.True(m.IsEmpty())
+	s.False(m.Contains(1))
+	s.False(m.Delete(1))
+	s.Empty(m.Keys())
+	s.Empty(m.Values())
+
+	value, ok := m.Get(1)
+	s.False(ok)
+	s.Nil(value)
+}
+
+func (s *MapSuite) TestMapInsertGetDelete() {
+	m := s.New()
+
+	s.True(m.Insert(1, "one"))
+	s.True(m.Insert(2, "two"))
+	s.False(m.Insert(1, "ONE"))
+	s.Equal(2, m.Len())
+
+	value, ok := m.Get(1)
+	s.True(ok)
+	s.Equal("ONE", value)
+	s.True(m.Contains(2))
+
+	s.True(m.Delete(1))
+	s.False(m.Delete(1))
+	s.False(m.Contains(1))
+	s.Equal(1, m.Len())
+	s.Equal([]interface{}{2}, m.Keys())
+	s.Equal([]interface{}{"two"}, m.Values())
+}
+
+func (s *MapSuite) TestMapOrdering() {
+	m := s.New()
+	ordered, ok := m.(gods.Ordered)
+
+	if !ok {
+		s.T().Skip("implementation is not ordered")
+	}
+
+	for _, i := range rand.New(rand.NewSource(1)).Perm(20) {
+		m.Insert(i, i*10)
+	}
+
+	values := make([]interface{}, 0, 20)
+
+	for i := 0; i < 20; i++ {
+		values = append(values, i*10)
+	}
+
+	s.Equal(ints(0, 20), m.Keys())
+	s.Equal(values, m.Values())
+	checkIterable(&s.Suite, m, ints(0, 20))
+
+	first, _ := ordered.First()
+	last, _ := ordered.Last()
+	s.Equal(0, first)
+	s.Equal(19, last)
+}
+
+func (s *MapSuite) TestMapClear() {
+	m := s.New()
+
+	for i := 0; i < 5; i++ {
+		m.Insert(i, i)
+	}
+
+	m.Clear()
+
+	s.Equal(0, m.Len())
+	s.True(m.IsEmpty())
+	s.False(m.Contains(0))
+	s.True(m.Insert(0, 0))
+}
+
+func (s *MapSuite) TestMapModel() {
+	m := s.New()
+	r := rand.New(rand.NewSource(1))
+	model := make(map[int]int)
+
+	for i := 0; i < modelIterations; i++ {
+		key := r.Intn(modelKeySpace)
+		expected, present := model[key]
+
+		switch r.Intn(5) {
+		case 0, 1:
+			s.Equal(!present, m.Insert(key, i), "insert %d", key)
+			model[key] = i
+		case 2:
+			s.Equal(present, m.Delete(key), "delete %d", key)
+			delete(model, key)
+		case 3:
+			value, ok := m.Get(key)
+			s.Equal(present, ok, "get %d", key)
+
+			if present {
+				s.Equal(expected, value, "get %d", key)
+			}
+		case 4:
+			if r.Intn(50) == 0 {
+				m.Clear()
+				model = make(map[int]int)
+			}
+		}
+
+		s.Equal(len(model), m.Len())
+		s.Equal(len(model) == 0, m.IsEmpty())
+	}
+
+	sorted := make([]int, 0, len(model))
+
+	for key := range model {
+		sorted = append(sorted, key)
+	}
+
+	sort.Ints(sorted)
+
+	keys := make([]interface{}, 0, len(sorted))
+	values := make([]interface{}, 0, len(sorted))
+
+	for _, key := range sorted {
+		keys = append(keys, key)
+		values = append(values, model[key])
+	}
+
+	if _, ok := m.(gods.Ordered); ok {
+		equalValues(&s.Suite, keys, m.Keys())
+		equalValues(&s.Suite, values, m.Values())
+	} else {
+		s.ElementsMatch(keys, m.Keys())
+		s.ElementsMatch(values, m.Values())
+	}
+}
+
+func (s *MapSuite) TestMapConcurrentInsertDelete() {
+	if s.SkipConcurrency {
+		s.T().Skip("implementation is not safe for concurrent use")
+	}
+
+	m := s.New()
+
+	runParallel(func(w int) {
+		for i := 0; i < perGoroutine; i++ {
+			key := i*goroutines + w
+			m.Insert(key, key)
+			m.Get(key - goroutines)
+
+			if i%2 == 1 {
+				m.Delete(key)
+			}
+		}
+	})
+
+	s.Equal(goroutines*perGoroutine/2, m.Len())
+
+	for key := 0; key < goroutines*perGoroutine; key++ {
+		value, ok := m.Get(key)
+		s.Equal((key/goroutines)%2 == 0, ok, "get %d", key)
+
+		if ok {
+			s.Equal(key, value)
+		}
+	}
+}
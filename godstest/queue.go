@@ -0,0 +1,142 @@
+package godstest
+
+import (
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+)
+
+type QueueSuite struct {
+	suite.Suite
+	New             func() gods.Queue
+	SkipConcurrency bool
+}
+
+func (s *QueueSuite) TestQueueEmpty() {
+	q := s.New()
+
+	s.Equal(0, q.Len())
+	s.True(q.IsEmpty())
+	s.Nil(q.Peek())
+	s.Nil(q.Pop())
+	s.Empty(q.Values())
+	s.Equal(0, q.Len())
+}
+
+func (s *QueueSuite) TestQueueOrdering() {
+	q := s.New()
+
+	for i, value := range ints(0, 10) {
+		q.Push(value)
+		s.Equal(i+1, q.Len())
+		s.Equal(0, q.Peek())
+	}
+
+	s.Equal(ints(0, 10), q.Values())
+	checkIterable(&s.Suite, q, ints(0, 10))
+
+	for i := 0; i < 10; i++ {
+		s.Equal(i, q.Peek())
+		s.Equal(i, q.Pop())
+		s.Equal(9-i, q.Len())
+	}
+
+	s.True(q.IsEmpty())
+	s.Nil(q.Pop())
+}
+
+func (s *QueueSuite) TestQueueClear() {
+	q := s.New()
+
+	for _, value := range ints(0, 5) {
+		q.Push(value)
+	}
+
+	q.Clear()
+
+	s.Equal(0, q.Len())
+	s.True(q.IsEmpty())
+	s.Nil(q.Peek())
+
+	q.Push(42)
+	s.Equal(42, q.Pop())
+}
+
+func (s *QueueSuite) TestQueueModel() {
+	q := s.New()
+	r := rand.New(rand.NewSource(1))
+
+	var model []interface{}
+
+	for i := 0; i < modelIterations; i++ {
+		switch r.Intn(4) {
+		case 0, 1:
+			q.Push(i)
+			model = append(model, i)
+		case 2:
+			if len(model) == 0 {
+				s.Nil(q.Pop())
+			} else {
+				s.Equal(model[0], q.Pop())
+				model = model[1:]
+			}
+		case 3:
+			if r.Intn(50) == 0 {
+				q.Clear()
+				model = nil
+			}
+		}
+
+		s.Equal(len(model), q.Len())
+		s.Equal(len(model) == 0, q.IsEmpty())
+
+		if len(model) > 0 {
+			s.Equal(model[0], q.Peek())
+		}
+	}
+
+	equalValues(&s.Suite, model, q.Values())
+}
+
+func (s *QueueSuite) TestQueueConcurrentPushPop() {
+	if s.SkipConcurrency {
+		s.T().Skip("implementation is not safe for concurrent use")
+	}
+
+	q := s.New()
+
+	runParallel(func(w int) {
+		for i := 0; i < perGoroutine; i++ {
+			q.Push(w*perGoroutine + i)
+		}
+	})
+
+	s.Equal(goroutines*perGoroutine, q.Len())
+
+	popped := make([][]int, goroutines)
+
+	runParallel(func(w int) {
+		for i := 0; i < perGoroutine; i++ {
+			if value := q.Pop(); value != nil {
+				popped[w] = append(popped[w], value.(int))
+			}
+		}
+	})
+
+	var all []int
+
+	for _, values := range popped {
+		all = append(all, values...)
+	}
+
+	sort.Ints(all)
+
+	s.Len(all, goroutines*perGoroutine)
+
+	for i, value := range all {
+		s.Equal(i, value)
+	}
+
+	s.True(q.IsEmpty())
+}
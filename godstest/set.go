@@ -0,0 +1,160 @@
+package godstest
+
+import (
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+)
+
+type SetSuite struct {
+	suite.Suite
+	New             func() gods.Set
+	SkipConcurrency bool
+}
+
+func (s *SetSuite) TestSetEmpty() {
+	set := s.New()
+
+	s.Equal(0, set.Len())
+	s.True(set.IsEmpty())
+	s.False(set.Contains(1))
+	s.False(set.Delete(1))
+	s.Empty(set.Values())
+
+	if ordered, ok := set.(gods.Ordered); ok {
+		_, found := ordered.First()
+		s.False(found)
+
+		_, found = ordered.Last()
+		s.False(found)
+	}
+}
+
+func (s *SetSuite) TestSetInsertDelete() {
+	set := s.New()
+
+	s.True(set.Insert(1))
+	s.True(set.Insert(2))
+	s.False(set.Insert(1))
+	s.Equal(2, set.Len())
+	s.True(set.Contains(1))
+	s.True(set.Contains(2))
+
+	s.True(set.Delete(1))
+	s.False(set.Delete(1))
+	s.False(set.Contains(1))
+	s.Equal(1, set.Len())
+	s.Equal([]interface{}{2}, set.Values())
+}
+
+func (s *SetSuite) TestSetOrdering() {
+	set := s.New()
+	ordered, ok := set.(gods.Ordered)
+
+	if !ok {
+		s.T().Skip("implementation is not ordered")
+	}
+
+	for _, i := range rand.New(rand.NewSource(1)).Perm(20) {
+		set.Insert(i)
+	}
+
+	s.Equal(ints(0, 20), set.Values())
+	checkIterable(&s.Suite, set, ints(0, 20))
+
+	first, _ := ordered.First()
+	last, _ := ordered.Last()
+	s.Equal(0, first)
+	s.Equal(19, last)
+}
+
+func (s *SetSuite) TestSetClear() {
+	set := s.New()
+
+	for i := 0; i < 5; i++ {
+		set.Insert(i)
+	}
+
+	set.Clear()
+
+	s.Equal(0, set.Len())
+	s.True(set.IsEmpty())
+	s.False(set.Contains(0))
+	s.True(set.Insert(0))
+}
+
+func (s *SetSuite) TestSetModel() {
+	set := s.New()
+	r := rand.New(rand.NewSource(1))
+	model := make(map[int]bool)
+
+	for i := 0; i < modelIterations; i++ {
+		value := r.Intn(modelKeySpace)
+
+		switch r.Intn(5) {
+		case 0, 1:
+			s.Equal(!model[value], set.Insert(value), "insert %d", value)
+			model[value] = true
+		case 2:
+			s.Equal(model[value], set.Delete(value), "delete %d", value)
+			delete(model, value)
+		case 3:
+			s.Equal(model[value], set.Contains(value), "contains %d", value)
+		case 4:
+			if r.Intn(50) == 0 {
+				set.Clear()
+				model = make(map[int]bool)
+			}
+		}
+
+		s.Equal(len(model), set.Len())
+		s.Equal(len(model) == 0, set.IsEmpty())
+	}
+
+	expected := make([]int, 0, len(model))
+
+	for value := range model {
+		expected = append(expected, value)
+	}
+
+	sort.Ints(expected)
+
+	values := make([]interface{}, 0, len(expected))
+
+	for _, value := range expected {
+		values = append(values, value)
+	}
+
+	if _, ok := set.(gods.Ordered); ok {
+		equalValues(&s.Suite, values, set.Values())
+	} else {
+		s.ElementsMatch(values, set.Values())
+	}
+}
+
+func (s *SetSuite) TestSetConcurrentInsertDelete() {
+	if s.SkipConcurrency {
+		s.T().Skip("implementation is not safe for concurrent use")
+	}
+
+	set := s.New()
+
+	runParallel(func(w int) {
+		for i := 0; i < perGoroutine; i++ {
+			value := i*goroutines + w
+			set.Insert(value)
+			set.Contains(value - goroutines)
+
+			if i%2 == 1 {
+				set.Delete(value)
+			}
+		}
+	})
+
+	s.Equal(goroutines*perGoroutine/2, set.Len())
+
+	for value := 0; value < goroutines*perGoroutine; value++ {
+		s.Equal((value/goroutines)%2 == 0, set.Contains(value), "contains %d", value)
+	}
+}
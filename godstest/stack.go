@@ -0,0 +1,154 @@
+package godstest
+
+import (
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+)
+
+type StackSuite struct {
+	suite.Suite
+	New             func() gods.Stack
+	SkipConcurrency bool
+}
+
+func (s *StackSuite) TestStackEmpty() {
+	st := s.New()
+
+	s.Equal(0, st.Len())
+	s.True(st.IsEmpty())
+	s.Nil(st.Peek())
+	s.Nil(st.Pop())
+	s.Empty(st.Values())
+	s.Equal(0, st.Len())
+}
+
+func (s *StackSuite) TestStackOrdering() {
+	st := s.New()
+
+	for i, value := range ints(0, 10) {
+		st.Push(value)
+		s.Equal(i+1, st.Len())
+		s.Equal(i, st.Peek())
+	}
+
+	expected := make([]interface{}, 0, 10)
+
+	for i := 9; i >= 0; i-- {
+		expected = append(expected, i)
+	}
+
+	s.Equal(expected, st.Values())
+	checkIterable(&s.Suite, st, expected)
+
+	for i := 9; i >= 0; i-- {
+		s.Equal(i, st.Peek())
+		s.Equal(i, st.Pop())
+		s.Equal(i, st.Len())
+	}
+
+	s.True(st.IsEmpty())
+	s.Nil(st.Pop())
+}
+
+func (s *StackSuite) TestStackClear() {
+	st := s.New()
+
+	for _, value := range ints(0, 5) {
+		st.Push(value)
+	}
+
+	st.Clear()
+
+	s.Equal(0, st.Len())
+	s.True(st.IsEmpty())
+	s.Nil(st.Peek())
+
+	st.Push(42)
+	s.Equal(42, st.Pop())
+}
+
+func (s *StackSuite) TestStackModel() {
+	st := s.New()
+	r := rand.New(rand.NewSource(1))
+
+	var model []interface{}
+
+	for i := 0; i < modelIterations; i++ {
+		switch r.Intn(4) {
+		case 0, 1:
+			st.Push(i)
+			model = append(model, i)
+		case 2:
+			if len(model) == 0 {
+				s.Nil(st.Pop())
+			} else {
+				s.Equal(model[len(model)-1], st.Pop())
+				model = model[:len(model)-1]
+			}
+		case 3:
+			if r.Intn(50) == 0 {
+				st.Clear()
+				model = nil
+			}
+		}
+
+		s.Equal(len(model), st.Len())
+		s.Equal(len(model) == 0, st.IsEmpty())
+
+		if len(model) > 0 {
+			s.Equal(model[len(model)-1], st.Peek())
+		}
+	}
+
+	expected := make([]interface{}, 0, len(model))
+
+	for i := len(model) - 1; i >= 0; i-- {
+		expected = append(expected, model[i])
+	}
+
+	equalValues(&s.Suite, expected, st.Values())
+}
+
+func (s *StackSuite) TestStackConcurrentPushPop() {
+	if s.SkipConcurrency {
+		s.T().Skip("implementation is not safe for concurrent use")
+	}
+
+	st := s.New()
+
+	runParallel(func(w int) {
+		for i := 0; i < perGoroutine; i++ {
+			st.Push(w*perGoroutine + i)
+		}
+	})
+
+	s.Equal(goroutines*perGoroutine, st.Len())
+
+	popped := make([][]int, goroutines)
+
+	runParallel(func(w int) {
+		for i := 0; i < perGoroutine; i++ {
+			if value := st.Pop(); value != nil {
+				popped[w] = append(popped[w], value.(int))
+			}
+		}
+	})
+
+	var all []int
+
+	for _, values := range popped {
+		all = append(all, values...)
+	}
+
+	sort.Ints(all)
+
+	s.Len(all, goroutines*perGoroutine)
+
+	for i, value := range all {
+		s.Equal(i, value)
+	}
+
+	s.True(st.IsEmpty())
+}
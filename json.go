@@ -0,0 +1,161 @@
+package gods
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Decoder decodes a single JSON element.
+type Decoder func(data []byte) (interface{}, error)
+
+// KeyDecoder decodes a JSON object key.
+type KeyDecoder func(key string) (interface{}, error)
+
+func DecodeAs[T any](data []byte) (interface{}, error) {
+	var value T
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// DecodeKeyAs decodes key as a quoted JSON string first, so strings and
+// encoding.TextUnmarshaler types work, then as a bare JSON literal for
+// numbers and booleans.
+func DecodeKeyAs[K any](key string) (interface{}, error) {
+	var value K
+
+	if err := json.Unmarshal([]byte(strconv.Quote(key)), &value); err == nil {
+		return value, nil
+	}
+
+	if err := json.Unmarshal([]byte(key), &value); err != nil {
+		return nil, fmt.Errorf("gods: cannot decode key %q: %w", key, err)
+	}
+
+	return value, nil
+}
+
+// UnmarshalArray decodes a JSON array element by element. A JSON null
+// decodes as an empty slice.
+func UnmarshalArray(data []byte, decode Decoder) ([]interface{}, error) {
+	var raw []json.RawMessage
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(raw))
+
+	for i, element := range raw {
+		value, err := decode(element)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// MarshalObject encodes keys and values as a JSON object, keeping the given
+// key order.
+func MarshalObject(keys, values []interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteByte('{')
+
+	for i, key := range keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, err := marshalKey(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(values[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		quoted, _ := json.Marshal(name)
+
+		buffer.Write(quoted)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalObject decodes a JSON object into parallel key and value slices
+// in no particular order. A JSON null decodes as empty slices.
+func UnmarshalObject(data []byte, decodeKey KeyDecoder, decode Decoder) ([]interface{}, []interface{}, error) {
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]interface{}, 0, len(raw))
+	values := make([]interface{}, 0, len(raw))
+
+	for name, element := range raw {
+		key, err := decodeKey(name)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		value, err := decode(element)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	return keys, values, nil
+}
+
+func marshalKey(key interface{}) (string, error) {
+	switch k := key.(type) {
+	case string:
+		return k, nil
+	case encoding.TextMarshaler:
+		text, err := k.MarshalText()
+		return string(text), err
+	}
+
+	data, err := json.Marshal(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	switch data[0] {
+	case '"':
+		var name string
+		err = json.Unmarshal(data, &name)
+		return name, err
+	case '{', '[', 'n':
+		return "", fmt.Errorf("gods: unsupported map key %v", key)
+	}
+
+	return string(data), nil
+}
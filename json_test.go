@@ -0,0 +1,102 @@
+package gods
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type JSONTestSuite struct {
+	suite.Suite
+}
+
+func (suite *JSONTestSuite) TestDecodeAs() {
+	value, err := DecodeAs[int]([]byte(`42`))
+	suite.NoError(err)
+	suite.Equal(42, value)
+
+	value, err = DecodeAs[interface{}]([]byte(`42`))
+	suite.NoError(err)
+	suite.Equal(42.0, value)
+
+	_, err = DecodeAs[int]([]byte(`"42"`))
+	suite.Error(err)
+}
+
+func (suite *JSONTestSuite) TestDecodeKeyAs() {
+	key, err := DecodeKeyAs[string]("1")
+	suite.NoError(err)
+	suite.Equal("1", key)
+
+	key, err = DecodeKeyAs[int]("-7")
+	suite.NoError(err)
+	suite.Equal(-7, key)
+
+	key, err = DecodeKeyAs[bool]("true")
+	suite.NoError(err)
+	suite.Equal(true, key)
+
+	key, err = DecodeKeyAs[time.Time]("2020-01-02T03:04:05Z")
+	suite.NoError(err)
+	suite.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), key)
+
+	_, err = DecodeKeyAs[int]("seven")
+	suite.Error(err)
+}
+
+func (suite *JSONTestSuite) TestMarshalObject() {
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data, err := MarshalObject([]interface{}{"a\"b", 2, 1.5, when}, []interface{}{1, nil, []int{1}, "t"})
+	suite.NoError(err)
+	suite.Equal(`{"a\"b":1,"2":null,"1.5":[1],"2020-01-02T03:04:05Z":"t"}`, string(data))
+
+	data, err = MarshalObject(nil, nil)
+	suite.NoError(err)
+	suite.Equal(`{}`, string(data))
+
+	_, err = MarshalObject([]interface{}{[]int{1}}, []interface{}{1})
+	suite.Error(err)
+
+	_, err = MarshalObject([]interface{}{nil}, []interface{}{1})
+	suite.Error(err)
+
+	_, err = MarshalObject([]interface{}{"x"}, []interface{}{func() {}})
+	suite.Error(err)
+}
+
+func (suite *JSONTestSuite) TestUnmarshalArray() {
+	values, err := UnmarshalArray([]byte(`[1,2]`), DecodeAs[int])
+	suite.NoError(err)
+	suite.Equal([]interface{}{1, 2}, values)
+
+	values, err = UnmarshalArray([]byte(`null`), DecodeAs[int])
+	suite.NoError(err)
+	suite.Empty(values)
+
+	_, err = UnmarshalArray([]byte(`{}`), DecodeAs[int])
+	suite.Error(err)
+
+	_, err = UnmarshalArray([]byte(`[1,"2"]`), DecodeAs[int])
+	suite.Error(err)
+}
+
+func (suite *JSONTestSuite) TestUnmarshalObject() {
+	keys, values, err := UnmarshalObject([]byte(`{"3":"c"}`), DecodeKeyAs[int], DecodeAs[string])
+	suite.NoError(err)
+	suite.Equal([]interface{}{3}, keys)
+	suite.Equal([]interface{}{"c"}, values)
+
+	_, _, err = UnmarshalObject([]byte(`{"c":"c"}`), DecodeKeyAs[int], DecodeAs[string])
+	suite.Error(err)
+
+	_, _, err = UnmarshalObject([]byte(`{"3":3}`), DecodeKeyAs[int], DecodeAs[string])
+	suite.Error(err)
+
+	_, _, err = UnmarshalObject([]byte(`[]`), DecodeKeyAs[int], DecodeAs[string])
+	suite.Error(err)
+}
+
+func TestJSONTestSuite(t *testing.T) {
+	suite.Run(t, new(JSONTestSuite))
+}
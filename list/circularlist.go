@@ -0,0 +1,314 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+)
+
+var (
+	_ gods.Container = (*CircularList)(nil)
+	_ gods.Iterable  = (*CircularList)(nil)
+)
+
+type CircularList struct {
+	tail    *SinglyNode
+	count   int
+	mutex   gods.Locker
+	element gods.Decoder
+	codec   gods.Codec
+}
+
+func NewCircularList(options ...gods.Option) *CircularList {
+	opts := gods.NewOptions(options...)
+	_, codec := opts.Codecs()
+
+	return &CircularList{
+		tail:    nil,
+		count:   0,
+		mutex:   opts.Locker(),
+		element: opts.ElementDecoder(),
+		codec:   codec,
+	}
+}
+
+func (l *CircularList) Len() int {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.count
+}
+
+func (l *CircularList) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l *CircularList) Clear() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.tail = nil
+	l.count = 0
+}
+
+func (l *CircularList) Values() []interface{} {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	values := make([]interface{}, 0, l.count)
+
+	if l.tail == nil {
+		return values
+	}
+
+	node := l.tail.next
+
+	for i := 0; i < l.count; i++ {
+		values = append(values, node.Value)
+		node = node.next
+	}
+
+	return values
+}
+
+func (l *CircularList) Each(fn func(value interface{}) bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if l.tail == nil {
+		return
+	}
+
+	node := l.tail.next
+
+	for i := 0; i < l.count; i++ {
+		if !fn(node.Value) {
+			return
+		}
+
+		node = node.next
+	}
+}
+
+func (l *CircularList) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "CircularList", l.Len(), l.Each)
+}
+
+func (l *CircularList) String() string {
+	return fmt.Sprint(l)
+}
+
+func (l *CircularList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Values())
+}
+
+func (l *CircularList) UnmarshalJSON(data []byte) error {
+	l.lazyInit()
+
+	values, err := gods.UnmarshalArray(data, l.element)
+
+	if err != nil {
+		return err
+	}
+
+	fresh := NewCircularList(gods.WithLock(gods.NoLock))
+
+	for _, value := range values {
+		fresh.PushBack(&SinglyNode{Value: value})
+	}
+
+	l.swap(fresh)
+
+	return nil
+}
+
+func (l *CircularList) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindList, l.codec, l.Each)
+}
+
+func (l *CircularList) ReadFrom(r io.Reader) (int64, error) {
+	l.lazyInit()
+
+	fresh := NewCircularList(gods.WithLock(gods.NoLock))
+
+	n, err := gods.ReadValues(r, gods.KindList, l.codec, func(value interface{}) {
+		fresh.PushBack(&SinglyNode{Value: value})
+	})
+
+	if err == nil {
+		l.swap(fresh)
+	}
+
+	return n, err
+}
+
+func (l *CircularList) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(l)
+}
+
+func (l *CircularList) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(l, data)
+}
+
+func (l *CircularList) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+func (l *CircularList) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+func (l *CircularList) Front() *SinglyNode {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if l.tail == nil {
+		return nil
+	}
+
+	return l.tail.next
+}
+
+func (l *CircularList) Back() *SinglyNode {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.tail
+}
+
+func (l *CircularList) PushFront(insertNode *SinglyNode) *SinglyNode {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.link(insertNode)
+
+	return insertNode
+}
+
+func (l *CircularList) PushBack(insertNode *SinglyNode) *SinglyNode {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.link(insertNode)
+	l.tail = insertNode
+
+	return insertNode
+}
+
+func (l *CircularList) PopFront() *SinglyNode {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.tail == nil {
+		return nil
+	}
+
+	front := l.tail.next
+
+	if front == l.tail {
+		l.tail = nil
+	} else {
+		l.tail.next = front.next
+	}
+
+	front.next = nil
+	l.count--
+
+	return front
+}
+
+func (l *CircularList) Remove(removeItem *SinglyNode) *SinglyNode {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.tail == nil {
+		return nil
+	}
+
+	prev := l.tail
+
+	for i := 0; i < l.count; i++ {
+		if prev.next == removeItem {
+			if removeItem == prev {
+				l.tail = nil
+			} else {
+				prev.next = removeItem.next
+
+				if removeItem == l.tail {
+					l.tail = prev
+				}
+			}
+
+			removeItem.next = nil
+			l.count--
+
+			return removeItem
+		}
+
+		prev = prev.next
+	}
+
+	return nil
+}
+
+func (l *CircularList) Rotate(n int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.count == 0 {
+		return
+	}
+
+	steps := n % l.count
+
+	if steps < 0 {
+		steps += l.count
+	}
+
+	for i := 0; i < steps; i++ {
+		l.tail = l.tail.next
+	}
+}
+
+func (l *CircularList) Traverse(runner func(node *SinglyNode)) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if l.tail == nil {
+		return
+	}
+
+	node := l.tail.next
+
+	for i := 0; i < l.count; i++ {
+		next := node.next
+		runner(node)
+		node = next
+	}
+}
+
+func (l *CircularList) lazyInit() {
+	if l.mutex == nil {
+		*l = *NewCircularList()
+	}
+}
+
+func (l *CircularList) swap(other *CircularList) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.tail, l.count = other.tail, other.count
+}
+
+func (l *CircularList) link(insertNode *SinglyNode) {
+	if l.tail == nil {
+		insertNode.next = insertNode
+		l.tail = insertNode
+	} else {
+		insertNode.next = l.tail.next
+		l.tail.next = insertNode
+	}
+
+	l.count++
+}
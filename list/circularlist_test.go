@@ -0,0 +1,247 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type CircularListTestSuite struct {
+	suite.Suite
+	ring *CircularList
+}
+
+func (suite *CircularListTestSuite) SetupTest() {
+	suite.ring = NewCircularList()
+}
+
+func (suite *CircularListTestSuite) values() []interface{} {
+	var values []interface{}
+
+	suite.ring.Traverse(func(node *SinglyNode) {
+		values = append(values, node.Value)
+	})
+
+	return values
+}
+
+func (suite *CircularListTestSuite) TestCircularListPush() {
+	suite.Nil(suite.ring.Front())
+	suite.Nil(suite.ring.Back())
+
+	node1 := suite.ring.PushBack(&SinglyNode{Value: 2})
+	suite.Equal(node1, node1.Next())
+
+	node2 := suite.ring.PushFront(&SinglyNode{Value: 1})
+	node3 := suite.ring.PushBack(&SinglyNode{Value: 3})
+
+	suite.Equal(3, suite.ring.Len())
+	suite.Equal(node2, suite.ring.Front())
+	suite.Equal(node3, suite.ring.Back())
+	suite.Equal(node2, node3.Next())
+	suite.Equal([]interface{}{1, 2, 3}, suite.values())
+}
+
+func (suite *CircularListTestSuite) TestCircularListRotate() {
+	suite.ring.Rotate(3)
+	suite.Nil(suite.ring.Front())
+
+	for i := 1; i <= 5; i++ {
+		suite.ring.PushBack(&SinglyNode{Value: i})
+	}
+
+	suite.ring.Rotate(2)
+	suite.Equal([]interface{}{3, 4, 5, 1, 2}, suite.values())
+
+	suite.ring.Rotate(-1)
+	suite.Equal([]interface{}{2, 3, 4, 5, 1}, suite.values())
+
+	suite.ring.Rotate(11)
+	suite.Equal([]interface{}{3, 4, 5, 1, 2}, suite.values())
+	suite.Equal(3, suite.ring.Front().Value)
+	suite.Equal(2, suite.ring.Back().Value)
+}
+
+func (suite *CircularListTestSuite) TestCircularListPopFront() {
+	suite.Nil(suite.ring.PopFront())
+
+	suite.ring.PushBack(&SinglyNode{Value: 1})
+	suite.ring.PushBack(&SinglyNode{Value: 2})
+
+	popped := suite.ring.PopFront()
+	suite.Equal(1, popped.Value)
+	suite.Nil(popped.Next())
+	suite.Equal(1, suite.ring.Len())
+	suite.Equal(suite.ring.Front(), suite.ring.Front().Next())
+
+	suite.Equal(2, suite.ring.PopFront().Value)
+	suite.Equal(0, suite.ring.Len())
+	suite.Nil(suite.ring.Back())
+}
+
+func (suite *CircularListTestSuite) TestCircularListRemove() {
+	nodes := make([]*SinglyNode, 4)
+
+	for i := range nodes {
+		nodes[i] = suite.ring.PushBack(&SinglyNode{Value: i})
+	}
+
+	suite.Nil(suite.ring.Remove(&SinglyNode{Value: 9}))
+
+	suite.Equal(nodes[3], suite.ring.Remove(nodes[3]))
+	suite.Equal(nodes[2], suite.ring.Back())
+	suite.Equal(nodes[1], suite.ring.Remove(nodes[1]))
+	suite.Equal([]interface{}{0, 2}, suite.values())
+
+	suite.ring.Remove(nodes[0])
+	suite.ring.Remove(nodes[2])
+	suite.Equal(0, suite.ring.Len())
+	suite.Nil(suite.ring.Front())
+}
+
+func (suite *CircularListTestSuite) TestCircularListContainer() {
+	suite.True(suite.ring.IsEmpty())
+
+	for i := 1; i <= 3; i++ {
+		suite.ring.PushBack(&SinglyNode{Value: i})
+	}
+
+	suite.ring.Rotate(1)
+	suite.False(suite.ring.IsEmpty())
+	suite.Equal([]interface{}{2, 3, 1}, suite.ring.Values())
+
+	var visited []interface{}
+	suite.ring.Each(func(value interface{}) bool {
+		visited = append(visited, value)
+		return len(visited) < 2
+	})
+	suite.Equal([]interface{}{2, 3}, visited)
+
+	suite.ring.Clear()
+	suite.True(suite.ring.IsEmpty())
+	suite.Nil(suite.ring.Front())
+}
+
+func (suite *CircularListTestSuite) TestCircularListJSON() {
+	decoded := NewCircularList(gods.WithElement[int]())
+	suite.NoError(json.Unmarshal([]byte(`[1,2,3]`), decoded))
+	suite.Equal([]interface{}{1, 2, 3}, decoded.Values())
+	suite.Equal(3, decoded.Back().Value)
+
+	decoded.Rotate(1)
+
+	data, err := json.Marshal(decoded)
+	suite.NoError(err)
+	suite.JSONEq(`[2,3,1]`, string(data))
+}
+
+func (suite *CircularListTestSuite) TestCircularListBinary() {
+	source := NewLinkedList(gods.WithCodec(gods.IntCodec))
+	source.PushBack(&Node{Value: 1})
+	source.PushBack(&Node{Value: 2})
+
+	var buffer bytes.Buffer
+
+	_, err := source.WriteTo(&buffer)
+	suite.NoError(err)
+
+	decoded := NewCircularList(gods.WithCodec(gods.IntCodec))
+	_, err = decoded.ReadFrom(&buffer)
+	suite.NoError(err)
+	suite.Equal([]interface{}{1, 2}, decoded.Values())
+
+	decoded.Rotate(1)
+
+	data, err := decoded.MarshalBinary()
+	suite.NoError(err)
+	rotated := NewCircularList(gods.WithCodec(gods.IntCodec))
+	suite.NoError(rotated.UnmarshalBinary(data))
+	suite.Equal([]interface{}{2, 1}, rotated.Values())
+}
+
+func (suite *CircularListTestSuite) TestCircularListFormat() {
+	suite.ring.PushBack(&SinglyNode{Value: 1})
+	suite.ring.PushBack(&SinglyNode{Value: 2})
+	suite.ring.Rotate(1)
+
+	suite.Equal("CircularList[2 1]", suite.ring.String())
+	suite.Equal("CircularList[2 ...1 more]", fmt.Sprintf("%.1v", suite.ring))
+}
+
+func TestCircularListTestSuite(t *testing.T) {
+	suite.Run(t, new(CircularListTestSuite))
+}
+
+func FuzzCircularList(f *testing.F) {
+	f.Add([]byte{0, 1, 1, 2, 1, 3, 3, 1, 4, 0, 2, 0})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		ring := NewCircularList()
+
+		var model []*SinglyNode
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 6 {
+			case 0:
+				model = append([]*SinglyNode{ring.PushFront(&SinglyNode{Value: value})}, model...)
+			case 1:
+				model = append(model, ring.PushBack(&SinglyNode{Value: value}))
+			case 2:
+				popped := ring.PopFront()
+
+				if len(model) == 0 {
+					if popped != nil {
+						t.Fatalf("popped %v from an empty ring", popped.Value)
+					}
+					continue
+				}
+
+				if popped != model[0] {
+					t.Fatalf("expected %v, popped %v", model[0].Value, popped.Value)
+				}
+
+				model = model[1:]
+			case 3:
+				steps := int(int8(ops[i+1]))
+				ring.Rotate(steps)
+
+				if len(model) > 0 {
+					steps = ((steps % len(model)) + len(model)) % len(model)
+					model = append(model[steps:], model[:steps]...)
+				}
+			case 4:
+				if len(model) == 0 {
+					continue
+				}
+
+				index := value % len(model)
+				ring.Remove(model[index])
+				model = append(model[:index], model[index+1:]...)
+			case 5:
+				if value%8 == 0 {
+					ring.Clear()
+					model = nil
+				}
+			}
+
+			if ring.Len() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), ring.Len())
+			}
+
+			for index, node := range model {
+				if node.Next() != model[(index+1)%len(model)] {
+					t.Fatalf("ring is broken at %d", index)
+				}
+			}
+
+			if len(model) > 0 && (ring.Front() != model[0] || ring.Back() != model[len(model)-1]) {
+				t.Fatalf("front or back is stale")
+			}
+		}
+	})
+}
@@ -1,16 +1,25 @@
 package list
 
 import (
-	"sync"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+	"sync/atomic"
 )
 
+// Node is an element of a LinkedList. The next link is atomic so that
+// snapshots can follow it without taking the list's lock.
 type Node struct {
-	next, prev *Node
-	Value      interface{}
+	next    atomic.Pointer[Node]
+	prev    *Node
+	version uint64
+	Value   interface{}
 }
 
 func (n *Node) Next() *Node {
-	return n.next
+	return n.next.Load()
 }
 
 func (n *Node) Prev() *Node {
@@ -21,36 +30,163 @@ func (n *Node) GetValue() interface{} {
 	return n.Value
 }
 
+var (
+	_ gods.Container = (*LinkedList)(nil)
+	_ gods.Iterable  = (*LinkedList)(nil)
+)
+
 type LinkedList struct {
 	head, tail *Node
 	count      int
-	mutex      *sync.Mutex
+	mutex      gods.Locker
+	element    gods.Decoder
+	codec      gods.Codec
+	epoch      *epoch
+	version    uint64
+	dirty      bool
 }
 
-func NewLinkedList() *LinkedList {
+func NewLinkedList(options ...gods.Option) *LinkedList {
 	head := &Node{Value: nil}
 	tail := &Node{Value: nil}
 
-	head.next = tail
+	head.next.Store(tail)
 	tail.prev = head
 
+	opts := gods.NewOptions(options...)
+	_, codec := opts.Codecs()
+
 	return &LinkedList{
-		head:  head,
-		tail:  tail,
-		count: 0,
-		mutex: new(sync.Mutex),
+		head:    head,
+		tail:    tail,
+		count:   0,
+		mutex:   opts.Locker(),
+		element: opts.ElementDecoder(),
+		codec:   codec,
 	}
 }
 
 func (l *LinkedList) Len() int {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
 	return l.count
 }
 
-func (l *LinkedList) Front() *Node {
+func (l *LinkedList) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l *LinkedList) Clear() {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
-	front := l.head.next
+	l.setNext(l.head, l.tail)
+	l.tail.prev = l.head
+	l.count = 0
+}
+
+func (l *LinkedList) Values() []interface{} {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	values := make([]interface{}, 0, l.count)
+
+	for node := l.head.Next(); node != l.tail; node = node.Next() {
+		values = append(values, node.Value)
+	}
+
+	return values
+}
+
+func (l *LinkedList) Each(fn func(value interface{}) bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	for node := l.head.Next(); node != l.tail; node = node.Next() {
+		if !fn(node.Value) {
+			return
+		}
+	}
+}
+
+func (l *LinkedList) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "LinkedList", l.Len(), l.Each)
+}
+
+func (l *LinkedList) String() string {
+	return fmt.Sprint(l)
+}
+
+func (l *LinkedList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Values())
+}
+
+// UnmarshalJSON replaces the contents of the list. A zero LinkedList is
+// initialized with the default options first.
+func (l *LinkedList) UnmarshalJSON(data []byte) error {
+	l.lazyInit()
+
+	values, err := gods.UnmarshalArray(data, l.element)
+
+	if err != nil {
+		return err
+	}
+
+	fresh := NewLinkedList(gods.WithLock(gods.NoLock))
+
+	for _, value := range values {
+		fresh.PushBack(&Node{Value: value})
+	}
+
+	l.swap(fresh)
+
+	return nil
+}
+
+func (l *LinkedList) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindList, l.codec, l.Each)
+}
+
+// ReadFrom replaces the contents of the list with a stream written by
+// WriteTo. The list is left unchanged if the stream is invalid.
+func (l *LinkedList) ReadFrom(r io.Reader) (int64, error) {
+	l.lazyInit()
+
+	fresh := NewLinkedList(gods.WithLock(gods.NoLock))
+
+	n, err := gods.ReadValues(r, gods.KindList, l.codec, func(value interface{}) {
+		fresh.PushBack(&Node{Value: value})
+	})
+
+	if err == nil {
+		l.swap(fresh)
+	}
+
+	return n, err
+}
+
+func (l *LinkedList) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(l)
+}
+
+func (l *LinkedList) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(l, data)
+}
+
+func (l *LinkedList) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+func (l *LinkedList) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+func (l *LinkedList) Front() *Node {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	front := l.head.Next()
 
 	if front == l.tail {
 		return nil
@@ -60,8 +196,8 @@ func (l *LinkedList) Front() *Node {
 }
 
 func (l *LinkedList) Back() *Node {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
 
 	back := l.tail.prev
 
@@ -77,10 +213,10 @@ func (l *LinkedList) PushFront(insertNode *Node) *Node {
 	defer l.mutex.Unlock()
 
 	head := l.head
-	headNext := head.next
-	head.next = insertNode
+	headNext := head.Next()
 	insertNode.prev = head
-	insertNode.next = headNext
+	l.attach(insertNode, headNext)
+	l.setNext(head, insertNode)
 	headNext.prev = insertNode
 	l.count++
 
@@ -93,9 +229,9 @@ func (l *LinkedList) PushBack(insertNode *Node) *Node {
 
 	tail := l.tail
 	tailPrev := tail.prev
-	tailPrev.next = insertNode
 	insertNode.prev = tailPrev
-	insertNode.next = tail
+	l.attach(insertNode, tail)
+	l.setNext(tailPrev, insertNode)
 	tail.prev = insertNode
 	l.count++
 
@@ -107,9 +243,9 @@ func (l *LinkedList) InsertBefore(insertNode *Node, mark *Node) *Node {
 	defer l.mutex.Unlock()
 
 	prevNode := mark.prev
-	prevNode.next = insertNode
 	insertNode.prev = prevNode
-	insertNode.next = mark
+	l.attach(insertNode, mark)
+	l.setNext(prevNode, insertNode)
 	mark.prev = insertNode
 
 	l.count++
@@ -121,11 +257,11 @@ func (l *LinkedList) InsertAfter(insertNode *Node, mark *Node) *Node {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
-	nextNode := mark.next
+	nextNode := mark.Next()
 	nextNode.prev = insertNode
 	insertNode.prev = mark
-	insertNode.next = nextNode
-	mark.next = insertNode
+	l.attach(insertNode, nextNode)
+	l.setNext(mark, insertNode)
 
 	l.count++
 
@@ -136,11 +272,90 @@ func (l *LinkedList) Remove(removeItem *Node) *Node {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
-	removeItem.prev.next = removeItem.next
-	removeItem.next.prev = removeItem.prev
-	removeItem.next, removeItem.prev = nil, nil
+	next := removeItem.Next()
+	l.setNext(removeItem.prev, next)
+	next.prev = removeItem.prev
+	l.setNext(removeItem, nil)
+	removeItem.prev = nil
 
 	l.count--
 
 	return removeItem
 }
+
+func (l *LinkedList) lazyInit() {
+	if l.mutex == nil {
+		*l = *NewLinkedList()
+	}
+}
+
+func (l *LinkedList) swap(other *LinkedList) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.head, l.tail, l.count = other.head, other.tail, other.count
+	l.dirty = true
+}
+
+// attach links a node that is being inserted. The node was not in the list
+// when any current snapshot was taken, so its old link is not saved.
+func (l *LinkedList) attach(node, next *Node) {
+	node.version = l.version
+	node.next.Store(next)
+}
+
+// setNext relinks a node, first saving its old link for the snapshots of
+// the current epoch if they can reach the node. It must be called with the
+// write lock held.
+func (l *LinkedList) setNext(node, next *Node) {
+	if e := l.epoch; e != nil && node.version < e.version {
+		e.saved.LoadOrStore(node, node.next.Load())
+	}
+
+	node.next.Store(next)
+	l.dirty = true
+}
+
+func (l *LinkedList) Validate() error {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if l.head == nil || l.tail == nil || l.head.prev != nil || l.tail.Next() != nil {
+		return errors.New("list: sentinels are broken")
+	}
+
+	if l.head.Value != nil || l.tail.Value != nil {
+		return errors.New("list: sentinels hold values")
+	}
+
+	count := 0
+	visited := map[*Node]bool{l.head: true}
+
+	for node := l.head; node != l.tail; node = node.Next() {
+		next := node.Next()
+
+		if next == nil {
+			return fmt.Errorf("list: chain ends before the tail after %d nodes", count)
+		}
+
+		if next.prev != node {
+			return fmt.Errorf("list: prev of node %d does not point back", count+1)
+		}
+
+		if visited[next] {
+			return fmt.Errorf("list: cycle detected after %d nodes", count)
+		}
+
+		visited[next] = true
+
+		if next != l.tail {
+			count++
+		}
+	}
+
+	if count != l.count {
+		return fmt.Errorf("list: count is %d but %d nodes are linked", l.count, count)
+	}
+
+	return nil
+}
@@ -1,7 +1,13 @@
 package list
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
 	"github.com/stretchr/testify/suite"
+	"reflect"
 	"testing"
 )
 
@@ -45,7 +51,7 @@ func (suite *LinkedListTestSuite) TestLinkedList() {
 
 	suite.Equal(3, suite.link.Len())
 	suite.Nil(resRemove.prev)
-	suite.Nil(resRemove.next)
+	suite.Nil(resRemove.Next())
 	suite.Equal(100, resRemove.Value)
 
 	head := suite.link.head
@@ -66,6 +72,200 @@ func (suite *LinkedListTestSuite) TestLinkedList() {
 	suite.Equal(tail, nextNode)
 }
 
+func (suite *LinkedListTestSuite) TestLinkedListContainer() {
+	link := NewLinkedList()
+	suite.True(link.IsEmpty())
+	suite.Equal([]interface{}{}, link.Values())
+
+	for i := 1; i <= 4; i++ {
+		link.PushBack(&Node{Value: i})
+	}
+
+	suite.False(link.IsEmpty())
+	suite.Equal([]interface{}{1, 2, 3, 4}, link.Values())
+
+	var visited []interface{}
+	link.Each(func(value interface{}) bool {
+		visited = append(visited, value)
+		return value.(int) < 2
+	})
+	suite.Equal([]interface{}{1, 2}, visited)
+
+	link.Clear()
+	suite.True(link.IsEmpty())
+	suite.Nil(link.Front())
+	suite.Nil(link.Back())
+}
+
+func (suite *LinkedListTestSuite) TestLinkedListValidate() {
+	link := NewLinkedList()
+	suite.Nil(link.Validate())
+
+	node1 := link.PushBack(&Node{Value: 1})
+	node2 := link.PushBack(&Node{Value: 2})
+	suite.Nil(link.Validate())
+
+	node2.prev = node2
+	suite.Error(link.Validate())
+
+	node2.prev = node1
+	link.count = 3
+	suite.Error(link.Validate())
+
+	link.count = 2
+	link.head.prev = node1
+	suite.Error(link.Validate())
+
+	link.head.prev = nil
+	node2.next.Store(node1)
+	suite.Error(link.Validate())
+}
+
+func (suite *LinkedListTestSuite) TestLinkedListJSON() {
+	l := NewLinkedList()
+	l.PushBack(&Node{Value: "a"})
+	l.PushBack(&Node{Value: "b"})
+
+	data, err := json.Marshal(l)
+	suite.NoError(err)
+	suite.JSONEq(`["a","b"]`, string(data))
+
+	type point struct{ X, Y int }
+
+	decoded := NewLinkedList(gods.WithElement[point]())
+	decoded.PushBack(&Node{Value: point{}})
+	suite.NoError(json.Unmarshal([]byte(`[{"X":1,"Y":2},{"X":3,"Y":4}]`), decoded))
+	suite.Equal([]interface{}{point{1, 2}, point{3, 4}}, decoded.Values())
+	suite.Equal(point{3, 4}, decoded.Back().Value)
+	suite.NoError(decoded.Validate())
+
+	var zero LinkedList
+	suite.NoError(json.Unmarshal([]byte(`null`), &zero))
+	suite.True(zero.IsEmpty())
+	suite.NoError(zero.Validate())
+}
+
+func (suite *LinkedListTestSuite) TestLinkedListBinary() {
+	l := NewLinkedList(gods.WithCodec(gods.Float64Codec))
+	l.PushBack(&Node{Value: 1.5})
+	l.PushBack(&Node{Value: -2.25})
+
+	data, err := l.MarshalBinary()
+	suite.NoError(err)
+
+	decoded := NewLinkedList(gods.WithCodec(gods.Float64Codec))
+	decoded.PushBack(&Node{Value: 9.0})
+	suite.NoError(decoded.UnmarshalBinary(data))
+	suite.Equal([]interface{}{1.5, -2.25}, decoded.Values())
+	suite.NoError(decoded.Validate())
+
+	suite.Error(decoded.UnmarshalBinary(data[:len(data)-3]))
+	suite.Equal([]interface{}{1.5, -2.25}, decoded.Values())
+
+	var buffer bytes.Buffer
+	var zero LinkedList
+
+	suite.NoError(gob.NewEncoder(&buffer).Encode(NewLinkedList()))
+	suite.NoError(gob.NewDecoder(&buffer).Decode(&zero))
+	suite.True(zero.IsEmpty())
+	suite.NoError(zero.Validate())
+}
+
+func (suite *LinkedListTestSuite) TestLinkedListFormat() {
+	l := NewLinkedList()
+	l.PushBack(&Node{Value: 1})
+	l.PushBack(&Node{Value: 2})
+
+	suite.Equal("LinkedList[1 2]", l.String())
+	suite.Equal("LinkedList[1 ...1 more]", fmt.Sprintf("%.1d", l))
+}
+
+func (suite *LinkedListTestSuite) TestLinkedListFormatWhileWriting() {
+	x := NewLinkedList()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 1000; i++ {
+			x.PushBack(&Node{Value: i})
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		_ = fmt.Sprint(x)
+		x.IsEmpty()
+	}
+
+	<-done
+	suite.Equal(1000, x.Len())
+}
+
 func TestLinkedListTestSuite(t *testing.T) {
 	suite.Run(t, new(LinkedListTestSuite))
 }
+
+func FuzzLinkedList(f *testing.F) {
+	f.Add([]byte{0, 1, 1, 2, 2, 3, 4, 0, 3, 9})
+	f.Add([]byte{1, 5, 1, 6, 4, 1, 4, 0, 5, 0, 0, 7})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		link := NewLinkedList()
+
+		var model []*Node
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 6 {
+			case 0:
+				model = append([]*Node{link.PushFront(&Node{Value: value})}, model...)
+			case 1:
+				model = append(model, link.PushBack(&Node{Value: value}))
+			case 2, 3:
+				if len(model) == 0 {
+					continue
+				}
+
+				index := value % len(model)
+				node := &Node{Value: value}
+
+				if ops[i]%6 == 3 {
+					link.InsertAfter(node, model[index])
+					index++
+				} else {
+					link.InsertBefore(node, model[index])
+				}
+
+				model = append(model[:index], append([]*Node{node}, model[index:]...)...)
+			case 4:
+				if len(model) == 0 {
+					continue
+				}
+
+				index := value % len(model)
+				link.Remove(model[index])
+				model = append(model[:index], model[index+1:]...)
+			case 5:
+				if value%8 == 0 {
+					link.Clear()
+					model = nil
+				}
+			}
+
+			if err := link.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			values := make([]interface{}, 0, len(model))
+
+			for _, node := range model {
+				values = append(values, node.Value)
+			}
+
+			if got := link.Values(); !reflect.DeepEqual(values, got) {
+				t.Fatalf("expected %v, got %v", values, got)
+			}
+		}
+	})
+}
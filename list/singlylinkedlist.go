@@ -0,0 +1,260 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+)
+
+type SinglyNode struct {
+	next  *SinglyNode
+	Value interface{}
+}
+
+func (n *SinglyNode) Next() *SinglyNode {
+	return n.next
+}
+
+func (n *SinglyNode) GetValue() interface{} {
+	return n.Value
+}
+
+var (
+	_ gods.Container = (*SinglyLinkedList)(nil)
+	_ gods.Iterable  = (*SinglyLinkedList)(nil)
+)
+
+type SinglyLinkedList struct {
+	head, tail *SinglyNode
+	count      int
+	mutex      gods.Locker
+	element    gods.Decoder
+	codec      gods.Codec
+}
+
+func NewSinglyLinkedList(options ...gods.Option) *SinglyLinkedList {
+	opts := gods.NewOptions(options...)
+	_, codec := opts.Codecs()
+
+	return &SinglyLinkedList{
+		head:    nil,
+		tail:    nil,
+		count:   0,
+		mutex:   opts.Locker(),
+		element: opts.ElementDecoder(),
+		codec:   codec,
+	}
+}
+
+func (l *SinglyLinkedList) Len() int {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.count
+}
+
+func (l *SinglyLinkedList) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l *SinglyLinkedList) Clear() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.head, l.tail = nil, nil
+	l.count = 0
+}
+
+func (l *SinglyLinkedList) Values() []interface{} {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	values := make([]interface{}, 0, l.count)
+
+	for node := l.head; node != nil; node = node.next {
+		values = append(values, node.Value)
+	}
+
+	return values
+}
+
+func (l *SinglyLinkedList) Each(fn func(value interface{}) bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	for node := l.head; node != nil; node = node.next {
+		if !fn(node.Value) {
+			return
+		}
+	}
+}
+
+func (l *SinglyLinkedList) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "SinglyLinkedList", l.Len(), l.Each)
+}
+
+func (l *SinglyLinkedList) String() string {
+	return fmt.Sprint(l)
+}
+
+func (l *SinglyLinkedList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Values())
+}
+
+func (l *SinglyLinkedList) UnmarshalJSON(data []byte) error {
+	l.lazyInit()
+
+	values, err := gods.UnmarshalArray(data, l.element)
+
+	if err != nil {
+		return err
+	}
+
+	fresh := NewSinglyLinkedList(gods.WithLock(gods.NoLock))
+
+	for _, value := range values {
+		fresh.PushBack(&SinglyNode{Value: value})
+	}
+
+	l.swap(fresh)
+
+	return nil
+}
+
+func (l *SinglyLinkedList) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindList, l.codec, l.Each)
+}
+
+func (l *SinglyLinkedList) ReadFrom(r io.Reader) (int64, error) {
+	l.lazyInit()
+
+	fresh := NewSinglyLinkedList(gods.WithLock(gods.NoLock))
+
+	n, err := gods.ReadValues(r, gods.KindList, l.codec, func(value interface{}) {
+		fresh.PushBack(&SinglyNode{Value: value})
+	})
+
+	if err == nil {
+		l.swap(fresh)
+	}
+
+	return n, err
+}
+
+func (l *SinglyLinkedList) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(l)
+}
+
+func (l *SinglyLinkedList) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(l, data)
+}
+
+func (l *SinglyLinkedList) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+func (l *SinglyLinkedList) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+func (l *SinglyLinkedList) Front() *SinglyNode {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.head
+}
+
+func (l *SinglyLinkedList) Back() *SinglyNode {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.tail
+}
+
+func (l *SinglyLinkedList) PushFront(insertNode *SinglyNode) *SinglyNode {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	insertNode.next = l.head
+	l.head = insertNode
+
+	if l.tail == nil {
+		l.tail = insertNode
+	}
+
+	l.count++
+
+	return insertNode
+}
+
+func (l *SinglyLinkedList) PushBack(insertNode *SinglyNode) *SinglyNode {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	insertNode.next = nil
+
+	if l.tail == nil {
+		l.head = insertNode
+	} else {
+		l.tail.next = insertNode
+	}
+
+	l.tail = insertNode
+	l.count++
+
+	return insertNode
+}
+
+func (l *SinglyLinkedList) PopFront() *SinglyNode {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	front := l.head
+
+	if front == nil {
+		return nil
+	}
+
+	l.head = front.next
+
+	if l.head == nil {
+		l.tail = nil
+	}
+
+	front.next = nil
+	l.count--
+
+	return front
+}
+
+func (l *SinglyLinkedList) Reverse() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var prev *SinglyNode
+	current := l.head
+	l.tail = l.head
+
+	for current != nil {
+		next := current.next
+		current.next = prev
+		prev = current
+		current = next
+	}
+
+	l.head = prev
+}
+
+func (l *SinglyLinkedList) lazyInit() {
+	if l.mutex == nil {
+		*l = *NewSinglyLinkedList()
+	}
+}
+
+func (l *SinglyLinkedList) swap(other *SinglyLinkedList) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.head, l.tail, l.count = other.head, other.tail, other.count
+}
@@ -0,0 +1,210 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"reflect"
+	"testing"
+)
+
+type SinglyLinkedListTestSuite struct {
+	suite.Suite
+	link *SinglyLinkedList
+}
+
+func (suite *SinglyLinkedListTestSuite) SetupTest() {
+	suite.link = NewSinglyLinkedList()
+}
+
+func (suite *SinglyLinkedListTestSuite) values() []interface{} {
+	var values []interface{}
+
+	for node := suite.link.Front(); node != nil; node = node.Next() {
+		values = append(values, node.GetValue())
+	}
+
+	return values
+}
+
+func (suite *SinglyLinkedListTestSuite) TestSinglyLinkedListPush() {
+	suite.Nil(suite.link.Front())
+	suite.Nil(suite.link.Back())
+
+	node1 := suite.link.PushBack(&SinglyNode{Value: 20})
+	node2 := suite.link.PushFront(&SinglyNode{Value: 10})
+	node3 := suite.link.PushBack(&SinglyNode{Value: 30})
+
+	suite.Equal(3, suite.link.Len())
+	suite.Equal(node2, suite.link.Front())
+	suite.Equal(node3, suite.link.Back())
+	suite.Equal(node1, node2.Next())
+	suite.Equal([]interface{}{10, 20, 30}, suite.values())
+}
+
+func (suite *SinglyLinkedListTestSuite) TestSinglyLinkedListPopFront() {
+	suite.Nil(suite.link.PopFront())
+
+	suite.link.PushBack(&SinglyNode{Value: 10})
+	suite.link.PushBack(&SinglyNode{Value: 20})
+
+	popped := suite.link.PopFront()
+	suite.Equal(10, popped.Value)
+	suite.Nil(popped.Next())
+	suite.Equal(1, suite.link.Len())
+
+	popped = suite.link.PopFront()
+	suite.Equal(20, popped.Value)
+	suite.Equal(0, suite.link.Len())
+	suite.Nil(suite.link.Front())
+	suite.Nil(suite.link.Back())
+
+	suite.link.PushBack(&SinglyNode{Value: 30})
+	suite.Equal(suite.link.Front(), suite.link.Back())
+}
+
+func (suite *SinglyLinkedListTestSuite) TestSinglyLinkedListReverse() {
+	suite.link.Reverse()
+	suite.Nil(suite.link.Front())
+
+	for i := 1; i <= 4; i++ {
+		suite.link.PushBack(&SinglyNode{Value: i})
+	}
+
+	suite.link.Reverse()
+
+	suite.Equal([]interface{}{4, 3, 2, 1}, suite.values())
+	suite.Equal(1, suite.link.Back().Value)
+	suite.Nil(suite.link.Back().Next())
+
+	suite.link.PushBack(&SinglyNode{Value: 0})
+	suite.Equal([]interface{}{4, 3, 2, 1, 0}, suite.values())
+}
+
+func (suite *SinglyLinkedListTestSuite) TestSinglyLinkedListContainer() {
+	suite.True(suite.link.IsEmpty())
+
+	for i := 1; i <= 3; i++ {
+		suite.link.PushBack(&SinglyNode{Value: i})
+	}
+
+	suite.False(suite.link.IsEmpty())
+	suite.Equal([]interface{}{1, 2, 3}, suite.link.Values())
+
+	count := 0
+	suite.link.Each(func(value interface{}) bool {
+		count++
+		return false
+	})
+	suite.Equal(1, count)
+
+	suite.link.Clear()
+	suite.True(suite.link.IsEmpty())
+	suite.Nil(suite.link.Front())
+	suite.Equal([]interface{}{}, suite.link.Values())
+}
+
+func (suite *SinglyLinkedListTestSuite) TestSinglyLinkedListJSON() {
+	suite.link.PushBack(&SinglyNode{Value: 1.5})
+	suite.link.PushBack(&SinglyNode{Value: 2.5})
+
+	data, err := json.Marshal(suite.link)
+	suite.NoError(err)
+	suite.JSONEq(`[1.5,2.5]`, string(data))
+
+	var decoded SinglyLinkedList
+	suite.NoError(json.Unmarshal(data, &decoded))
+	suite.Equal([]interface{}{1.5, 2.5}, decoded.Values())
+	suite.Equal(2.5, decoded.Back().Value)
+	suite.Equal(2, decoded.Len())
+}
+
+func (suite *SinglyLinkedListTestSuite) TestSinglyLinkedListBinary() {
+	suite.link.PushBack(&SinglyNode{Value: 1})
+	suite.link.PushBack(&SinglyNode{Value: 2})
+
+	var buffer bytes.Buffer
+
+	_, err := suite.link.WriteTo(&buffer)
+	suite.NoError(err)
+
+	decoded := NewSinglyLinkedList(gods.WithLock(gods.NoLock))
+	_, err = decoded.ReadFrom(&buffer)
+	suite.NoError(err)
+	suite.Equal([]interface{}{1, 2}, decoded.Values())
+	suite.Equal(2, decoded.Back().Value)
+}
+
+func (suite *SinglyLinkedListTestSuite) TestSinglyLinkedListFormat() {
+	suite.link.PushBack(&SinglyNode{Value: 255})
+
+	suite.Equal("SinglyLinkedList[255]", suite.link.String())
+	suite.Equal("SinglyLinkedList[ff]", fmt.Sprintf("%x", suite.link))
+}
+
+func TestSinglyLinkedListTestSuite(t *testing.T) {
+	suite.Run(t, new(SinglyLinkedListTestSuite))
+}
+
+func FuzzSinglyLinkedList(f *testing.F) {
+	f.Add([]byte{0, 1, 1, 2, 3, 0, 2, 0, 1, 3})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		link := NewSinglyLinkedList()
+
+		var model []interface{}
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 5 {
+			case 0:
+				link.PushFront(&SinglyNode{Value: value})
+				model = append([]interface{}{value}, model...)
+			case 1:
+				link.PushBack(&SinglyNode{Value: value})
+				model = append(model, value)
+			case 2:
+				popped := link.PopFront()
+
+				if len(model) == 0 {
+					if popped != nil {
+						t.Fatalf("popped %v from an empty list", popped.Value)
+					}
+					continue
+				}
+
+				if popped.Value != model[0] {
+					t.Fatalf("expected %v, popped %v", model[0], popped.Value)
+				}
+
+				model = model[1:]
+			case 3:
+				link.Reverse()
+
+				for l, r := 0, len(model)-1; l < r; l, r = l+1, r-1 {
+					model[l], model[r] = model[r], model[l]
+				}
+			case 4:
+				if value%8 == 0 {
+					link.Clear()
+					model = nil
+				}
+			}
+
+			if link.Len() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), link.Len())
+			}
+
+			if got := link.Values(); len(model) > 0 && !reflect.DeepEqual(model, got) {
+				t.Fatalf("expected %v, got %v", model, got)
+			}
+
+			if back := link.Back(); (back == nil) != (len(model) == 0) || (back != nil && (back.Value != model[len(model)-1] || back.Next() != nil)) {
+				t.Fatalf("tail pointer is stale")
+			}
+		}
+	})
+}
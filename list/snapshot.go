@@ -0,0 +1,124 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	_ gods.Iterable = (*LinkedListSnapshot)(nil)
+)
+
+// LinkedListSnapshot is a read-only view of a LinkedList as it was when
+// Snapshot was called. Reads never take the list's lock.
+type LinkedListSnapshot struct {
+	head, tail *Node
+	count      int
+	epoch      *epoch
+	codec      gods.Codec
+}
+
+// epoch records, for every node relinked since a group of snapshots was
+// taken, the node that followed it at the time. Snapshots taken with no
+// write in between share an epoch, and each epoch links to the next one, so
+// a snapshot finds the first change made to a node after it was taken.
+type epoch struct {
+	version uint64
+	saved   sync.Map
+	next    atomic.Pointer[epoch]
+}
+
+// Snapshot returns a view of the current contents in constant time. Nodes
+// are shared with the list, which saves the old link of a node the first
+// time it relinks it after a snapshot. Reading a snapshot slows down with
+// the number of snapshots taken after it between writes. Assigning
+// Node.Value directly bypasses the list and is not tracked.
+func (l *LinkedList) Snapshot() *LinkedListSnapshot {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.epoch == nil || l.dirty {
+		e := &epoch{version: l.version + 1}
+
+		if l.epoch != nil {
+			l.epoch.next.Store(e)
+		}
+
+		l.epoch, l.version, l.dirty = e, e.version, false
+	}
+
+	return &LinkedListSnapshot{
+		head:  l.head,
+		tail:  l.tail,
+		count: l.count,
+		epoch: l.epoch,
+		codec: l.codec,
+	}
+}
+
+// next returns the node that followed node when the snapshot was taken. The
+// live link is read first: if it changed since, the list saved the old one
+// before changing it.
+func (s *LinkedListSnapshot) next(node *Node) *Node {
+	next := node.Next()
+
+	for e := s.epoch; e != nil; e = e.next.Load() {
+		if saved, ok := e.saved.Load(node); ok {
+			return saved.(*Node)
+		}
+	}
+
+	return next
+}
+
+func (s *LinkedListSnapshot) Len() int {
+	return s.count
+}
+
+func (s *LinkedListSnapshot) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+func (s *LinkedListSnapshot) Values() []interface{} {
+	values := make([]interface{}, 0, s.count)
+
+	s.Each(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (s *LinkedListSnapshot) Each(fn func(value interface{}) bool) {
+	for node := s.next(s.head); node != s.tail; node = s.next(node) {
+		if !fn(node.Value) {
+			return
+		}
+	}
+}
+
+func (s *LinkedListSnapshot) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "LinkedListSnapshot", s.Len(), s.Each)
+}
+
+func (s *LinkedListSnapshot) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *LinkedListSnapshot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Values())
+}
+
+// WriteTo writes the same stream as LinkedList.WriteTo.
+func (s *LinkedListSnapshot) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindList, s.codec, s.Each)
+}
+
+func (s *LinkedListSnapshot) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(s)
+}
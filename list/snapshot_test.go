@@ -0,0 +1,226 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SnapshotTestSuite) list(values ...interface{}) *LinkedList {
+	l := NewLinkedList(gods.WithCodec(gods.IntCodec))
+
+	for _, value := range values {
+		l.PushBack(&Node{Value: value})
+	}
+
+	return l
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotIsolation() {
+	l := suite.list(1, 2, 3)
+
+	first := l.Snapshot()
+	second := l.Snapshot()
+
+	front := l.Front()
+	l.Remove(front)
+	l.PushFront(&Node{Value: 0})
+	l.InsertAfter(&Node{Value: 5}, l.Back())
+
+	suite.Equal([]interface{}{0, 2, 3, 5}, l.Values())
+	suite.Equal([]interface{}{1, 2, 3}, first.Values())
+	suite.Equal([]interface{}{1, 2, 3}, second.Values())
+
+	third := l.Snapshot()
+
+	suite.Equal([]interface{}{0, 2, 3, 5}, third.Values())
+
+	l.Clear()
+
+	suite.Equal(4, third.Len())
+	suite.False(third.IsEmpty())
+	suite.True(l.Snapshot().IsEmpty())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotReadBeforeWrite() {
+	l := suite.list(1, 2)
+	snapshot := l.Snapshot()
+
+	suite.Equal([]interface{}{1, 2}, snapshot.Values())
+
+	l.PushBack(&Node{Value: 3})
+	l.InsertBefore(&Node{Value: 0}, l.Front())
+
+	suite.Equal([]interface{}{1, 2}, snapshot.Values())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotSharedEpoch() {
+	l := suite.list(1, 2, 3)
+	first := l.Snapshot()
+
+	for i := 0; i < 1000; i++ {
+		suite.Same(first.epoch, l.Snapshot().epoch)
+	}
+
+	// A queue-like workload only saves the links of nodes the snapshot can
+	// reach, however many writes follow.
+	for i := 0; i < 1000; i++ {
+		l.PushBack(&Node{Value: i})
+		l.Remove(l.Front())
+	}
+
+	saved := 0
+
+	first.epoch.saved.Range(func(key, value interface{}) bool {
+		saved++
+		return true
+	})
+
+	suite.LessOrEqual(saved, 4)
+	suite.Equal([]interface{}{1, 2, 3}, first.Values())
+	suite.NotSame(first.epoch, l.Snapshot().epoch)
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotMovedNodes() {
+	l := suite.list(1, 2, 3, 4)
+	first := l.Snapshot()
+
+	second := l.Front().Next()
+	l.Remove(second)
+	l.InsertAfter(second, l.Back())
+
+	middle := l.Snapshot()
+
+	l.Remove(second)
+	l.PushFront(second)
+	l.Remove(l.Back())
+
+	suite.Equal([]interface{}{2, 1, 3}, l.Values())
+	suite.Equal([]interface{}{1, 3, 4, 2}, middle.Values())
+	suite.Equal([]interface{}{1, 2, 3, 4}, first.Values())
+	suite.NoError(l.Validate())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotRandom() {
+	random := rand.New(rand.NewSource(1))
+	l := suite.list()
+
+	var nodes []*Node
+	var snapshots []*LinkedListSnapshot
+	var expected [][]interface{}
+
+	for i := 0; i < 2000; i++ {
+		switch op := random.Intn(6); {
+		case op < 2 || len(nodes) == 0:
+			node := &Node{Value: i}
+
+			if len(nodes) > 0 && op == 1 {
+				l.InsertAfter(node, nodes[random.Intn(len(nodes))])
+			} else {
+				l.PushFront(node)
+			}
+
+			nodes = append(nodes, node)
+		case op < 4:
+			j := random.Intn(len(nodes))
+			l.Remove(nodes[j])
+			nodes = append(nodes[:j], nodes[j+1:]...)
+		case op == 4:
+			node := nodes[random.Intn(len(nodes))]
+			l.Remove(node)
+
+			if front := l.Front(); front != nil {
+				l.InsertBefore(node, front)
+			} else {
+				l.PushBack(node)
+			}
+		default:
+			snapshots = append(snapshots, l.Snapshot())
+			expected = append(expected, l.Values())
+		}
+	}
+
+	suite.NoError(l.Validate())
+
+	for i, snapshot := range snapshots {
+		suite.Equal(len(expected[i]), snapshot.Len())
+		suite.Equal(expected[i], snapshot.Values())
+	}
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotDecode() {
+	l := suite.list(1, 2)
+	snapshot := l.Snapshot()
+
+	suite.NoError(json.Unmarshal([]byte(`[7,8,9]`), l))
+
+	suite.Equal([]interface{}{1, 2}, snapshot.Values())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotEncoding() {
+	l := suite.list(1, 2, 3)
+	snapshot := l.Snapshot()
+	l.PushBack(&Node{Value: 4})
+
+	data, err := json.Marshal(snapshot)
+	suite.NoError(err)
+	suite.JSONEq(`[1,2,3]`, string(data))
+
+	data, err = snapshot.MarshalBinary()
+	suite.NoError(err)
+
+	restored := suite.list()
+	suite.NoError(restored.UnmarshalBinary(data))
+	suite.Equal([]interface{}{1, 2, 3}, restored.Values())
+
+	suite.Equal("LinkedListSnapshot[1 2 3]", snapshot.String())
+	suite.Equal("LinkedListSnapshot[1 ...2 more]", fmt.Sprintf("%.1v", snapshot))
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotConcurrentWriters() {
+	l := suite.list()
+
+	for i := 0; i < 100; i++ {
+		l.PushBack(&Node{Value: i})
+	}
+
+	var wg sync.WaitGroup
+
+	for r := 0; r < 4; r++ {
+		snapshot := l.Snapshot()
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sum := 0
+
+			snapshot.Each(func(value interface{}) bool {
+				sum += value.(int)
+				return true
+			})
+
+			suite.Equal(4950, sum)
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		l.PushBack(&Node{Value: i})
+		l.Remove(l.Front())
+	}
+
+	wg.Wait()
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotTestSuite))
+}
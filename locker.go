@@ -0,0 +1,50 @@
+package gods
+
+import (
+	"sync"
+)
+
+type LockMode int
+
+const (
+	RWMutexLock LockMode = iota
+	MutexLock
+	NoLock
+)
+
+type Locker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+}
+
+type mutexLocker struct {
+	sync.Mutex
+}
+
+func (m *mutexLocker) RLock() {
+	m.Lock()
+}
+
+func (m *mutexLocker) RUnlock() {
+	m.Unlock()
+}
+
+type noLocker struct{}
+
+func (noLocker) Lock()    {}
+func (noLocker) Unlock()  {}
+func (noLocker) RLock()   {}
+func (noLocker) RUnlock() {}
+
+func NewLocker(mode LockMode) Locker {
+	switch mode {
+	case MutexLock:
+		return new(mutexLocker)
+	case NoLock:
+		return noLocker{}
+	}
+
+	return new(sync.RWMutex)
+}
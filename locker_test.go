@@ -0,0 +1,63 @@
+package gods
+
+import (
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+	"time"
+)
+
+type LockerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LockerTestSuite) acquiredWithin(acquire func()) bool {
+	done := make(chan struct{})
+
+	go func() {
+		acquire()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(50 * time.Millisecond):
+		return false
+	}
+}
+
+func (suite *LockerTestSuite) TestRWMutexLockSharesReads() {
+	locker := NewLocker(RWMutexLock)
+	suite.IsType(new(sync.RWMutex), locker)
+
+	locker.RLock()
+	suite.True(suite.acquiredWithin(locker.RLock))
+	suite.False(suite.acquiredWithin(locker.Lock))
+
+	locker.RUnlock()
+	locker.RUnlock()
+}
+
+func (suite *LockerTestSuite) TestMutexLockSerializesReads() {
+	locker := NewLocker(MutexLock)
+
+	locker.RLock()
+	suite.False(suite.acquiredWithin(locker.RLock))
+
+	locker.RUnlock()
+}
+
+func (suite *LockerTestSuite) TestNoLockNeverBlocks() {
+	locker := NewLocker(NoLock)
+
+	locker.Lock()
+	suite.True(suite.acquiredWithin(locker.Lock))
+	suite.True(suite.acquiredWithin(locker.RLock))
+	locker.Unlock()
+	locker.RUnlock()
+}
+
+func TestLockerTestSuite(t *testing.T) {
+	suite.Run(t, new(LockerTestSuite))
+}
@@ -0,0 +1,112 @@
+package gods
+
+type Options struct {
+	Lock     LockMode
+	Element  Decoder
+	Key      KeyDecoder
+	Codec    Codec
+	KeyCodec Codec
+	Compare  func(a, b interface{}) int
+}
+
+type Option func(options *Options)
+
+func WithLock(mode LockMode) Option {
+	return func(options *Options) {
+		options.Lock = mode
+	}
+}
+
+// WithElement sets the type elements (and map values) are decoded into when
+// a container is unmarshalled. Without it they decode as plain interface{}.
+func WithElement[T any]() Option {
+	return func(options *Options) {
+		options.Element = DecodeAs[T]
+	}
+}
+
+// WithKey sets the type map keys are decoded into when a container is
+// unmarshalled. Without it keys decode as strings.
+func WithKey[K any]() Option {
+	return func(options *Options) {
+		options.Key = DecodeKeyAs[K]
+	}
+}
+
+// WithCodec sets the codec used for elements (and map values) by the binary
+// encoding. The default is GobCodec[interface{}]().
+func WithCodec(codec Codec) Option {
+	return func(options *Options) {
+		options.Codec = codec
+	}
+}
+
+// WithKeyCodec sets the codec used for map keys by the binary encoding. The
+// default is GobCodec[interface{}]().
+func WithKeyCodec(codec Codec) Option {
+	return func(options *Options) {
+		options.KeyCodec = codec
+	}
+}
+
+// WithCompare sets the order of an ordered container to a three-way function
+// such as cmp.Compare[int]: negative when a < b, zero when they are equal
+// and positive when a > b. It takes precedence over the comparer passed to
+// the constructor, which may then be nil.
+func WithCompare[T any](compare func(a, b T) int) Option {
+	if untyped, ok := any(compare).(func(a, b interface{}) int); ok {
+		return func(options *Options) {
+			options.Compare = untyped
+		}
+	}
+
+	return func(options *Options) {
+		options.Compare = func(a, b interface{}) int {
+			return compare(a.(T), b.(T))
+		}
+	}
+}
+
+func NewOptions(options ...Option) *Options {
+	opts := &Options{Lock: RWMutexLock}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+func (o *Options) Locker() Locker {
+	return NewLocker(o.Lock)
+}
+
+func (o *Options) ElementDecoder() Decoder {
+	if o.Element == nil {
+		return DecodeAs[interface{}]
+	}
+
+	return o.Element
+}
+
+func (o *Options) KeyDecoder() KeyDecoder {
+	if o.Key == nil {
+		return DecodeKeyAs[string]
+	}
+
+	return o.Key
+}
+
+func (o *Options) Codecs() (key, element Codec) {
+	key, element = o.KeyCodec, o.Codec
+
+	if key == nil {
+		key = GobCodec[interface{}]()
+	}
+
+	if element == nil {
+		element = GobCodec[interface{}]()
+	}
+
+	return key, element
+}
@@ -0,0 +1,78 @@
+package gods
+
+import (
+	"cmp"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+)
+
+type OptionsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *OptionsTestSuite) TestNewOptionsDefault() {
+	opts := NewOptions()
+
+	suite.Equal(RWMutexLock, opts.Lock)
+	suite.IsType(new(sync.RWMutex), opts.Locker())
+}
+
+func (suite *OptionsTestSuite) TestWithLock() {
+	opts := NewOptions(WithLock(MutexLock), WithLock(NoLock))
+
+	suite.Equal(NoLock, opts.Lock)
+	suite.Equal(noLocker{}, opts.Locker())
+}
+
+func (suite *OptionsTestSuite) TestDecoders() {
+	opts := NewOptions()
+
+	key, err := opts.KeyDecoder()("1")
+	suite.NoError(err)
+	suite.Equal("1", key)
+
+	value, err := opts.ElementDecoder()([]byte(`1`))
+	suite.NoError(err)
+	suite.Equal(1.0, value)
+
+	opts = NewOptions(WithKey[int](), WithElement[string]())
+
+	key, err = opts.KeyDecoder()("1")
+	suite.NoError(err)
+	suite.Equal(1, key)
+
+	value, err = opts.ElementDecoder()([]byte(`"1"`))
+	suite.NoError(err)
+	suite.Equal("1", value)
+}
+
+func (suite *OptionsTestSuite) TestCodecs() {
+	key, element := NewOptions().Codecs()
+
+	suite.Equal(GobCodec[interface{}](), key)
+	suite.Equal(GobCodec[interface{}](), element)
+
+	key, element = NewOptions(WithKeyCodec(StringCodec), WithCodec(IntCodec)).Codecs()
+
+	suite.Equal(StringCodec, key)
+	suite.Equal(IntCodec, element)
+}
+
+func (suite *OptionsTestSuite) TestWithCompare() {
+	suite.Nil(NewOptions().Compare)
+
+	compare := NewOptions(WithCompare(cmp.Compare[int])).Compare
+	suite.Negative(compare(1, 2))
+	suite.Zero(compare(2, 2))
+	suite.Positive(compare(3, 2))
+
+	compare = NewOptions(WithCompare(func(a, b interface{}) int {
+		return cmp.Compare(a.(string), b.(string))
+	})).Compare
+	suite.Negative(compare("a", "b"))
+}
+
+func TestOptionsTestSuite(t *testing.T) {
+	suite.Run(t, new(OptionsTestSuite))
+}
@@ -0,0 +1,168 @@
+package queue
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"sync"
+)
+
+// cell is a forced stream cell. A nil *cell is the end of the stream.
+type cell struct {
+	value interface{}
+	next  *stream
+}
+
+// stream is a lazily evaluated list whose cells are computed at most once,
+// even when versions sharing it are read from several goroutines.
+type stream struct {
+	once  sync.Once
+	thunk func() *cell
+	cell  *cell
+}
+
+func delay(thunk func() *cell) *stream {
+	return &stream{thunk: thunk}
+}
+
+func eager(c *cell) *stream {
+	s := &stream{cell: c}
+	s.once.Do(func() {})
+
+	return s
+}
+
+func (s *stream) force() *cell {
+	if s == nil {
+		return nil
+	}
+
+	s.once.Do(func() {
+		s.cell = s.thunk()
+		s.thunk = nil
+	})
+
+	return s.cell
+}
+
+// rotate lazily computes front ++ reverse(rear) ++ acc. It relies on rear
+// being exactly one element longer than front.
+func rotate(front *stream, rear *link, acc *stream) *stream {
+	return delay(func() *cell {
+		c := front.force()
+
+		if c == nil {
+			return &cell{value: rear.value, next: acc}
+		}
+
+		return &cell{value: c.value, next: rotate(c.next, rear.next, eager(&cell{value: rear.value, next: acc}))}
+	})
+}
+
+type link struct {
+	value interface{}
+	next  *link
+}
+
+var _ gods.Iterable = (*ImmutableQueue)(nil)
+
+// ImmutableQueue is a persistent FIFO queue using Okasaki's real-time
+// representation: Push and Pop take constant time in the worst case, not
+// just amortized, and return a new version while older versions stay
+// usable. Versions can be read concurrently without locking. The zero value
+// is an empty queue.
+type ImmutableQueue struct {
+	front    *stream
+	rear     *link
+	schedule *stream
+	count    int
+	rearLen  int
+}
+
+func NewImmutableQueue() *ImmutableQueue {
+	return &ImmutableQueue{}
+}
+
+func (q *ImmutableQueue) Len() int {
+	return q.count
+}
+
+func (q *ImmutableQueue) IsEmpty() bool {
+	return q.count == 0
+}
+
+// Push returns a version with value at the back.
+func (q *ImmutableQueue) Push(value interface{}) *ImmutableQueue {
+	return exec(q.front, &link{value: value, next: q.rear}, q.schedule, q.count+1, q.rearLen+1)
+}
+
+// Pop returns the version without the front value, or q itself if it is
+// empty.
+func (q *ImmutableQueue) Pop() *ImmutableQueue {
+	if q.count == 0 {
+		return q
+	}
+
+	return exec(q.front.force().next, q.rear, q.schedule, q.count-1, q.rearLen)
+}
+
+// Peek returns the front value, or nil if the queue is empty.
+func (q *ImmutableQueue) Peek() interface{} {
+	if c := q.front.force(); c != nil {
+		return c.value
+	}
+
+	return nil
+}
+
+// Values returns the values front first.
+func (q *ImmutableQueue) Values() []interface{} {
+	values := make([]interface{}, 0, q.count)
+
+	q.Each(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (q *ImmutableQueue) Each(fn func(value interface{}) bool) {
+	for c := q.front.force(); c != nil; c = c.next.force() {
+		if !fn(c.value) {
+			return
+		}
+	}
+
+	rear := make([]interface{}, 0, q.rearLen)
+
+	for l := q.rear; l != nil; l = l.next {
+		rear = append(rear, l.value)
+	}
+
+	for i := len(rear) - 1; i >= 0; i-- {
+		if !fn(rear[i]) {
+			return
+		}
+	}
+}
+
+func (q *ImmutableQueue) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "ImmutableQueue", q.count, q.Each)
+}
+
+func (q *ImmutableQueue) String() string {
+	return fmt.Sprint(q)
+}
+
+// exec forces one step of the schedule, or starts a new rotation once the
+// schedule is exhausted, which happens exactly when the rear has grown one
+// longer than the front.
+func exec(front *stream, rear *link, schedule *stream, count, rearLen int) *ImmutableQueue {
+	if c := schedule.force(); c != nil {
+		return &ImmutableQueue{front: front, rear: rear, schedule: c.next, count: count, rearLen: rearLen}
+	}
+
+	front = rotate(front, rear, nil)
+
+	return &ImmutableQueue{front: front, schedule: front, count: count}
+}
@@ -0,0 +1,152 @@
+package queue
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type ImmutableQueueTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ImmutableQueueTestSuite) TestImmutableQueue() {
+	empty := NewImmutableQueue()
+
+	suite.True(empty.IsEmpty())
+	suite.Nil(empty.Peek())
+	suite.Same(empty, empty.Pop())
+
+	q := empty
+
+	for i := 0; i < 5; i++ {
+		q = q.Push(i)
+	}
+
+	popped := q.Pop().Pop()
+	branch := popped.Push(10)
+
+	suite.Equal([]interface{}{0, 1, 2, 3, 4}, q.Values())
+	suite.Equal(0, q.Peek())
+	suite.Equal([]interface{}{2, 3, 4}, popped.Values())
+	suite.Equal(2, popped.Peek())
+	suite.Equal([]interface{}{2, 3, 4, 10}, branch.Values())
+	suite.Equal(4, branch.Len())
+	suite.True(empty.IsEmpty())
+}
+
+func (suite *ImmutableQueueTestSuite) TestImmutableQueueZeroValue() {
+	var q ImmutableQueue
+
+	suite.True(q.IsEmpty())
+	suite.Equal([]interface{}{1, 2}, q.Push(1).Push(2).Values())
+	suite.True(q.Push(1).Pop().IsEmpty())
+}
+
+func (suite *ImmutableQueueTestSuite) TestImmutableQueueVersions() {
+	versions := []*ImmutableQueue{NewImmutableQueue()}
+	models := [][]interface{}{{}}
+
+	for i := 0; i < 500; i++ {
+		q, model := versions[i], models[i]
+
+		if i%3 == 2 && !q.IsEmpty() {
+			suite.Equal(model[0], q.Peek())
+			q, model = q.Pop(), model[1:]
+		} else {
+			q, model = q.Push(i), append(append([]interface{}{}, model...), i)
+		}
+
+		versions = append(versions, q)
+		models = append(models, model)
+	}
+
+	for i, q := range versions {
+		suite.Equal(len(models[i]), q.Len())
+
+		if !reflect.DeepEqual(models[i], q.Values()) {
+			suite.Failf("version mismatch", "version %d: expected %v, got %v", i, models[i], q.Values())
+		}
+	}
+}
+
+func (suite *ImmutableQueueTestSuite) TestImmutableQueueConcurrentReaders() {
+	q := NewImmutableQueue()
+
+	for i := 0; i < 100; i++ {
+		q = q.Push(i)
+	}
+
+	var wg sync.WaitGroup
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sum := 0
+
+			for v := q; !v.IsEmpty(); v = v.Pop() {
+				sum += v.Peek().(int)
+			}
+
+			suite.Equal(4950, sum)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (suite *ImmutableQueueTestSuite) TestImmutableQueueFormat() {
+	q := NewImmutableQueue().Push(1).Push(2).Push(3)
+
+	suite.Equal("ImmutableQueue[1 2 3]", q.String())
+	suite.Equal("ImmutableQueue[1 ...2 more]", fmt.Sprintf("%.1v", q))
+}
+
+func TestImmutableQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(ImmutableQueueTestSuite))
+}
+
+func FuzzImmutableQueue(f *testing.F) {
+	f.Add([]byte{0, 0, 1, 0, 2, 1, 1, 3})
+	f.Add([]byte{0, 0, 0, 0, 0, 1, 1, 1, 1, 0})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		q := NewImmutableQueue()
+		saved, savedValues := q, []interface{}{}
+
+		var model []interface{}
+
+		for i, op := range ops {
+			switch op % 3 {
+			case 0:
+				q = q.Push(i)
+				model = append(model, i)
+			case 1:
+				q = q.Pop()
+
+				if len(model) > 0 {
+					model = model[1:]
+				}
+			case 2:
+				saved, savedValues = q, append([]interface{}{}, model...)
+			}
+
+			if q.Len() != len(model) {
+				t.Fatalf("len %d, model %d", q.Len(), len(model))
+			}
+		}
+
+		if values := q.Values(); len(model) > 0 && !reflect.DeepEqual(model, values) {
+			t.Fatalf("expected %v, got %v", model, values)
+		}
+
+		if values := saved.Values(); len(savedValues) > 0 && !reflect.DeepEqual(savedValues, values) {
+			t.Fatalf("saved version changed: expected %v, got %v", savedValues, values)
+		}
+	})
+}
@@ -1,32 +1,147 @@
 package queue
 
 import (
-	"sync"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
 )
 
-type node interface{}
+type node = interface{}
+
+var (
+	_ gods.Queue    = (*Queue)(nil)
+	_ gods.Iterable = (*Queue)(nil)
+)
 
 type Queue struct {
-	count int
-	data  []node
-	mutex *sync.Mutex
+	count   int
+	data    []node
+	mutex   gods.Locker
+	element gods.Decoder
+	codec   gods.Codec
 }
 
-func NewQueue() *Queue {
+func NewQueue(options ...gods.Option) *Queue {
+	opts := gods.NewOptions(options...)
+	_, codec := opts.Codecs()
+
 	return &Queue{
-		count: 0,
-		mutex: new(sync.Mutex),
+		count:   0,
+		mutex:   opts.Locker(),
+		element: opts.ElementDecoder(),
+		codec:   codec,
 	}
 }
 
 func (q *Queue) Len() int {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
 	return q.count
 }
 
-func (q *Queue) AtomicLen() int {
+func (q *Queue) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+func (q *Queue) Clear() {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
+	q.data = nil
+	q.count = 0
+}
+
+func (q *Queue) Values() []interface{} {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	values := make([]interface{}, q.count)
+	copy(values, q.data)
+
+	return values
+}
+
+func (q *Queue) Each(fn func(value interface{}) bool) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, item := range q.data {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+func (q *Queue) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "Queue", q.Len(), q.Each)
+}
+
+func (q *Queue) String() string {
+	return fmt.Sprint(q)
+}
+
+func (q *Queue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.Values())
+}
+
+// UnmarshalJSON replaces the contents of the queue, front first. A zero
+// Queue is initialized with the default options first.
+func (q *Queue) UnmarshalJSON(data []byte) error {
+	q.lazyInit()
+
+	values, err := gods.UnmarshalArray(data, q.element)
+
+	if err != nil {
+		return err
+	}
+
+	q.replace(values)
+
+	return nil
+}
+
+func (q *Queue) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindQueue, q.codec, q.Each)
+}
+
+func (q *Queue) ReadFrom(reader io.Reader) (int64, error) {
+	q.lazyInit()
+
+	var values []interface{}
+
+	n, err := gods.ReadValues(reader, gods.KindQueue, q.codec, func(value interface{}) {
+		values = append(values, value)
+	})
+
+	if err == nil {
+		q.replace(values)
+	}
+
+	return n, err
+}
+
+func (q *Queue) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(q)
+}
+
+func (q *Queue) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(q, data)
+}
+
+func (q *Queue) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+func (q *Queue) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
+
+func (q *Queue) AtomicLen() int {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
 	return q.count
 }
 
@@ -42,7 +157,7 @@ func (q *Queue) Pop() node {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
-	if q.Len() == 0 {
+	if q.count == 0 {
 		return nil
 	}
 
@@ -55,12 +170,26 @@ func (q *Queue) Pop() node {
 }
 
 func (q *Queue) Peek() node {
-	q.mutex.Lock()
-	defer q.mutex.Unlock()
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
 
-	if q.Len() < 1 {
+	if q.count < 1 {
 		return nil
 	}
 
 	return q.data[0]
 }
+
+func (q *Queue) lazyInit() {
+	if q.mutex == nil {
+		*q = *NewQueue()
+	}
+}
+
+func (q *Queue) replace(values []interface{}) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.data = values
+	q.count = len(values)
+}
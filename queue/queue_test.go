@@ -1,7 +1,14 @@
 package queue
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
 	"github.com/stretchr/testify/suite"
+	"reflect"
 	"testing"
 )
 
@@ -42,6 +49,210 @@ func (suite *QueueTestSuite) TestQueue() {
 	suite.Equal(testNode{strValue: "test1", intValue: 30}, suite.q.Pop().(testNode))
 }
 
+func (suite *QueueTestSuite) TestQueueWithoutLocking() {
+	q := NewQueue(gods.WithLock(gods.NoLock))
+
+	q.Push(1)
+	q.Push(2)
+
+	suite.Equal(2, q.AtomicLen())
+	suite.Equal(1, q.Peek())
+	suite.Equal(1, q.Pop())
+	suite.Equal(2, q.Pop())
+	suite.Nil(q.Pop())
+}
+
+func (suite *QueueTestSuite) TestQueueContainer() {
+	q := NewQueue()
+	suite.True(q.IsEmpty())
+
+	q.Push(1)
+	q.Push(2)
+	q.Push(3)
+
+	suite.False(q.IsEmpty())
+	suite.Equal([]interface{}{1, 2, 3}, q.Values())
+
+	var visited []interface{}
+	q.Each(func(value interface{}) bool {
+		visited = append(visited, value)
+		return len(visited) < 2
+	})
+	suite.Equal([]interface{}{1, 2}, visited)
+
+	q.Clear()
+	suite.True(q.IsEmpty())
+	suite.Nil(q.Peek())
+}
+
+func (suite *QueueTestSuite) TestQueueJSON() {
+	q := NewQueue()
+	q.Push(1)
+	q.Push(2)
+	q.Push(3)
+
+	data, err := json.Marshal(q)
+	suite.NoError(err)
+	suite.JSONEq(`[1,2,3]`, string(data))
+
+	decoded := NewQueue(gods.WithElement[int]())
+	suite.NoError(json.Unmarshal(data, decoded))
+	suite.Equal(1, decoded.Pop())
+	suite.Equal([]interface{}{2, 3}, decoded.Values())
+
+	var zero struct{ Queue *Queue }
+	suite.NoError(json.Unmarshal([]byte(`{"Queue":["a","b"]}`), &zero))
+	suite.Equal([]interface{}{"a", "b"}, zero.Queue.Values())
+
+	suite.Error(json.Unmarshal([]byte(`{}`), decoded))
+	suite.Error(json.Unmarshal([]byte(`["x"]`), decoded))
+}
+
+func (suite *QueueTestSuite) TestQueueBinary() {
+	q := NewQueue(gods.WithCodec(gods.IntCodec))
+
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+
+	data, err := q.MarshalBinary()
+	suite.NoError(err)
+
+	decoded := NewQueue(gods.WithCodec(gods.IntCodec))
+	decoded.Push(-1)
+	suite.NoError(decoded.UnmarshalBinary(data))
+	suite.Equal(q.Values(), decoded.Values())
+
+	var buffer bytes.Buffer
+
+	written, err := q.WriteTo(&buffer)
+	suite.NoError(err)
+	suite.Equal(int64(len(data)), written)
+
+	read, err := decoded.ReadFrom(&buffer)
+	suite.NoError(err)
+	suite.Equal(written, read)
+
+	suite.Error(NewQueue(gods.WithCodec(gods.Float64Codec)).UnmarshalBinary(data))
+	suite.Error(decoded.UnmarshalBinary(data[:len(data)-1]))
+	suite.Equal(100, decoded.Len())
+}
+
+func (suite *QueueTestSuite) TestQueueGob() {
+	type job struct {
+		Name    string
+		Pending *Queue
+	}
+
+	q := NewQueue()
+	q.Push("a")
+	q.Push(1)
+	q.Push(nil)
+
+	var buffer bytes.Buffer
+	suite.NoError(gob.NewEncoder(&buffer).Encode(job{Name: "x", Pending: q}))
+
+	var decoded job
+	suite.NoError(gob.NewDecoder(&buffer).Decode(&decoded))
+	suite.Equal("x", decoded.Name)
+	suite.Equal([]interface{}{"a", 1, nil}, decoded.Pending.Values())
+}
+
+func (suite *QueueTestSuite) TestQueueFormat() {
+	q := NewQueue()
+
+	for i := 0; i < 12; i++ {
+		q.Push(i)
+	}
+
+	suite.Equal("Queue[0 1 2 3 4 5 6 7 8 9 ...2 more]", q.String())
+	suite.Equal("Queue[0 1 2 3 4 5 6 7 8 9 10 11]", fmt.Sprintf("%+v", q))
+	suite.Equal("Queue[]", NewQueue().String())
+}
+
+func (suite *QueueTestSuite) TestQueueFormatWhileWriting() {
+	x := NewQueue()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 1000; i++ {
+			x.Push(i)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		_ = fmt.Sprint(x)
+		x.IsEmpty()
+	}
+
+	<-done
+	suite.Equal(1000, x.Len())
+}
+
 func TestQueueTestSuite(t *testing.T) {
 	suite.Run(t, new(QueueTestSuite))
 }
+
+func TestQueueConformance(t *testing.T) {
+	suite.Run(t, &godstest.QueueSuite{
+		New: func() gods.Queue { return NewQueue() },
+	})
+	suite.Run(t, &godstest.QueueSuite{
+		New:             func() gods.Queue { return NewQueue(gods.WithLock(gods.NoLock)) },
+		SkipConcurrency: true,
+	})
+}
+
+func FuzzQueue(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 1, 0, 2, 0, 0, 3, 1, 0})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		q := NewQueue()
+
+		var model []interface{}
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 4 {
+			case 0:
+				q.Push(value)
+				model = append(model, value)
+			case 1:
+				popped := q.Pop()
+
+				if len(model) == 0 {
+					if popped != nil {
+						t.Fatalf("popped %v from an empty queue", popped)
+					}
+					continue
+				}
+
+				if popped != model[0] {
+					t.Fatalf("expected %v, popped %v", model[0], popped)
+				}
+
+				model = model[1:]
+			case 2:
+				if len(model) > 0 && q.Peek() != model[0] {
+					t.Fatalf("expected %v, peeked %v", model[0], q.Peek())
+				}
+			case 3:
+				if value%8 == 0 {
+					q.Clear()
+					model = nil
+				}
+			}
+
+			if q.Len() != len(model) || q.AtomicLen() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), q.Len())
+			}
+
+			if got := q.Values(); len(model) > 0 && !reflect.DeepEqual(model, got) {
+				t.Fatalf("expected %v, got %v", model, got)
+			}
+		}
+	})
+}
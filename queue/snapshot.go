@@ -0,0 +1,76 @@
+package queue
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+)
+
+var (
+	_ gods.Iterable = (*Snapshot)(nil)
+)
+
+// Snapshot is a read-only view of a Queue as it was when Snapshot was
+// called. Reads never take the queue's lock.
+type Snapshot struct {
+	queue *Queue
+}
+
+// Snapshot returns a view of the current contents in constant time. Push
+// only appends past the end and Pop only advances the front, so the
+// snapshot can share the queue's storage without copying.
+func (q *Queue) Snapshot() *Snapshot {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return &Snapshot{
+		queue: &Queue{
+			count:   q.count,
+			data:    q.data[:q.count:q.count],
+			mutex:   gods.NewLocker(gods.NoLock),
+			element: q.element,
+			codec:   q.codec,
+		},
+	}
+}
+
+func (s *Snapshot) Len() int {
+	return s.queue.Len()
+}
+
+func (s *Snapshot) IsEmpty() bool {
+	return s.queue.IsEmpty()
+}
+
+func (s *Snapshot) Values() []interface{} {
+	return s.queue.Values()
+}
+
+func (s *Snapshot) Each(fn func(value interface{}) bool) {
+	s.queue.Each(fn)
+}
+
+func (s *Snapshot) Peek() interface{} {
+	return s.queue.Peek()
+}
+
+func (s *Snapshot) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "QueueSnapshot", s.Len(), s.Each)
+}
+
+func (s *Snapshot) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	return s.queue.MarshalJSON()
+}
+
+// WriteTo writes the same stream as Queue.WriteTo.
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+	return s.queue.WriteTo(w)
+}
+
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+	return s.queue.MarshalBinary()
+}
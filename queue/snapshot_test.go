@@ -0,0 +1,105 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotIsolation() {
+	q := NewQueue()
+
+	for i := 0; i < 5; i++ {
+		q.Push(i)
+	}
+
+	snapshot := q.Snapshot()
+
+	q.Pop()
+	q.Push(5)
+	q.Push(6)
+
+	suite.Equal([]interface{}{1, 2, 3, 4, 5, 6}, q.Values())
+	suite.Equal([]interface{}{0, 1, 2, 3, 4}, snapshot.Values())
+	suite.Equal(5, snapshot.Len())
+	suite.False(snapshot.IsEmpty())
+	suite.Equal(0, snapshot.Peek())
+
+	q.Clear()
+
+	suite.Equal(5, snapshot.Len())
+	suite.True(NewQueue().Snapshot().IsEmpty())
+	suite.Nil(NewQueue().Snapshot().Peek())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotEncoding() {
+	q := NewQueue(gods.WithCodec(gods.IntCodec))
+
+	for i := 0; i < 3; i++ {
+		q.Push(i)
+	}
+
+	snapshot := q.Snapshot()
+	q.Push(3)
+
+	data, err := json.Marshal(snapshot)
+	suite.NoError(err)
+	suite.JSONEq(`[0,1,2]`, string(data))
+
+	data, err = snapshot.MarshalBinary()
+	suite.NoError(err)
+
+	restored := NewQueue(gods.WithCodec(gods.IntCodec))
+	suite.NoError(restored.UnmarshalBinary(data))
+	suite.Equal([]interface{}{0, 1, 2}, restored.Values())
+
+	suite.Equal("QueueSnapshot[0 1 2]", snapshot.String())
+	suite.Equal("QueueSnapshot[0 ...2 more]", fmt.Sprintf("%.1v", snapshot))
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotConcurrentWriters() {
+	q := NewQueue()
+
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+
+	snapshot := q.Snapshot()
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 1000; i++ {
+			q.Push(i)
+			q.Pop()
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		sum := 0
+
+		snapshot.Each(func(value interface{}) bool {
+			sum += value.(int)
+			return true
+		})
+
+		suite.Equal(4950, sum)
+	}
+
+	wg.Wait()
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotTestSuite))
+}
@@ -0,0 +1,156 @@
+package rangequery
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+)
+
+// FenwickTree, or binary indexed tree, holds a fixed-length sequence and
+// answers prefix aggregates for a Group in O(log n) with O(log n) point
+// updates. It needs less memory and fewer combines than a SegmentTree, but
+// only supports groups, since range aggregates are found by subtracting one
+// prefix from another.
+type FenwickTree struct {
+	tree  []interface{}
+	group Group
+	mutex gods.Locker
+}
+
+// NewFenwickTree builds the tree in O(n).
+func NewFenwickTree(values []interface{}, group Group, options ...gods.Option) *FenwickTree {
+	opts := gods.NewOptions(options...)
+
+	// tree[i] holds the aggregate of the elements (i - lowbit(i), i] in
+	// one-based positions; slot 0 is unused.
+	tree := make([]interface{}, len(values)+1)
+	tree[0] = group.Identity
+	copy(tree[1:], values)
+
+	for i := 1; i < len(tree); i++ {
+		if parent := i + i&-i; parent < len(tree) {
+			tree[parent] = group.Combine(tree[parent], tree[i])
+		}
+	}
+
+	return &FenwickTree{
+		tree:  tree,
+		group: group,
+		mutex: opts.Locker(),
+	}
+}
+
+func (f *FenwickTree) Len() int {
+	return len(f.tree) - 1
+}
+
+func (f *FenwickTree) IsEmpty() bool {
+	return f.Len() == 0
+}
+
+// Add combines delta into the element at index i. It panics if i is out of
+// range.
+func (f *FenwickTree) Add(i int, delta interface{}) {
+	f.check(i, i+1)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.add(i, delta)
+}
+
+// Set replaces the element at index i. It panics if i is out of range.
+func (f *FenwickTree) Set(i int, value interface{}) {
+	f.check(i, i+1)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	old := f.group.Combine(f.group.Inverse(f.prefix(i)), f.prefix(i+1))
+	f.add(i, f.group.Combine(value, f.group.Inverse(old)))
+}
+
+// Get returns the element at index i. It panics if i is out of range.
+func (f *FenwickTree) Get(i int) interface{} {
+	return f.Query(i, i+1)
+}
+
+// Prefix returns the aggregate of the first n elements. It panics if n is
+// out of range.
+func (f *FenwickTree) Prefix(n int) interface{} {
+	f.check(0, n)
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.prefix(n)
+}
+
+// Query returns the aggregate of the elements in [from, to). It panics if
+// the range is out of bounds.
+func (f *FenwickTree) Query(from, to int) interface{} {
+	f.check(from, to)
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.group.Combine(f.group.Inverse(f.prefix(from)), f.prefix(to))
+}
+
+func (f *FenwickTree) Values() []interface{} {
+	values := make([]interface{}, 0, f.Len())
+
+	f.Each(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+// Each visits the elements in O(n log n).
+func (f *FenwickTree) Each(fn func(value interface{}) bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	previous := f.group.Identity
+
+	for i := 1; i < len(f.tree); i++ {
+		current := f.prefix(i)
+
+		if !fn(f.group.Combine(f.group.Inverse(previous), current)) {
+			return
+		}
+
+		previous = current
+	}
+}
+
+func (f *FenwickTree) Format(s fmt.State, verb rune) {
+	gods.FormatValues(s, verb, "FenwickTree", f.Len(), f.Each)
+}
+
+func (f *FenwickTree) String() string {
+	return fmt.Sprint(f)
+}
+
+func (f *FenwickTree) check(from, to int) {
+	if from < 0 || to > f.Len() || from > to {
+		panic(fmt.Sprintf("rangequery: range [%d, %d) out of bounds for length %d", from, to, f.Len()))
+	}
+}
+
+func (f *FenwickTree) add(i int, delta interface{}) {
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] = f.group.Combine(f.tree[i], delta)
+	}
+}
+
+func (f *FenwickTree) prefix(n int) interface{} {
+	result := f.group.Identity
+
+	for ; n > 0; n -= n & -n {
+		result = f.group.Combine(result, f.tree[n])
+	}
+
+	return result
+}
@@ -0,0 +1,115 @@
+package rangequery
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"testing"
+)
+
+type FenwickTreeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FenwickTreeTestSuite) TestFenwickTree() {
+	f := NewFenwickTree(int64s(5, 2, 8, 1, 9, 3), Int64SumGroup)
+
+	suite.Equal(6, f.Len())
+	suite.Equal(int64(0), f.Prefix(0))
+	suite.Equal(int64(15), f.Prefix(3))
+	suite.Equal(int64(28), f.Prefix(6))
+	suite.Equal(int64(11), f.Query(1, 4))
+	suite.Equal(int64(0), f.Query(2, 2))
+	suite.Equal(int64(9), f.Get(4))
+
+	f.Add(1, int64(3))
+	suite.Equal(int64(5), f.Get(1))
+	suite.Equal(int64(31), f.Prefix(6))
+
+	f.Set(4, int64(0))
+	suite.Equal(int64s(5, 5, 8, 1, 0, 3), f.Values())
+	suite.Equal(int64(22), f.Query(0, 6))
+}
+
+func (suite *FenwickTreeTestSuite) TestFenwickTreeFloat64() {
+	f := NewFenwickTree([]interface{}{0.5, 1.5, 2.0}, Float64SumGroup)
+
+	suite.Equal(4.0, f.Prefix(3))
+	suite.Equal(3.5, f.Query(1, 3))
+
+	f.Add(0, 1.0)
+	suite.Equal(1.5, f.Get(0))
+}
+
+func (suite *FenwickTreeTestSuite) TestFenwickTreeBounds() {
+	f := NewFenwickTree(int64s(1, 2), Int64SumGroup)
+
+	suite.Panics(func() { f.Prefix(3) })
+	suite.Panics(func() { f.Query(-1, 1) })
+	suite.Panics(func() { f.Add(2, int64(1)) })
+	suite.Panics(func() { f.Set(-1, int64(1)) })
+
+	empty := NewFenwickTree(nil, Int64SumGroup)
+	suite.True(empty.IsEmpty())
+	suite.Equal(int64(0), empty.Prefix(0))
+	suite.Empty(empty.Values())
+}
+
+func (suite *FenwickTreeTestSuite) TestFenwickTreeFormat() {
+	f := NewFenwickTree(int64s(1, 2, 3), Int64SumGroup)
+
+	suite.Equal("FenwickTree[1 2 3]", f.String())
+	suite.Equal("FenwickTree[1 ...2 more]", fmt.Sprintf("%.1v", f))
+}
+
+func (suite *FenwickTreeTestSuite) TestFenwickTreeRandom() {
+	random := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{1, 2, 7, 64, 100} {
+		model := make([]int64, n)
+		values := make([]interface{}, n)
+
+		for i := range model {
+			model[i] = random.Int63n(100)
+			values[i] = model[i]
+		}
+
+		f := NewFenwickTree(values, Int64SumGroup)
+
+		for op := 0; op < 500; op++ {
+			i := random.Intn(n)
+
+			switch random.Intn(3) {
+			case 0:
+				delta := random.Int63n(21) - 10
+				model[i] += delta
+				f.Add(i, delta)
+			case 1:
+				model[i] = random.Int63n(100)
+				f.Set(i, model[i])
+			case 2:
+				from := random.Intn(n + 1)
+				to := from + random.Intn(n-from+1)
+				expected := int64(0)
+
+				for j := from; j < to; j++ {
+					expected += model[j]
+				}
+
+				suite.Equal(expected, f.Query(from, to))
+			}
+		}
+
+		expected := make([]interface{}, n)
+
+		for i, value := range model {
+			expected[i] = value
+		}
+
+		suite.Equal(expected, f.Values())
+	}
+}
+
+func TestFenwickTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(FenwickTreeTestSuite))
+}
@@ -0,0 +1,130 @@
+// Package rangequery answers aggregate queries, such as sums, minimums and
+// maximums, over ranges of a sequence that changes between queries.
+package rangequery
+
+import (
+	"math"
+)
+
+// Monoid is an associative operation with an identity element:
+//
+//	Combine(Combine(a, b), c) == Combine(a, Combine(b, c))
+//	Combine(Identity, a) == Combine(a, Identity) == a
+type Monoid struct {
+	Identity interface{}
+	Combine  func(a, b interface{}) interface{}
+}
+
+// Group is a commutative Monoid with inverses, Combine(a, Inverse(a)) ==
+// Identity, which lets prefix aggregates be subtracted from each other.
+type Group struct {
+	Monoid
+	Inverse func(a interface{}) interface{}
+}
+
+// Action describes updates applied to every element of a range. Updates
+// form a monoid of their own: Compose(later, earlier) is the update that
+// applies earlier and then later, and Identity changes nothing. Apply
+// returns the aggregate of length elements after update is applied to each
+// of them, and must distribute over the Monoid it is used with:
+//
+//	Apply(u, Combine(a, b), n+m) == Combine(Apply(u, a, n), Apply(u, b, m))
+type Action struct {
+	Identity interface{}
+	Compose  func(later, earlier interface{}) interface{}
+	Apply    func(update, aggregate interface{}, length int) interface{}
+}
+
+var (
+	Int64Sum   = sum[int64]()
+	Int64Min   = extreme[int64](math.MaxInt64, func(a, b int64) bool { return a < b })
+	Int64Max   = extreme[int64](math.MinInt64, func(a, b int64) bool { return a > b })
+	Float64Sum = sum[float64]()
+	Float64Min = extreme(math.Inf(1), func(a, b float64) bool { return a < b })
+	Float64Max = extreme(math.Inf(-1), func(a, b float64) bool { return a > b })
+
+	Int64SumGroup   = Group{Monoid: Int64Sum, Inverse: func(a interface{}) interface{} { return -a.(int64) }}
+	Float64SumGroup = Group{Monoid: Float64Sum, Inverse: func(a interface{}) interface{} { return -a.(float64) }}
+
+	// Int64AddToSum adds an int64 to every element of a range of an Int64Sum
+	// tree, and Int64AddToMinMax does the same for Int64Min and Int64Max.
+	Int64AddToSum      = add[int64](true)
+	Int64AddToMinMax   = add[int64](false)
+	Float64AddToSum    = add[float64](true)
+	Float64AddToMinMax = add[float64](false)
+
+	// Int64AssignToSum sets every element of a range of an Int64Sum tree to
+	// an int64, and Int64AssignToMinMax does the same for Int64Min and
+	// Int64Max.
+	Int64AssignToSum      = assign[int64](true)
+	Int64AssignToMinMax   = assign[int64](false)
+	Float64AssignToSum    = assign[float64](true)
+	Float64AssignToMinMax = assign[float64](false)
+)
+
+type number interface {
+	~int64 | ~float64
+}
+
+func sum[T number]() Monoid {
+	return Monoid{
+		Identity: T(0),
+		Combine: func(a, b interface{}) interface{} {
+			return a.(T) + b.(T)
+		},
+	}
+}
+
+func extreme[T number](identity T, better func(a, b T) bool) Monoid {
+	return Monoid{
+		Identity: identity,
+		Combine: func(a, b interface{}) interface{} {
+			if better(b.(T), a.(T)) {
+				return b
+			}
+
+			return a
+		},
+	}
+}
+
+func add[T number](scaled bool) Action {
+	return Action{
+		Identity: T(0),
+		Compose: func(later, earlier interface{}) interface{} {
+			return later.(T) + earlier.(T)
+		},
+		Apply: func(update, aggregate interface{}, length int) interface{} {
+			if scaled {
+				return aggregate.(T) + update.(T)*T(length)
+			}
+
+			return aggregate.(T) + update.(T)
+		},
+	}
+}
+
+// assign uses nil as the update that changes nothing.
+func assign[T number](scaled bool) Action {
+	return Action{
+		Identity: nil,
+		Compose: func(later, earlier interface{}) interface{} {
+			if later == nil {
+				return earlier
+			}
+
+			return later
+		},
+		Apply: func(update, aggregate interface{}, length int) interface{} {
+			if update == nil {
+				return aggregate
+			}
+
+			if scaled {
+				return update.(T) * T(length)
+			}
+
+			return update
+		},
+	}
+}
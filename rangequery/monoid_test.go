@@ -0,0 +1,60 @@
+package rangequery
+
+import (
+	"github.com/stretchr/testify/suite"
+	"math"
+	"testing"
+)
+
+type MonoidTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MonoidTestSuite) TestMonoids() {
+	for _, check := range []struct {
+		monoid   Monoid
+		a, b     interface{}
+		combined interface{}
+	}{
+		{Int64Sum, int64(3), int64(4), int64(7)},
+		{Int64Min, int64(3), int64(-4), int64(-4)},
+		{Int64Max, int64(3), int64(-4), int64(3)},
+		{Float64Sum, 1.5, 2.0, 3.5},
+		{Float64Min, 1.5, 2.0, 1.5},
+		{Float64Max, 1.5, 2.0, 2.0},
+	} {
+		suite.Equal(check.combined, check.monoid.Combine(check.a, check.b))
+		suite.Equal(check.a, check.monoid.Combine(check.monoid.Identity, check.a))
+		suite.Equal(check.a, check.monoid.Combine(check.a, check.monoid.Identity))
+	}
+
+	suite.Equal(math.Inf(1), Float64Min.Identity)
+	suite.Equal(int64(math.MinInt64), Int64Max.Identity)
+}
+
+func (suite *MonoidTestSuite) TestGroups() {
+	suite.Equal(int64(0), Int64SumGroup.Combine(int64(5), Int64SumGroup.Inverse(int64(5))))
+	suite.Equal(0.0, Float64SumGroup.Combine(2.5, Float64SumGroup.Inverse(2.5)))
+}
+
+func (suite *MonoidTestSuite) TestActions() {
+	suite.Equal(int64(13), Int64AddToSum.Apply(int64(2), int64(7), 3))
+	suite.Equal(int64(9), Int64AddToMinMax.Apply(int64(2), int64(7), 3))
+	suite.Equal(int64(5), Int64AddToSum.Compose(int64(2), int64(3)))
+	suite.Equal(int64(7), Int64AddToSum.Apply(Int64AddToSum.Identity, int64(7), 3))
+
+	suite.Equal(int64(6), Int64AssignToSum.Apply(int64(2), int64(7), 3))
+	suite.Equal(int64(2), Int64AssignToMinMax.Apply(int64(2), int64(7), 3))
+	suite.Equal(int64(7), Int64AssignToSum.Apply(Int64AssignToSum.Identity, int64(7), 3))
+	suite.Equal(int64(2), Int64AssignToSum.Compose(int64(2), int64(3)))
+	suite.Equal(int64(3), Int64AssignToSum.Compose(nil, int64(3)))
+
+	suite.Equal(4.5, Float64AddToSum.Apply(0.5, 3.0, 3))
+	suite.Equal(1.5, Float64AssignToSum.Apply(0.5, 3.0, 3))
+	suite.Equal(3.5, Float64AddToMinMax.Apply(0.5, 3.0, 3))
+	suite.Equal(0.5, Float64AssignToMinMax.Apply(0.5, 3.0, 3))
+}
+
+func TestMonoidTestSuite(t *testing.T) {
+	suite.Run(t, new(MonoidTestSuite))
+}
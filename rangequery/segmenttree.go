@@ -0,0 +1,271 @@
+package rangequery
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+)
+
+// SegmentTree holds a fixed-length sequence and answers Query over any
+// range in O(log n) for a Monoid. Trees created with NewLazySegmentTree also
+// apply an Action to a whole range in O(log n) with Update, by recording
+// updates on the nodes that cover the range and pushing them to children
+// only when a later Set or Update reaches below them.
+//
+// Nodes are stored in pre-order over exactly n leaves: the children of the
+// node for [lo, hi) are node+1 and node+2*(mid-lo), so the tree needs 2n-1
+// slots and no padding elements, which Apply would otherwise have to
+// handle.
+type SegmentTree struct {
+	tree   []interface{}
+	lazy   []interface{}
+	length int
+	monoid Monoid
+	action *Action
+	mutex  gods.Locker
+}
+
+func NewSegmentTree(values []interface{}, monoid Monoid, options ...gods.Option) *SegmentTree {
+	opts := gods.NewOptions(options...)
+
+	s := &SegmentTree{
+		length: len(values),
+		monoid: monoid,
+		mutex:  opts.Locker(),
+	}
+
+	if len(values) > 0 {
+		s.tree = make([]interface{}, 2*len(values)-1)
+		s.build(0, 0, len(values), values)
+	}
+
+	return s
+}
+
+func NewLazySegmentTree(values []interface{}, monoid Monoid, action Action, options ...gods.Option) *SegmentTree {
+	s := NewSegmentTree(values, monoid, options...)
+	s.action = &action
+	s.lazy = make([]interface{}, len(s.tree))
+
+	for i := range s.lazy {
+		s.lazy[i] = action.Identity
+	}
+
+	return s
+}
+
+func (s *SegmentTree) Len() int {
+	return s.length
+}
+
+func (s *SegmentTree) IsEmpty() bool {
+	return s.length == 0
+}
+
+// Get returns the element at index i. It panics if i is out of range.
+func (s *SegmentTree) Get(i int) interface{} {
+	s.check(i, i+1)
+
+	return s.Query(i, i+1)
+}
+
+// Set replaces the element at index i. It panics if i is out of range.
+func (s *SegmentTree) Set(i int, value interface{}) {
+	s.check(i, i+1)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.set(0, 0, s.length, i, value)
+}
+
+// Query returns the aggregate of the elements in [from, to), or the
+// identity when the range is empty. It panics if the range is out of
+// bounds.
+func (s *SegmentTree) Query(from, to int) interface{} {
+	s.check(from, to)
+
+	if from == to {
+		return s.monoid.Identity
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.query(0, 0, s.length, from, to)
+}
+
+// Update applies update to every element in [from, to). It panics if the
+// range is out of bounds or the tree was not created with
+// NewLazySegmentTree.
+func (s *SegmentTree) Update(from, to int, update interface{}) {
+	if s.action == nil {
+		panic("rangequery: Update needs a tree created by NewLazySegmentTree")
+	}
+
+	s.check(from, to)
+
+	if from == to {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.update(0, 0, s.length, from, to, update)
+}
+
+func (s *SegmentTree) Values() []interface{} {
+	values := make([]interface{}, 0, s.length)
+
+	s.Each(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (s *SegmentTree) Each(fn func(value interface{}) bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var pending interface{}
+
+	if s.action != nil {
+		pending = s.action.Identity
+	}
+
+	if s.length > 0 {
+		s.each(0, 0, s.length, pending, fn)
+	}
+}
+
+func (s *SegmentTree) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "SegmentTree", s.Len(), s.Each)
+}
+
+func (s *SegmentTree) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *SegmentTree) check(from, to int) {
+	if from < 0 || to > s.length || from > to {
+		panic(fmt.Sprintf("rangequery: range [%d, %d) out of bounds for length %d", from, to, s.length))
+	}
+}
+
+func (s *SegmentTree) build(node, lo, hi int, values []interface{}) {
+	if hi-lo == 1 {
+		s.tree[node] = values[lo]
+		return
+	}
+
+	mid := (lo + hi) / 2
+	left, right := node+1, node+2*(mid-lo)
+	s.build(left, lo, mid, values)
+	s.build(right, mid, hi, values)
+	s.tree[node] = s.monoid.Combine(s.tree[left], s.tree[right])
+}
+
+// query treats the pending update of each node on the way down as applying
+// to the part of the result below it, so that reads never modify the tree.
+func (s *SegmentTree) query(node, lo, hi, from, to int) interface{} {
+	if from <= lo && hi <= to {
+		return s.tree[node]
+	}
+
+	mid := (lo + hi) / 2
+	left, right := node+1, node+2*(mid-lo)
+
+	var result interface{}
+
+	switch {
+	case to <= mid:
+		result = s.query(left, lo, mid, from, to)
+	case from >= mid:
+		result = s.query(right, mid, hi, from, to)
+	default:
+		result = s.monoid.Combine(s.query(left, lo, mid, from, to), s.query(right, mid, hi, from, to))
+	}
+
+	if s.action != nil {
+		result = s.action.Apply(s.lazy[node], result, min(hi, to)-max(lo, from))
+	}
+
+	return result
+}
+
+func (s *SegmentTree) set(node, lo, hi, i int, value interface{}) {
+	if hi-lo == 1 {
+		s.tree[node] = value
+		return
+	}
+
+	mid := (lo + hi) / 2
+	left, right := node+1, node+2*(mid-lo)
+	s.push(node, lo, mid, hi)
+
+	if i < mid {
+		s.set(left, lo, mid, i, value)
+	} else {
+		s.set(right, mid, hi, i, value)
+	}
+
+	s.tree[node] = s.monoid.Combine(s.tree[left], s.tree[right])
+}
+
+func (s *SegmentTree) update(node, lo, hi, from, to int, update interface{}) {
+	if to <= lo || hi <= from {
+		return
+	}
+
+	if from <= lo && hi <= to {
+		s.apply(node, hi-lo, update)
+		return
+	}
+
+	mid := (lo + hi) / 2
+	left, right := node+1, node+2*(mid-lo)
+	s.push(node, lo, mid, hi)
+	s.update(left, lo, mid, from, to, update)
+	s.update(right, mid, hi, from, to, update)
+	s.tree[node] = s.monoid.Combine(s.tree[left], s.tree[right])
+}
+
+func (s *SegmentTree) apply(node, length int, update interface{}) {
+	s.tree[node] = s.action.Apply(update, s.tree[node], length)
+	s.lazy[node] = s.action.Compose(update, s.lazy[node])
+}
+
+// push moves the pending update of node to its children.
+func (s *SegmentTree) push(node, lo, mid, hi int) {
+	if s.action == nil {
+		return
+	}
+
+	s.apply(node+1, mid-lo, s.lazy[node])
+	s.apply(node+2*(mid-lo), hi-mid, s.lazy[node])
+	s.lazy[node] = s.action.Identity
+}
+
+// each visits the leaves in order, applying the updates still pending
+// above them.
+func (s *SegmentTree) each(node, lo, hi int, pending interface{}, fn func(value interface{}) bool) bool {
+	if hi-lo == 1 {
+		value := s.tree[node]
+
+		if s.action != nil {
+			value = s.action.Apply(pending, value, 1)
+		}
+
+		return fn(value)
+	}
+
+	if s.action != nil {
+		pending = s.action.Compose(pending, s.lazy[node])
+	}
+
+	mid := (lo + hi) / 2
+
+	return s.each(node+1, lo, mid, pending, fn) && s.each(node+2*(mid-lo), mid, hi, pending, fn)
+}
@@ -0,0 +1,197 @@
+package rangequery
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+type SegmentTreeTestSuite struct {
+	suite.Suite
+}
+
+func int64s(values ...int64) []interface{} {
+	result := make([]interface{}, len(values))
+
+	for i, value := range values {
+		result[i] = value
+	}
+
+	return result
+}
+
+func (suite *SegmentTreeTestSuite) TestSegmentTreeQuery() {
+	values := int64s(5, 2, 8, 1, 9, 3)
+	sum := NewSegmentTree(values, Int64Sum)
+	min := NewSegmentTree(values, Int64Min)
+	max := NewSegmentTree(values, Int64Max)
+
+	suite.Equal(6, sum.Len())
+	suite.Equal(int64(28), sum.Query(0, 6))
+	suite.Equal(int64(11), sum.Query(1, 4))
+	suite.Equal(int64(0), sum.Query(3, 3))
+	suite.Equal(int64(1), min.Query(0, 6))
+	suite.Equal(int64(2), min.Query(0, 3))
+	suite.Equal(int64(9), max.Query(2, 6))
+	suite.Equal(int64(8), max.Query(2, 4))
+	suite.Equal(int64(9), sum.Get(4))
+
+	sum.Set(3, int64(10))
+	min.Set(3, int64(10))
+	suite.Equal(int64(37), sum.Query(0, 6))
+	suite.Equal(int64(8), min.Query(2, 4))
+	suite.Equal(int64s(5, 2, 8, 10, 9, 3), sum.Values())
+}
+
+func (suite *SegmentTreeTestSuite) TestSegmentTreeLazy() {
+	sum := NewLazySegmentTree(int64s(1, 2, 3, 4, 5, 6, 7), Int64Sum, Int64AddToSum)
+	max := NewLazySegmentTree(int64s(1, 2, 3, 4, 5, 6, 7), Int64Max, Int64AddToMinMax)
+
+	sum.Update(1, 5, int64(10))
+	max.Update(1, 5, int64(10))
+	suite.Equal(int64(68), sum.Query(0, 7))
+	suite.Equal(int64(27), sum.Query(2, 4))
+	suite.Equal(int64(15), max.Query(0, 7))
+	suite.Equal(int64(7), max.Query(5, 7))
+	suite.Equal(int64s(1, 12, 13, 14, 15, 6, 7), sum.Values())
+
+	sum.Set(2, int64(0))
+	suite.Equal(int64s(1, 12, 0, 14, 15, 6, 7), sum.Values())
+	suite.Equal(int64(14), sum.Get(3))
+
+	assigned := NewLazySegmentTree(int64s(1, 2, 3, 4), Int64Sum, Int64AssignToSum)
+	assigned.Update(0, 3, int64(5))
+	assigned.Update(2, 4, int64(1))
+	suite.Equal(int64s(5, 5, 1, 1), assigned.Values())
+	suite.Equal(int64(12), assigned.Query(0, 4))
+
+	floats := NewLazySegmentTree([]interface{}{1.5, 2.5, -1.0}, Float64Min, Float64AssignToMinMax)
+	floats.Update(0, 2, 0.5)
+	suite.Equal(-1.0, floats.Query(0, 3))
+	suite.Equal(0.5, floats.Query(0, 2))
+}
+
+func (suite *SegmentTreeTestSuite) TestSegmentTreeCustomMonoid() {
+	concat := Monoid{
+		Identity: "",
+		Combine: func(a, b interface{}) interface{} {
+			return a.(string) + b.(string)
+		},
+	}
+
+	upper := Action{
+		Identity: false,
+		Compose: func(later, earlier interface{}) interface{} {
+			return later.(bool) || earlier.(bool)
+		},
+		Apply: func(update, aggregate interface{}, length int) interface{} {
+			if update.(bool) {
+				return strings.ToUpper(aggregate.(string))
+			}
+
+			return aggregate
+		},
+	}
+
+	s := NewLazySegmentTree([]interface{}{"a", "b", "c", "d", "e"}, concat, upper)
+	suite.Equal("bcd", s.Query(1, 4))
+
+	s.Update(2, 5, true)
+	suite.Equal("abCDE", s.Query(0, 5))
+	suite.Equal("bC", s.Query(1, 3))
+
+	s.Set(3, "x")
+	suite.Equal("abCxE", s.Query(0, 5))
+}
+
+func (suite *SegmentTreeTestSuite) TestSegmentTreeBounds() {
+	s := NewSegmentTree(int64s(1, 2, 3), Int64Sum)
+
+	suite.Panics(func() { s.Query(-1, 2) })
+	suite.Panics(func() { s.Query(2, 4) })
+	suite.Panics(func() { s.Query(2, 1) })
+	suite.Panics(func() { s.Get(3) })
+	suite.Panics(func() { s.Update(0, 1, int64(1)) })
+
+	empty := NewLazySegmentTree(nil, Int64Sum, Int64AddToSum)
+	suite.True(empty.IsEmpty())
+	suite.Equal(int64(0), empty.Query(0, 0))
+	suite.Empty(empty.Values())
+	empty.Update(0, 0, int64(1))
+}
+
+func (suite *SegmentTreeTestSuite) TestSegmentTreeFormat() {
+	s := NewLazySegmentTree(int64s(1, 2, 3), Int64Sum, Int64AddToSum)
+	s.Update(0, 3, int64(1))
+
+	suite.Equal("SegmentTree[2 3 4]", s.String())
+	suite.Equal("SegmentTree[2 ...2 more]", fmt.Sprintf("%.1v", s))
+}
+
+func (suite *SegmentTreeTestSuite) TestSegmentTreeRandom() {
+	random := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{1, 2, 3, 17, 100} {
+		model := make([]int64, n)
+		values := make([]interface{}, n)
+
+		for i := range model {
+			model[i] = random.Int63n(100)
+			values[i] = model[i]
+		}
+
+		sum := NewLazySegmentTree(values, Int64Sum, Int64AddToSum)
+		min := NewLazySegmentTree(values, Int64Min, Int64AddToMinMax)
+
+		for op := 0; op < 500; op++ {
+			from := random.Intn(n + 1)
+			to := from + random.Intn(n-from+1)
+
+			switch random.Intn(3) {
+			case 0:
+				delta := random.Int63n(21) - 10
+
+				for i := from; i < to; i++ {
+					model[i] += delta
+				}
+
+				sum.Update(from, to, delta)
+				min.Update(from, to, delta)
+			case 1:
+				if from < n {
+					model[from] = random.Int63n(100)
+					sum.Set(from, model[from])
+					min.Set(from, model[from])
+				}
+			case 2:
+				expectedSum, expectedMin := int64(0), Int64Min.Identity.(int64)
+
+				for i := from; i < to; i++ {
+					expectedSum += model[i]
+
+					if model[i] < expectedMin {
+						expectedMin = model[i]
+					}
+				}
+
+				suite.Equal(expectedSum, sum.Query(from, to))
+				suite.Equal(expectedMin, min.Query(from, to))
+			}
+		}
+
+		expected := make([]interface{}, n)
+
+		for i, value := range model {
+			expected[i] = value
+		}
+
+		suite.Equal(expected, sum.Values())
+		suite.Equal(expected, min.Values())
+	}
+}
+
+func TestSegmentTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(SegmentTreeTestSuite))
+}
@@ -0,0 +1,476 @@
+package skiplist
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/tree"
+	"io"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type concurrentNode struct {
+	key      interface{}
+	value    atomic.Pointer[interface{}]
+	next     []atomic.Pointer[concurrentNode]
+	marked   atomic.Bool
+	linked   atomic.Bool
+	topLevel int
+	mutex    sync.Mutex
+}
+
+func newConcurrentNode(level int, key, value interface{}) *concurrentNode {
+	node := &concurrentNode{
+		key:      key,
+		next:     make([]atomic.Pointer[concurrentNode], level),
+		topLevel: level,
+	}
+	node.value.Store(&value)
+
+	return node
+}
+
+var (
+	_ gods.Map     = (*ConcurrentSkipList)(nil)
+	_ gods.Ordered = (*ConcurrentSkipList)(nil)
+)
+
+type ConcurrentSkipList struct {
+	head     *concurrentNode
+	count    atomic.Int64
+	comparer tree.CompareFunc
+	key      gods.KeyDecoder
+	element  gods.Decoder
+	keyCodec gods.Codec
+	codec    gods.Codec
+}
+
+// NewConcurrentSkipList creates a lazy skip list: reads never lock, and
+// writers lock only the nodes they relink. The locking option is ignored;
+// only the encoding and compare options apply.
+func NewConcurrentSkipList(comparer tree.Comparer, options ...gods.Option) *ConcurrentSkipList {
+	head := newConcurrentNode(maxLevel, nil, nil)
+	head.linked.Store(true)
+
+	opts := gods.NewOptions(options...)
+	keyCodec, codec := opts.Codecs()
+
+	return &ConcurrentSkipList{
+		head:     head,
+		comparer: tree.OrderOf(comparer, opts),
+		key:      opts.KeyDecoder(),
+		element:  opts.ElementDecoder(),
+		keyCodec: keyCodec,
+		codec:    codec,
+	}
+}
+
+func (s *ConcurrentSkipList) Len() int {
+	return int(s.count.Load())
+}
+
+func (s *ConcurrentSkipList) Insert(key, value interface{}) bool {
+	var preds, succs [maxLevel]*concurrentNode
+
+	level := randomConcurrentLevel()
+
+	for {
+		if found := s.find(key, &preds, &succs); found != -1 {
+			node := succs[found]
+
+			if node.marked.Load() {
+				continue
+			}
+
+			for !node.linked.Load() {
+				runtime.Gosched()
+			}
+
+			node.mutex.Lock()
+			if node.marked.Load() {
+				node.mutex.Unlock()
+				continue
+			}
+			node.value.Store(&value)
+			node.mutex.Unlock()
+
+			return false
+		}
+
+		highest := -1
+		valid := true
+
+		var prev *concurrentNode
+
+		for i := 0; valid && i < level; i++ {
+			pred, succ := preds[i], succs[i]
+
+			if pred != prev {
+				pred.mutex.Lock()
+				prev = pred
+			}
+
+			highest = i
+			valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[i].Load() == succ
+		}
+
+		if !valid {
+			unlockPreds(&preds, highest)
+			continue
+		}
+
+		node := newConcurrentNode(level, key, value)
+
+		for i := 0; i < level; i++ {
+			node.next[i].Store(succs[i])
+		}
+
+		for i := 0; i < level; i++ {
+			preds[i].next[i].Store(node)
+		}
+
+		node.linked.Store(true)
+		s.count.Add(1)
+		unlockPreds(&preds, highest)
+
+		return true
+	}
+}
+
+func (s *ConcurrentSkipList) Delete(key interface{}) bool {
+	var preds, succs [maxLevel]*concurrentNode
+	var victim *concurrentNode
+
+	marked := false
+
+	for {
+		found := s.find(key, &preds, &succs)
+
+		if !marked {
+			if found == -1 {
+				return false
+			}
+
+			victim = succs[found]
+
+			if !victim.linked.Load() || victim.topLevel-1 != found || victim.marked.Load() {
+				return false
+			}
+
+			victim.mutex.Lock()
+
+			if victim.marked.Load() {
+				victim.mutex.Unlock()
+				return false
+			}
+
+			victim.marked.Store(true)
+			marked = true
+		}
+
+		highest := -1
+		valid := true
+
+		var prev *concurrentNode
+
+		for i := 0; valid && i < victim.topLevel; i++ {
+			pred := preds[i]
+
+			if pred != prev {
+				pred.mutex.Lock()
+				prev = pred
+			}
+
+			highest = i
+			valid = !pred.marked.Load() && pred.next[i].Load() == victim
+		}
+
+		if !valid {
+			unlockPreds(&preds, highest)
+			continue
+		}
+
+		for i := victim.topLevel - 1; i >= 0; i-- {
+			preds[i].next[i].Store(victim.next[i].Load())
+		}
+
+		s.count.Add(-1)
+		victim.mutex.Unlock()
+		unlockPreds(&preds, highest)
+
+		return true
+	}
+}
+
+func (s *ConcurrentSkipList) Get(key interface{}) (interface{}, bool) {
+	node := s.ceiling(key)
+
+	if node == nil || s.comparer.Compare(node.key, key) != 0 || !node.linked.Load() || node.marked.Load() {
+		return nil, false
+	}
+
+	return *node.value.Load(), true
+}
+
+func (s *ConcurrentSkipList) Contains(key interface{}) bool {
+	_, ok := s.Get(key)
+
+	return ok
+}
+
+func (s *ConcurrentSkipList) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+func (s *ConcurrentSkipList) Clear() {
+	s.Range(nil, nil, func(key, value interface{}) bool {
+		s.Delete(key)
+		return true
+	})
+}
+
+func (s *ConcurrentSkipList) Keys() []interface{} {
+	keys := make([]interface{}, 0, s.Len())
+
+	s.Range(nil, nil, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys
+}
+
+func (s *ConcurrentSkipList) Values() []interface{} {
+	values := make([]interface{}, 0, s.Len())
+
+	s.Range(nil, nil, func(key, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (s *ConcurrentSkipList) Each(fn func(key interface{}) bool) {
+	s.Range(nil, nil, func(key, value interface{}) bool {
+		return fn(key)
+	})
+}
+
+func (s *ConcurrentSkipList) First() (interface{}, bool) {
+	var first interface{}
+
+	found := false
+
+	s.Range(nil, nil, func(key, value interface{}) bool {
+		first, found = key, true
+		return false
+	})
+
+	return first, found
+}
+
+func (s *ConcurrentSkipList) Last() (interface{}, bool) {
+	node := s.head
+
+	for i := maxLevel - 1; i >= 0; i-- {
+		for next := node.next[i].Load(); next != nil; next = node.next[i].Load() {
+			node = next
+		}
+	}
+
+	if node != s.head && node.linked.Load() && !node.marked.Load() {
+		return node.key, true
+	}
+
+	var last interface{}
+
+	found := false
+
+	s.Range(nil, nil, func(key, value interface{}) bool {
+		last, found = key, true
+		return true
+	})
+
+	return last, found
+}
+
+func (s *ConcurrentSkipList) Range(from, to interface{}, fn func(key, value interface{}) bool) {
+	node := s.head.next[0].Load()
+
+	if from != nil {
+		node = s.ceiling(from)
+	}
+
+	for ; node != nil; node = node.next[0].Load() {
+		if to != nil && s.comparer.Compare(node.key, to) >= 0 {
+			return
+		}
+
+		if !node.linked.Load() || node.marked.Load() {
+			continue
+		}
+
+		if !fn(node.key, *node.value.Load()) {
+			return
+		}
+	}
+}
+
+func (s *ConcurrentSkipList) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "ConcurrentSkipList", s.Len(), func(fn func(key, value interface{}) bool) {
+		s.Range(nil, nil, fn)
+	})
+}
+
+func (s *ConcurrentSkipList) String() string {
+	return fmt.Sprint(s)
+}
+
+// MarshalJSON encodes the list as an object with keys in sorted order. Like
+// Range it is not a snapshot under concurrent writes.
+func (s *ConcurrentSkipList) MarshalJSON() ([]byte, error) {
+	var keys, values []interface{}
+
+	s.Range(nil, nil, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+
+	return gods.MarshalObject(keys, values)
+}
+
+// UnmarshalJSON clears the list and inserts the decoded entries. The list
+// must have been created with NewConcurrentSkipList.
+func (s *ConcurrentSkipList) UnmarshalJSON(data []byte) error {
+	if s.comparer == nil {
+		return errNoComparer
+	}
+
+	keys, values, err := gods.UnmarshalObject(data, s.key, s.element)
+
+	if err != nil {
+		return err
+	}
+
+	s.replace(keys, values)
+
+	return nil
+}
+
+// WriteTo streams the entries in key order. Like Range it is not a snapshot
+// under concurrent writes.
+func (s *ConcurrentSkipList) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteEntries(w, gods.KindMap, s.keyCodec, s.codec, func(fn func(key, value interface{}) bool) {
+		s.Range(nil, nil, fn)
+	})
+}
+
+// ReadFrom decodes a stream written by WriteTo, then clears the list and
+// inserts the entries.
+func (s *ConcurrentSkipList) ReadFrom(r io.Reader) (int64, error) {
+	if s.comparer == nil {
+		return 0, errNoComparer
+	}
+
+	var keys, values []interface{}
+
+	n, err := gods.ReadEntries(r, gods.KindMap, s.keyCodec, s.codec, func(key, value interface{}) {
+		keys = append(keys, key)
+		values = append(values, value)
+	})
+
+	if err == nil {
+		s.replace(keys, values)
+	}
+
+	return n, err
+}
+
+func (s *ConcurrentSkipList) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(s)
+}
+
+func (s *ConcurrentSkipList) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(s, data)
+}
+
+func (s *ConcurrentSkipList) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+func (s *ConcurrentSkipList) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+func (s *ConcurrentSkipList) replace(keys, values []interface{}) {
+	s.Clear()
+
+	for i, key := range keys {
+		s.Insert(key, values[i])
+	}
+}
+
+func (s *ConcurrentSkipList) find(key interface{}, preds, succs *[maxLevel]*concurrentNode) int {
+	found := -1
+	pred := s.head
+
+	for i := maxLevel - 1; i >= 0; i-- {
+		curr := pred.next[i].Load()
+
+		for curr != nil && s.comparer.Compare(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.next[i].Load()
+		}
+
+		if found == -1 && curr != nil && s.comparer.Compare(curr.key, key) == 0 {
+			found = i
+		}
+
+		preds[i] = pred
+		succs[i] = curr
+	}
+
+	return found
+}
+
+func (s *ConcurrentSkipList) ceiling(key interface{}) *concurrentNode {
+	pred := s.head
+
+	var curr *concurrentNode
+
+	for i := maxLevel - 1; i >= 0; i-- {
+		curr = pred.next[i].Load()
+
+		for curr != nil && s.comparer.Compare(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.next[i].Load()
+		}
+	}
+
+	return curr
+}
+
+func unlockPreds(preds *[maxLevel]*concurrentNode, highest int) {
+	var prev *concurrentNode
+
+	for i := 0; i <= highest; i++ {
+		if preds[i] != prev {
+			preds[i].mutex.Unlock()
+			prev = preds[i]
+		}
+	}
+}
+
+func randomConcurrentLevel() int {
+	level := 1
+
+	for level < maxLevel && rand.Float64() < probability {
+		level++
+	}
+
+	return level
+}
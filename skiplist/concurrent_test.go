@@ -0,0 +1,424 @@
+package skiplist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
+	"github.com/kucuny/gods/tree"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	opInsert = iota
+	opDelete
+	opGet
+)
+
+type operation struct {
+	kind       int
+	key, value int
+	ok         bool
+	result     int
+	call, ret  int64
+}
+
+type keyState struct {
+	present bool
+	value   int
+}
+
+func (o operation) apply(state keyState) (keyState, bool) {
+	switch o.kind {
+	case opInsert:
+		return keyState{present: true, value: o.value}, o.ok == !state.present
+	case opDelete:
+		return keyState{}, o.ok == state.present
+	default:
+		return state, o.ok == state.present && (!o.ok || o.result == state.value)
+	}
+}
+
+func linearizable(ops []operation) bool {
+	full := uint64(1)<<uint(len(ops)) - 1
+	visited := make(map[[3]uint64]bool)
+
+	var search func(done uint64, state keyState) bool
+
+	search = func(done uint64, state keyState) bool {
+		if done == full {
+			return true
+		}
+
+		present := uint64(0)
+		if state.present {
+			present = 1
+		}
+
+		memo := [3]uint64{done, present, uint64(state.value)}
+
+		if visited[memo] {
+			return false
+		}
+
+		visited[memo] = true
+		minRet := int64(-1)
+
+		for i, o := range ops {
+			if done&(1<<uint(i)) == 0 && (minRet < 0 || o.ret < minRet) {
+				minRet = o.ret
+			}
+		}
+
+		for i, o := range ops {
+			if done&(1<<uint(i)) != 0 || o.call > minRet {
+				continue
+			}
+
+			if next, ok := o.apply(state); ok && search(done|1<<uint(i), next) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return search(0, keyState{})
+}
+
+type ConcurrentSkipListTestSuite struct {
+	suite.Suite
+	s *ConcurrentSkipList
+}
+
+func (suite *ConcurrentSkipListTestSuite) SetupTest() {
+	suite.s = NewConcurrentSkipList(tree.IntegerComparer)
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestConcurrentSkipListSequential() {
+	suite.True(suite.s.Insert(2, "two"))
+	suite.True(suite.s.Insert(1, "one"))
+	suite.False(suite.s.Insert(2, "TWO"))
+	suite.Equal(2, suite.s.Len())
+
+	value, ok := suite.s.Get(2)
+	suite.True(ok)
+	suite.Equal("TWO", value)
+	suite.False(suite.s.Contains(3))
+
+	suite.True(suite.s.Delete(1))
+	suite.False(suite.s.Delete(1))
+	suite.Equal(1, suite.s.Len())
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestConcurrentSkipListRange() {
+	for i := 9; i >= 0; i-- {
+		suite.s.Insert(i, i*i)
+	}
+
+	var keys, values []interface{}
+
+	suite.s.Range(3, 7, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+
+	suite.Equal([]interface{}{3, 4, 5, 6}, keys)
+	suite.Equal([]interface{}{9, 16, 25, 36}, values)
+
+	keys = nil
+	suite.s.Range(nil, nil, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+
+	suite.Equal([]interface{}{0, 1}, keys)
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestConcurrentSkipListContainer() {
+	suite.True(suite.s.IsEmpty())
+
+	_, ok := suite.s.Last()
+	suite.False(ok)
+
+	suite.s.Insert(2, "b")
+	suite.s.Insert(1, "a")
+	suite.s.Insert(3, "c")
+
+	suite.False(suite.s.IsEmpty())
+	suite.Equal([]interface{}{1, 2, 3}, suite.s.Keys())
+	suite.Equal([]interface{}{"a", "b", "c"}, suite.s.Values())
+
+	first, _ := suite.s.First()
+	last, _ := suite.s.Last()
+	suite.Equal(1, first)
+	suite.Equal(3, last)
+
+	var visited []interface{}
+	suite.s.Each(func(key interface{}) bool {
+		visited = append(visited, key)
+		return true
+	})
+	suite.Equal([]interface{}{1, 2, 3}, visited)
+
+	suite.s.Clear()
+	suite.True(suite.s.IsEmpty())
+	suite.Equal([]interface{}{}, suite.s.Keys())
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestConcurrentSkipListParallelWriters() {
+	const writers = 8
+	const perWriter = 500
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			last := -1
+			suite.s.Range(nil, nil, func(key, value interface{}) bool {
+				if key.(int) <= last {
+					suite.Fail("range out of order")
+				}
+				last = key.(int)
+				return true
+			})
+		}
+	}()
+
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < perWriter; i++ {
+				key := i*writers + w
+				suite.s.Insert(key, key)
+
+				if i%2 == 1 {
+					suite.s.Delete(key)
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(stop)
+
+	suite.Equal(writers*perWriter/2, suite.s.Len())
+
+	for key := 0; key < writers*perWriter; key++ {
+		suite.Equal((key/writers)%2 == 0, suite.s.Contains(key), key)
+	}
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestConcurrentSkipListLinearizable() {
+	const rounds = 300
+	const workers = 4
+	const opsPerWorker = 4
+	const keys = 2
+
+	r := rand.New(rand.NewSource(1))
+
+	for round := 0; round < rounds; round++ {
+		s := NewConcurrentSkipList(tree.IntegerComparer)
+		history := make([][]operation, workers)
+		plans := make([][]operation, workers)
+
+		for w := range plans {
+			for i := 0; i < opsPerWorker; i++ {
+				plans[w] = append(plans[w], operation{
+					kind:  r.Intn(3),
+					key:   r.Intn(keys),
+					value: round*100 + w*10 + i,
+				})
+			}
+		}
+
+		var clock atomic.Int64
+		var wg sync.WaitGroup
+
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+
+			go func(w int) {
+				defer wg.Done()
+
+				for _, o := range plans[w] {
+					o.call = clock.Add(1)
+
+					switch o.kind {
+					case opInsert:
+						o.ok = s.Insert(o.key, o.value)
+					case opDelete:
+						o.ok = s.Delete(o.key)
+					default:
+						var value interface{}
+						value, o.ok = s.Get(o.key)
+						if o.ok {
+							o.result = value.(int)
+						}
+					}
+
+					o.ret = clock.Add(1)
+					history[w] = append(history[w], o)
+				}
+			}(w)
+		}
+
+		wg.Wait()
+
+		for key := 0; key < keys; key++ {
+			var ops []operation
+
+			for _, h := range history {
+				for _, o := range h {
+					if o.key == key {
+						ops = append(ops, o)
+					}
+				}
+			}
+
+			suite.True(linearizable(ops), "round %d key %d: %+v", round, key, ops)
+		}
+	}
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestLinearizableRejectsStaleRead() {
+	ops := []operation{
+		{kind: opInsert, key: 0, value: 1, ok: true, call: 1, ret: 2},
+		{kind: opGet, key: 0, ok: false, call: 3, ret: 4},
+	}
+
+	suite.False(linearizable(ops))
+
+	ops[1].call, ops[1].ret = 0, 5
+	suite.True(linearizable(ops))
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestConcurrentSkipListJSON() {
+	s := NewConcurrentSkipList(tree.Float64Comparer, gods.WithKey[float64](), gods.WithElement[bool]())
+	s.Insert(2.5, true)
+	s.Insert(-0.5, false)
+
+	data, err := json.Marshal(s)
+	suite.NoError(err)
+	suite.Equal(`{"-0.5":false,"2.5":true}`, string(data))
+
+	decoded := NewConcurrentSkipList(tree.Float64Comparer, gods.WithKey[float64](), gods.WithElement[bool]())
+	decoded.Insert(7.0, true)
+	suite.NoError(json.Unmarshal(data, decoded))
+	suite.Equal([]interface{}{-0.5, 2.5}, decoded.Keys())
+	suite.Equal([]interface{}{false, true}, decoded.Values())
+
+	empty, err := json.Marshal(NewConcurrentSkipList(tree.IntegerComparer))
+	suite.NoError(err)
+	suite.Equal(`{}`, string(empty))
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestConcurrentSkipListBinary() {
+	s := NewConcurrentSkipList(tree.StringComparer, gods.WithKeyCodec(gods.StringCodec), gods.WithCodec(gods.IntCodec))
+	s.Insert("b", 2)
+	s.Insert("a", 1)
+
+	var buffer bytes.Buffer
+
+	written, err := s.WriteTo(&buffer)
+	suite.NoError(err)
+
+	decoded := NewConcurrentSkipList(tree.StringComparer, gods.WithKeyCodec(gods.StringCodec), gods.WithCodec(gods.IntCodec))
+	decoded.Insert("z", 26)
+
+	read, err := decoded.ReadFrom(&buffer)
+	suite.NoError(err)
+	suite.Equal(written, read)
+	suite.Equal([]interface{}{"a", "b"}, decoded.Keys())
+	suite.Equal([]interface{}{1, 2}, decoded.Values())
+
+	data, err := s.GobEncode()
+	suite.NoError(err)
+	suite.NoError(decoded.GobDecode(data))
+	suite.Equal(2, decoded.Len())
+
+	suite.Error(NewConcurrentSkipList(tree.StringComparer).GobDecode(data))
+}
+
+func (suite *ConcurrentSkipListTestSuite) TestConcurrentSkipListFormat() {
+	s := NewConcurrentSkipList(tree.StringComparer)
+	s.Insert("b", 2)
+	s.Insert("a", 1)
+
+	suite.Equal("ConcurrentSkipList[a:1 b:2]", s.String())
+	suite.Equal(`ConcurrentSkipList["a":'\x01' ...1 more]`, fmt.Sprintf("%.1q", s))
+}
+
+func TestConcurrentSkipListTestSuite(t *testing.T) {
+	suite.Run(t, new(ConcurrentSkipListTestSuite))
+}
+
+func TestConcurrentSkipListConformance(t *testing.T) {
+	suite.Run(t, &godstest.MapSuite{
+		New: func() gods.Map { return NewConcurrentSkipList(tree.IntegerComparer) },
+	})
+}
+
+func FuzzConcurrentSkipList(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 5, 0, 15, 1, 5, 2, 10, 0, 5})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		s := NewConcurrentSkipList(tree.IntegerComparer)
+		model := make(map[int]int)
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			key := int(ops[i+1])
+			_, present := model[key]
+
+			switch ops[i] % 3 {
+			case 0:
+				if s.Insert(key, i) == present {
+					t.Fatalf("insert %d disagrees with model", key)
+				}
+
+				model[key] = i
+			case 1:
+				if s.Delete(key) != present {
+					t.Fatalf("delete %d disagrees with model", key)
+				}
+
+				delete(model, key)
+			case 2:
+				if value, ok := s.Get(key); ok != present || (ok && value != model[key]) {
+					t.Fatalf("get %d disagrees with model", key)
+				}
+			}
+
+			if s.Len() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), s.Len())
+			}
+
+			last := -1
+
+			s.Each(func(key interface{}) bool {
+				if key.(int) <= last {
+					t.Fatalf("keys out of order at %v", key)
+				}
+
+				last = key.(int)
+				return true
+			})
+		}
+	})
+}
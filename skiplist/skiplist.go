@@ -0,0 +1,496 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/tree"
+	"io"
+	"math/rand"
+	"time"
+)
+
+const (
+	maxLevel    = 32
+	probability = 0.25
+)
+
+var errNoComparer = errors.New("skiplist: cannot decode into a list without a comparer")
+
+type Runner func(node *Node)
+
+type Node struct {
+	next  []*Node
+	span  []int
+	Key   interface{}
+	Value interface{}
+}
+
+func newNode(level int, key, value interface{}) *Node {
+	return &Node{
+		next:  make([]*Node, level),
+		span:  make([]int, level),
+		Key:   key,
+		Value: value,
+	}
+}
+
+func (n *Node) Next() *Node {
+	return n.next[0]
+}
+
+var (
+	_ gods.Map     = (*SkipList)(nil)
+	_ gods.Ordered = (*SkipList)(nil)
+)
+
+type SkipList struct {
+	head     *Node
+	level    int
+	count    int
+	comparer tree.CompareFunc
+	random   *rand.Rand
+	mutex    gods.Locker
+	key      gods.KeyDecoder
+	element  gods.Decoder
+	keyCodec gods.Codec
+	codec    gods.Codec
+}
+
+func NewSkipList(comparer tree.Comparer, options ...gods.Option) *SkipList {
+	opts := gods.NewOptions(options...)
+	keyCodec, codec := opts.Codecs()
+
+	return &SkipList{
+		head:     newNode(maxLevel, nil, nil),
+		level:    1,
+		count:    0,
+		comparer: tree.OrderOf(comparer, opts),
+		random:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		mutex:    opts.Locker(),
+		key:      opts.KeyDecoder(),
+		element:  opts.ElementDecoder(),
+		keyCodec: keyCodec,
+		codec:    codec,
+	}
+}
+
+func (s *SkipList) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.count
+}
+
+func (s *SkipList) Insert(key, value interface{}) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var update [maxLevel]*Node
+	var rank [maxLevel]int
+
+	node := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		if i < s.level-1 {
+			rank[i] = rank[i+1]
+		}
+
+		for node.next[i] != nil && s.comparer.Compare(node.next[i].Key, key) < 0 {
+			rank[i] += node.span[i]
+			node = node.next[i]
+		}
+
+		update[i] = node
+	}
+
+	if next := node.next[0]; next != nil && s.comparer.Compare(next.Key, key) == 0 {
+		next.Value = value
+		return false
+	}
+
+	level := s.randomLevel()
+
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = s.head
+			update[i].span[i] = s.count
+		}
+
+		s.level = level
+	}
+
+	inserted := newNode(level, key, value)
+
+	for i := 0; i < level; i++ {
+		inserted.next[i] = update[i].next[i]
+		update[i].next[i] = inserted
+
+		inserted.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+
+	for i := level; i < s.level; i++ {
+		update[i].span[i]++
+	}
+
+	s.count++
+
+	return true
+}
+
+func (s *SkipList) Delete(key interface{}) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var update [maxLevel]*Node
+
+	node := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && s.comparer.Compare(node.next[i].Key, key) < 0 {
+			node = node.next[i]
+		}
+
+		update[i] = node
+	}
+
+	node = node.next[0]
+
+	if node == nil || s.comparer.Compare(node.Key, key) != 0 {
+		return false
+	}
+
+	for i := 0; i < s.level; i++ {
+		if update[i].next[i] == node {
+			update[i].span[i] += node.span[i] - 1
+			update[i].next[i] = node.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+
+	s.count--
+
+	return true
+}
+
+func (s *SkipList) Search(key interface{}) *Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	node := s.ceiling(key)
+
+	if node == nil || s.comparer.Compare(node.Key, key) != 0 {
+		return nil
+	}
+
+	return node
+}
+
+func (s *SkipList) Get(key interface{}) (interface{}, bool) {
+	node := s.Search(key)
+
+	if node == nil {
+		return nil, false
+	}
+
+	return node.Value, true
+}
+
+func (s *SkipList) Contains(key interface{}) bool {
+	return s.Search(key) != nil
+}
+
+func (s *SkipList) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+func (s *SkipList) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.head = newNode(maxLevel, nil, nil)
+	s.level = 1
+	s.count = 0
+}
+
+func (s *SkipList) Keys() []interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	keys := make([]interface{}, 0, s.count)
+
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		keys = append(keys, node.Key)
+	}
+
+	return keys
+}
+
+func (s *SkipList) Values() []interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	values := make([]interface{}, 0, s.count)
+
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		values = append(values, node.Value)
+	}
+
+	return values
+}
+
+func (s *SkipList) Each(fn func(key interface{}) bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		if !fn(node.Key) {
+			return
+		}
+	}
+}
+
+func (s *SkipList) First() (interface{}, bool) {
+	if node := s.Min(); node != nil {
+		return node.Key, true
+	}
+
+	return nil, false
+}
+
+func (s *SkipList) Last() (interface{}, bool) {
+	if node := s.Max(); node != nil {
+		return node.Key, true
+	}
+
+	return nil, false
+}
+
+func (s *SkipList) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "SkipList", s.Len(), s.entries)
+}
+
+func (s *SkipList) String() string {
+	return fmt.Sprint(s)
+}
+
+// MarshalJSON encodes the list as an object with keys in sorted order.
+func (s *SkipList) MarshalJSON() ([]byte, error) {
+	keys := make([]interface{}, 0, s.Len())
+	values := make([]interface{}, 0, s.Len())
+
+	s.entries(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+
+	return gods.MarshalObject(keys, values)
+}
+
+// UnmarshalJSON replaces the contents of the list. The list must have been
+// created with NewSkipList so that it has a comparer.
+func (s *SkipList) UnmarshalJSON(data []byte) error {
+	if s.comparer == nil {
+		return errNoComparer
+	}
+
+	keys, values, err := gods.UnmarshalObject(data, s.key, s.element)
+
+	if err != nil {
+		return err
+	}
+
+	fresh := NewSkipList(nil, gods.WithCompare(s.comparer), gods.WithLock(gods.NoLock))
+
+	for i, key := range keys {
+		fresh.Insert(key, values[i])
+	}
+
+	s.swap(fresh)
+
+	return nil
+}
+
+// WriteTo streams the entries in key order.
+func (s *SkipList) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteEntries(w, gods.KindMap, s.keyCodec, s.codec, s.entries)
+}
+
+// ReadFrom replaces the contents of the list with a stream written by
+// WriteTo. The list is left unchanged if the stream is invalid.
+func (s *SkipList) ReadFrom(r io.Reader) (int64, error) {
+	if s.comparer == nil {
+		return 0, errNoComparer
+	}
+
+	fresh := NewSkipList(nil, gods.WithCompare(s.comparer), gods.WithLock(gods.NoLock))
+
+	n, err := gods.ReadEntries(r, gods.KindMap, s.keyCodec, s.codec, func(key, value interface{}) {
+		fresh.Insert(key, value)
+	})
+
+	if err == nil {
+		s.swap(fresh)
+	}
+
+	return n, err
+}
+
+func (s *SkipList) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(s)
+}
+
+func (s *SkipList) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(s, data)
+}
+
+func (s *SkipList) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+func (s *SkipList) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+func (s *SkipList) Min() *Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.head.next[0]
+}
+
+func (s *SkipList) Max() *Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	node := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil {
+			node = node.next[i]
+		}
+	}
+
+	if node == s.head {
+		return nil
+	}
+
+	return node
+}
+
+func (s *SkipList) Rank(key interface{}) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	rank := 0
+	node := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && s.comparer.Compare(node.next[i].Key, key) <= 0 {
+			rank += node.span[i]
+			node = node.next[i]
+		}
+	}
+
+	if node != s.head && s.comparer.Compare(node.Key, key) == 0 {
+		return rank - 1
+	}
+
+	return -1
+}
+
+func (s *SkipList) ByRank(rank int) *Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if rank < 0 || rank >= s.count {
+		return nil
+	}
+
+	traversed := 0
+	node := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && traversed+node.span[i] <= rank+1 {
+			traversed += node.span[i]
+			node = node.next[i]
+		}
+
+		if traversed == rank+1 {
+			return node
+		}
+	}
+
+	return nil
+}
+
+func (s *SkipList) Range(from, to interface{}, runner Runner) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	node := s.head.next[0]
+
+	if from != nil {
+		node = s.ceiling(from)
+	}
+
+	for ; node != nil; node = node.next[0] {
+		if to != nil && s.comparer.Compare(node.Key, to) >= 0 {
+			return
+		}
+
+		runner(node)
+	}
+}
+
+func (s *SkipList) Traverse(runner Runner) {
+	s.Range(nil, nil, runner)
+}
+
+func (s *SkipList) entries(fn func(key, value interface{}) bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		if !fn(node.Key, node.Value) {
+			return
+		}
+	}
+}
+
+func (s *SkipList) swap(other *SkipList) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.head, s.level, s.count = other.head, other.level, other.count
+}
+
+func (s *SkipList) ceiling(key interface{}) *Node {
+	node := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && s.comparer.Compare(node.next[i].Key, key) < 0 {
+			node = node.next[i]
+		}
+	}
+
+	return node.next[0]
+}
+
+func (s *SkipList) randomLevel() int {
+	level := 1
+
+	for level < maxLevel && s.random.Float64() < probability {
+		level++
+	}
+
+	return level
+}
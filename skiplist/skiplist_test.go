@@ -0,0 +1,342 @@
+package skiplist
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
+	"github.com/kucuny/gods/tree"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type SkipListTestSuite struct {
+	suite.Suite
+	s *SkipList
+}
+
+func (suite *SkipListTestSuite) SetupTest() {
+	suite.s = NewSkipList(tree.IntegerComparer)
+}
+
+func (suite *SkipListTestSuite) keys() []interface{} {
+	var keys []interface{}
+
+	suite.s.Traverse(func(node *Node) {
+		keys = append(keys, node.Key)
+	})
+
+	return keys
+}
+
+func (suite *SkipListTestSuite) TestSkipListInsert() {
+	suite.Equal(0, suite.s.Len())
+	suite.Nil(suite.s.Min())
+	suite.Nil(suite.s.Max())
+
+	suite.True(suite.s.Insert(20, "b"))
+	suite.True(suite.s.Insert(10, "a"))
+	suite.True(suite.s.Insert(30, "c"))
+	suite.False(suite.s.Insert(20, "B"))
+
+	suite.Equal(3, suite.s.Len())
+	suite.Equal([]interface{}{10, 20, 30}, suite.keys())
+	suite.Equal(10, suite.s.Min().Key)
+	suite.Equal(30, suite.s.Max().Key)
+
+	value, ok := suite.s.Get(20)
+	suite.True(ok)
+	suite.Equal("B", value)
+}
+
+func (suite *SkipListTestSuite) TestSkipListSearch() {
+	suite.s.Insert(5, "five")
+	suite.s.Insert(1, "one")
+
+	suite.Equal("five", suite.s.Search(5).Value)
+	suite.Equal(5, suite.s.Search(1).Next().Key)
+	suite.Nil(suite.s.Search(3))
+
+	_, ok := suite.s.Get(3)
+	suite.False(ok)
+}
+
+func (suite *SkipListTestSuite) TestSkipListDelete() {
+	for i := 0; i < 10; i++ {
+		suite.s.Insert(i, i)
+	}
+
+	suite.True(suite.s.Delete(0))
+	suite.True(suite.s.Delete(5))
+	suite.True(suite.s.Delete(9))
+	suite.False(suite.s.Delete(5))
+
+	suite.Equal(7, suite.s.Len())
+	suite.Equal([]interface{}{1, 2, 3, 4, 6, 7, 8}, suite.keys())
+	suite.Equal(4, suite.s.Rank(6))
+}
+
+func (suite *SkipListTestSuite) TestSkipListRank() {
+	for _, key := range []int{40, 10, 30, 20} {
+		suite.s.Insert(key, nil)
+	}
+
+	suite.Equal(0, suite.s.Rank(10))
+	suite.Equal(3, suite.s.Rank(40))
+	suite.Equal(-1, suite.s.Rank(25))
+
+	suite.Equal(30, suite.s.ByRank(2).Key)
+	suite.Nil(suite.s.ByRank(-1))
+	suite.Nil(suite.s.ByRank(4))
+}
+
+func (suite *SkipListTestSuite) TestSkipListRange() {
+	for i := 0; i < 10; i++ {
+		suite.s.Insert(i*10, nil)
+	}
+
+	var keys []interface{}
+	collect := func(node *Node) { keys = append(keys, node.Key) }
+
+	suite.s.Range(25, 60, collect)
+	suite.Equal([]interface{}{30, 40, 50}, keys)
+
+	keys = nil
+	suite.s.Range(nil, 20, collect)
+	suite.Equal([]interface{}{0, 10}, keys)
+
+	keys = nil
+	suite.s.Range(80, nil, collect)
+	suite.Equal([]interface{}{80, 90}, keys)
+}
+
+func (suite *SkipListTestSuite) TestSkipListRandomized() {
+	r := rand.New(rand.NewSource(1))
+	model := make(map[int]bool)
+
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(500)
+
+		if r.Intn(3) == 0 {
+			suite.Equal(model[key], suite.s.Delete(key))
+			delete(model, key)
+		} else {
+			suite.Equal(!model[key], suite.s.Insert(key, key))
+			model[key] = true
+		}
+	}
+
+	var expected []int
+
+	for key := range model {
+		expected = append(expected, key)
+	}
+
+	sort.Ints(expected)
+
+	suite.Equal(len(expected), suite.s.Len())
+
+	for rank, key := range expected {
+		suite.Equal(rank, suite.s.Rank(key))
+		suite.Equal(key, suite.s.ByRank(rank).Key)
+	}
+}
+
+func (suite *SkipListTestSuite) TestSkipListContainer() {
+	suite.True(suite.s.IsEmpty())
+
+	_, ok := suite.s.First()
+	suite.False(ok)
+
+	suite.s.Insert(2, "b")
+	suite.s.Insert(1, "a")
+	suite.s.Insert(3, "c")
+
+	suite.False(suite.s.IsEmpty())
+	suite.True(suite.s.Contains(2))
+	suite.False(suite.s.Contains(4))
+	suite.Equal([]interface{}{1, 2, 3}, suite.s.Keys())
+	suite.Equal([]interface{}{"a", "b", "c"}, suite.s.Values())
+
+	first, _ := suite.s.First()
+	last, _ := suite.s.Last()
+	suite.Equal(1, first)
+	suite.Equal(3, last)
+
+	var visited []interface{}
+	suite.s.Each(func(key interface{}) bool {
+		visited = append(visited, key)
+		return false
+	})
+	suite.Equal([]interface{}{1}, visited)
+
+	suite.s.Clear()
+	suite.True(suite.s.IsEmpty())
+	suite.Nil(suite.s.Min())
+	suite.True(suite.s.Insert(1, "a"))
+}
+
+func (suite *SkipListTestSuite) TestSkipListJSON() {
+	suite.s.Insert(10, "ten")
+	suite.s.Insert(2, "two")
+	suite.s.Insert(-1, "minus one")
+
+	data, err := json.Marshal(suite.s)
+	suite.NoError(err)
+	suite.Equal(`{"-1":"minus one","2":"two","10":"ten"}`, string(data))
+
+	decoded := NewSkipList(tree.IntegerComparer, gods.WithKey[int](), gods.WithElement[string]())
+	decoded.Insert(99, "stale")
+	suite.NoError(json.Unmarshal(data, decoded))
+	suite.Equal([]interface{}{-1, 2, 10}, decoded.Keys())
+	suite.Equal([]interface{}{"minus one", "two", "ten"}, decoded.Values())
+	suite.Equal(1, decoded.Rank(2))
+
+	strings := NewSkipList(tree.StringComparer)
+	suite.NoError(json.Unmarshal([]byte(`{"b":[1],"a":{"x":true}}`), strings))
+	suite.Equal([]interface{}{"a", "b"}, strings.Keys())
+	suite.Equal([]interface{}{map[string]interface{}{"x": true}, []interface{}{1.0}}, strings.Values())
+
+	suite.Error(json.Unmarshal([]byte(`{"x":1}`), decoded))
+	suite.Error(json.Unmarshal(data, new(SkipList)))
+
+	unsupported := NewSkipList(tree.By(func(value interface{}) interface{} { return len(value.([]int)) }, tree.IntegerComparer))
+	unsupported.Insert([]int{1}, 1)
+
+	_, err = json.Marshal(unsupported)
+	suite.Error(err)
+}
+
+func (suite *SkipListTestSuite) TestSkipListBinary() {
+	s := NewSkipList(tree.IntegerComparer, gods.WithKeyCodec(gods.IntCodec), gods.WithCodec(gods.StringCodec))
+
+	for i := 0; i < 50; i++ {
+		s.Insert(i*3, fmt.Sprint(i))
+	}
+
+	data, err := s.MarshalBinary()
+	suite.NoError(err)
+
+	decoded := NewSkipList(tree.IntegerComparer, gods.WithKeyCodec(gods.IntCodec), gods.WithCodec(gods.StringCodec))
+	decoded.Insert(1, "stale")
+	suite.NoError(decoded.UnmarshalBinary(data))
+	suite.Equal(s.Keys(), decoded.Keys())
+	suite.Equal(s.Values(), decoded.Values())
+	suite.Equal(10, decoded.Rank(30))
+
+	suite.Error(decoded.UnmarshalBinary(data[:len(data)-2]))
+	suite.Equal(50, decoded.Len())
+
+	var buffer bytes.Buffer
+
+	plain := NewSkipList(tree.StringComparer)
+	plain.Insert("k", 2.5)
+	suite.NoError(gob.NewEncoder(&buffer).Encode(plain))
+
+	decoded = NewSkipList(tree.StringComparer)
+	suite.NoError(gob.NewDecoder(&buffer).Decode(decoded))
+	value, _ := decoded.Get("k")
+	suite.Equal(2.5, value)
+
+	plain.Insert("unregistered", []interface{}{1})
+	suite.Error(gob.NewEncoder(&buffer).Encode(plain))
+
+	_, err = new(SkipList).ReadFrom(&buffer)
+	suite.Error(err)
+}
+
+func (suite *SkipListTestSuite) TestSkipListWithCompare() {
+	s := NewSkipList(nil, gods.WithCompare(cmp.Compare[string]))
+	c := NewConcurrentSkipList(nil, gods.WithCompare(cmp.Compare[string]))
+
+	for _, key := range []string{"b", "c", "a"} {
+		s.Insert(key, nil)
+		c.Insert(key, nil)
+	}
+
+	suite.Equal([]interface{}{"a", "b", "c"}, s.Keys())
+	suite.Equal([]interface{}{"a", "b", "c"}, c.Keys())
+
+	data, err := s.MarshalBinary()
+	suite.NoError(err)
+
+	decoded := NewSkipList(nil, gods.WithCompare(cmp.Compare[string]))
+	suite.NoError(decoded.UnmarshalBinary(data))
+	decoded.Insert("ab", nil)
+	suite.Equal([]interface{}{"a", "ab", "b", "c"}, decoded.Keys())
+}
+
+func (suite *SkipListTestSuite) TestSkipListFormat() {
+	suite.s.Insert(2, "b")
+	suite.s.Insert(1, "a")
+
+	suite.Equal("SkipList[1:a 2:b]", suite.s.String())
+	suite.Equal("SkipList[1:a ...1 more]", fmt.Sprintf("%.1v", suite.s))
+}
+
+func TestSkipListTestSuite(t *testing.T) {
+	suite.Run(t, new(SkipListTestSuite))
+}
+
+func TestSkipListConformance(t *testing.T) {
+	suite.Run(t, &godstest.MapSuite{
+		New: func() gods.Map { return NewSkipList(tree.IntegerComparer) },
+	})
+}
+
+func FuzzSkipList(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 5, 0, 15, 1, 5, 2, 10, 0, 5})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		s := NewSkipList(tree.IntegerComparer)
+		model := make(map[int]int)
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			key := int(ops[i+1])
+			_, present := model[key]
+
+			switch ops[i] % 4 {
+			case 0:
+				if s.Insert(key, i) == present {
+					t.Fatalf("insert %d disagrees with model", key)
+				}
+
+				model[key] = i
+			case 1:
+				if s.Delete(key) != present {
+					t.Fatalf("delete %d disagrees with model", key)
+				}
+
+				delete(model, key)
+			case 2:
+				if value, ok := s.Get(key); ok != present || (ok && value != model[key]) {
+					t.Fatalf("get %d disagrees with model", key)
+				}
+			case 3:
+				if key%8 == 0 {
+					s.Clear()
+					model = make(map[int]int)
+				}
+			}
+
+			if s.Len() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), s.Len())
+			}
+
+			for rank, k := range s.Keys() {
+				if _, ok := model[k.(int)]; !ok || s.Rank(k) != rank || s.ByRank(rank).Key != k {
+					t.Fatalf("rank of %v is inconsistent", k)
+				}
+
+				if rank > 0 && s.ByRank(rank-1).Key.(int) >= k.(int) {
+					t.Fatalf("keys out of order at rank %d", rank)
+				}
+			}
+		}
+	})
+}
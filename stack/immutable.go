@@ -0,0 +1,77 @@
+package stack
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+)
+
+var _ gods.Iterable = (*ImmutableStack)(nil)
+
+// ImmutableStack is a persistent stack built as a cons list. Push and Pop
+// return a new version that shares every element below the top with the
+// old one, so each version can be read concurrently without locking. The
+// zero value is an empty stack.
+type ImmutableStack struct {
+	value interface{}
+	next  *ImmutableStack
+	count int
+}
+
+func NewImmutableStack() *ImmutableStack {
+	return &ImmutableStack{}
+}
+
+func (s *ImmutableStack) Len() int {
+	return s.count
+}
+
+func (s *ImmutableStack) IsEmpty() bool {
+	return s.count == 0
+}
+
+// Push returns a version with value on top.
+func (s *ImmutableStack) Push(value interface{}) *ImmutableStack {
+	return &ImmutableStack{value: value, next: s, count: s.count + 1}
+}
+
+// Pop returns the version below the top, or s itself if it is empty.
+func (s *ImmutableStack) Pop() *ImmutableStack {
+	if s.count == 0 {
+		return s
+	}
+
+	return s.next
+}
+
+// Peek returns the top value, or nil if the stack is empty.
+func (s *ImmutableStack) Peek() interface{} {
+	return s.value
+}
+
+// Values returns the values top first.
+func (s *ImmutableStack) Values() []interface{} {
+	values := make([]interface{}, 0, s.count)
+
+	s.Each(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (s *ImmutableStack) Each(fn func(value interface{}) bool) {
+	for node := s; node.count > 0; node = node.next {
+		if !fn(node.value) {
+			return
+		}
+	}
+}
+
+func (s *ImmutableStack) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "ImmutableStack", s.count, s.Each)
+}
+
+func (s *ImmutableStack) String() string {
+	return fmt.Sprint(s)
+}
@@ -0,0 +1,63 @@
+package stack
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ImmutableStackTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ImmutableStackTestSuite) TestImmutableStack() {
+	empty := NewImmutableStack()
+
+	suite.True(empty.IsEmpty())
+	suite.Nil(empty.Peek())
+	suite.Same(empty, empty.Pop())
+
+	one := empty.Push(1)
+	two := one.Push(2)
+	other := one.Push(3)
+
+	suite.Equal(2, two.Len())
+	suite.Equal(2, two.Peek())
+	suite.Equal([]interface{}{2, 1}, two.Values())
+	suite.Equal([]interface{}{3, 1}, other.Values())
+	suite.Equal([]interface{}{1}, one.Values())
+	suite.Same(one, two.Pop())
+	suite.True(empty.IsEmpty())
+}
+
+func (suite *ImmutableStackTestSuite) TestImmutableStackZeroValue() {
+	var s ImmutableStack
+
+	suite.True(s.IsEmpty())
+	suite.Equal([]interface{}{1}, s.Push(1).Values())
+	suite.True(s.Push(1).Pop().IsEmpty())
+}
+
+func (suite *ImmutableStackTestSuite) TestImmutableStackEach() {
+	s := NewImmutableStack().Push(1).Push(2).Push(3)
+
+	var visited []interface{}
+
+	s.Each(func(value interface{}) bool {
+		visited = append(visited, value)
+		return len(visited) < 2
+	})
+
+	suite.Equal([]interface{}{3, 2}, visited)
+}
+
+func (suite *ImmutableStackTestSuite) TestImmutableStackFormat() {
+	s := NewImmutableStack().Push(1).Push(2).Push(3)
+
+	suite.Equal("ImmutableStack[3 2 1]", s.String())
+	suite.Equal("ImmutableStack[3 ...2 more]", fmt.Sprintf("%.1v", s))
+}
+
+func TestImmutableStackTestSuite(t *testing.T) {
+	suite.Run(t, new(ImmutableStackTestSuite))
+}
@@ -0,0 +1,80 @@
+package stack
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+)
+
+var (
+	_ gods.Iterable = (*Snapshot)(nil)
+)
+
+// Snapshot is a read-only view of a Stack as it was when Snapshot was
+// called. Reads never take the stack's lock.
+type Snapshot struct {
+	stack *Stack
+}
+
+// Snapshot returns a view of the current contents in constant time. The
+// stack copies its storage only if a later Push would overwrite an element
+// the snapshot still holds.
+func (s *Stack) Snapshot() *Snapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.count > s.shared {
+		s.shared = s.count
+	}
+
+	return &Snapshot{
+		stack: &Stack{
+			count:   s.count,
+			data:    s.data[:s.count:s.count],
+			mutex:   gods.NewLocker(gods.NoLock),
+			element: s.element,
+			codec:   s.codec,
+		},
+	}
+}
+
+func (s *Snapshot) Len() int {
+	return s.stack.Len()
+}
+
+func (s *Snapshot) IsEmpty() bool {
+	return s.stack.IsEmpty()
+}
+
+func (s *Snapshot) Values() []interface{} {
+	return s.stack.Values()
+}
+
+func (s *Snapshot) Each(fn func(value interface{}) bool) {
+	s.stack.Each(fn)
+}
+
+func (s *Snapshot) Peek() interface{} {
+	return s.stack.Peek()
+}
+
+func (s *Snapshot) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "StackSnapshot", s.Len(), s.Each)
+}
+
+func (s *Snapshot) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	return s.stack.MarshalJSON()
+}
+
+// WriteTo writes the same stream as Stack.WriteTo.
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+	return s.stack.WriteTo(w)
+}
+
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+	return s.stack.MarshalBinary()
+}
@@ -0,0 +1,114 @@
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotIsolation() {
+	s := NewStack()
+
+	for i := 0; i < 5; i++ {
+		s.Push(i)
+	}
+
+	snapshot := s.Snapshot()
+
+	s.Pop()
+	s.Pop()
+	s.Push(10)
+	s.Push(11)
+	s.Push(12)
+
+	suite.Equal([]interface{}{12, 11, 10, 2, 1, 0}, s.Values())
+	suite.Equal([]interface{}{4, 3, 2, 1, 0}, snapshot.Values())
+	suite.Equal(4, snapshot.Peek())
+	suite.Equal(5, snapshot.Len())
+
+	again := s.Snapshot()
+	s.Pop()
+	s.Push(20)
+
+	suite.Equal([]interface{}{12, 11, 10, 2, 1, 0}, again.Values())
+	suite.Equal([]interface{}{4, 3, 2, 1, 0}, snapshot.Values())
+
+	s.Clear()
+	s.Push(30)
+
+	suite.Equal(6, again.Len())
+	suite.True(NewStack().Snapshot().IsEmpty())
+	suite.Nil(NewStack().Snapshot().Peek())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotEncoding() {
+	s := NewStack(gods.WithCodec(gods.IntCodec))
+
+	for i := 0; i < 3; i++ {
+		s.Push(i)
+	}
+
+	snapshot := s.Snapshot()
+	s.Pop()
+
+	data, err := json.Marshal(snapshot)
+	suite.NoError(err)
+	suite.JSONEq(`[2,1,0]`, string(data))
+
+	data, err = snapshot.MarshalBinary()
+	suite.NoError(err)
+
+	restored := NewStack(gods.WithCodec(gods.IntCodec))
+	suite.NoError(restored.UnmarshalBinary(data))
+	suite.Equal([]interface{}{2, 1, 0}, restored.Values())
+
+	suite.Equal("StackSnapshot[2 1 0]", snapshot.String())
+	suite.Equal("StackSnapshot[2 ...2 more]", fmt.Sprintf("%.1v", snapshot))
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotConcurrentWriters() {
+	s := NewStack()
+
+	for i := 0; i < 100; i++ {
+		s.Push(i)
+	}
+
+	snapshot := s.Snapshot()
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 1000; i++ {
+			s.Pop()
+			s.Push(-i)
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		sum := 0
+
+		snapshot.Each(func(value interface{}) bool {
+			sum += value.(int)
+			return true
+		})
+
+		suite.Equal(4950, sum)
+	}
+
+	wg.Wait()
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotTestSuite))
+}
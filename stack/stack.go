@@ -1,37 +1,168 @@
 package stack
 
 import (
-	"sync"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
 )
 
-type node interface{}
+type node = interface{}
+
+var (
+	_ gods.Stack    = (*Stack)(nil)
+	_ gods.Iterable = (*Stack)(nil)
+)
 
 type Stack struct {
-	count int
-	data  []node
-	mutex *sync.Mutex
+	count   int
+	data    []node
+	mutex   gods.Locker
+	element gods.Decoder
+	codec   gods.Codec
+	shared  int
 }
 
-func NewStack() *Stack {
+func NewStack(options ...gods.Option) *Stack {
+	opts := gods.NewOptions(options...)
+	_, codec := opts.Codecs()
+
 	return &Stack{
-		count: 0,
-		mutex: new(sync.Mutex),
+		count:   0,
+		mutex:   opts.Locker(),
+		element: opts.ElementDecoder(),
+		codec:   codec,
 	}
 }
 
 func (s *Stack) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
 	return s.count
 }
 
-func (s *Stack) AtomicLen() int {
+func (s *Stack) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+func (s *Stack) Clear() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+
+	s.data = nil
+	s.count = 0
+	s.shared = 0
+}
+
+func (s *Stack) Values() []interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	values := make([]interface{}, 0, s.count)
+
+	for i := s.count - 1; i >= 0; i-- {
+		values = append(values, s.data[i])
+	}
+
+	return values
+}
+
+func (s *Stack) Each(fn func(value interface{}) bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for i := s.count - 1; i >= 0; i-- {
+		if !fn(s.data[i]) {
+			return
+		}
+	}
+}
+
+func (s *Stack) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "Stack", s.Len(), s.Each)
+}
+
+func (s *Stack) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *Stack) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Values())
+}
+
+// UnmarshalJSON replaces the contents of the stack, top first to match
+// MarshalJSON. A zero Stack is initialized with the default options first.
+func (s *Stack) UnmarshalJSON(data []byte) error {
+	s.lazyInit()
+
+	values, err := gods.UnmarshalArray(data, s.element)
+
+	if err != nil {
+		return err
+	}
+
+	s.replace(values)
+
+	return nil
+}
+
+func (s *Stack) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindStack, s.codec, s.Each)
+}
+
+// ReadFrom expects the values top first, as written by WriteTo.
+func (s *Stack) ReadFrom(reader io.Reader) (int64, error) {
+	s.lazyInit()
+
+	var values []interface{}
+
+	n, err := gods.ReadValues(reader, gods.KindStack, s.codec, func(value interface{}) {
+		values = append(values, value)
+	})
+
+	if err == nil {
+		s.replace(values)
+	}
+
+	return n, err
+}
+
+func (s *Stack) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(s)
+}
+
+func (s *Stack) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(s, data)
+}
+
+func (s *Stack) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+func (s *Stack) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+func (s *Stack) AtomicLen() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	return s.count
 }
 
 func (s *Stack) Push(item node) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+
+	if len(s.data) < s.shared {
+		// A snapshot still reads the slots above the top, so appending in
+		// place would overwrite them.
+		data := make([]node, len(s.data), 2*len(s.data)+1)
+		copy(data, s.data)
+		s.data = data
+		s.shared = 0
+	}
+
 	s.data = append(s.data, item)
 	s.count++
 }
@@ -40,7 +171,7 @@ func (s *Stack) Pop() node {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	topIndex := s.Len() - 1
+	topIndex := s.count - 1
 
 	if topIndex < 0 {
 		return nil
@@ -55,10 +186,10 @@ func (s *Stack) Pop() node {
 }
 
 func (s *Stack) Peek() node {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 
-	topIndex := s.Len() - 1
+	topIndex := s.count - 1
 
 	if topIndex < 0 {
 		return nil
@@ -66,3 +197,22 @@ func (s *Stack) Peek() node {
 
 	return s.data[topIndex]
 }
+
+func (s *Stack) lazyInit() {
+	if s.mutex == nil {
+		*s = *NewStack()
+	}
+}
+
+func (s *Stack) replace(values []interface{}) {
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.data = values
+	s.count = len(values)
+	s.shared = 0
+}
@@ -1,6 +1,12 @@
 package stack
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
@@ -33,6 +39,164 @@ func (suite *StackTestSuite) TestStack() {
 	suite.Equal(1, suite.s.Len())
 }
 
+func (suite *StackTestSuite) TestStackContainer() {
+	s := NewStack()
+	suite.True(s.IsEmpty())
+
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+
+	suite.False(s.IsEmpty())
+	suite.Equal([]interface{}{3, 2, 1}, s.Values())
+
+	var visited []interface{}
+	s.Each(func(value interface{}) bool {
+		visited = append(visited, value)
+		return len(visited) < 2
+	})
+	suite.Equal([]interface{}{3, 2}, visited)
+
+	s.Clear()
+	suite.True(s.IsEmpty())
+	suite.Nil(s.Peek())
+}
+
+func (suite *StackTestSuite) TestStackJSON() {
+	s := NewStack()
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+
+	data, err := json.Marshal(s)
+	suite.NoError(err)
+	suite.JSONEq(`[3,2,1]`, string(data))
+
+	decoded := NewStack(gods.WithElement[int]())
+	suite.NoError(json.Unmarshal(data, decoded))
+	suite.Equal(3, decoded.Pop())
+	decoded.Push(4)
+	suite.Equal([]interface{}{4, 2, 1}, decoded.Values())
+
+	var zero Stack
+	suite.NoError(json.Unmarshal([]byte(`["top","bottom"]`), &zero))
+	suite.Equal("top", zero.Peek())
+}
+
+func (suite *StackTestSuite) TestStackBinary() {
+	s := NewStack(gods.WithCodec(gods.StringCodec))
+	s.Push("bottom")
+	s.Push("top")
+
+	var buffer bytes.Buffer
+
+	_, err := s.WriteTo(&buffer)
+	suite.NoError(err)
+
+	decoded := NewStack(gods.WithCodec(gods.StringCodec))
+	_, err = decoded.ReadFrom(&buffer)
+	suite.NoError(err)
+	suite.Equal("top", decoded.Pop())
+	suite.Equal("bottom", decoded.Pop())
+
+	s = NewStack()
+	s.Push("bottom")
+	s.Push("top")
+
+	var zero Stack
+	suite.NoError(gob.NewEncoder(&buffer).Encode(s))
+	suite.NoError(gob.NewDecoder(&buffer).Decode(&zero))
+	suite.Equal([]interface{}{"top", "bottom"}, zero.Values())
+}
+
+func (suite *StackTestSuite) TestStackFormat() {
+	s := NewStack()
+	s.Push("bottom")
+	s.Push("top")
+
+	suite.Equal("Stack[top bottom]", s.String())
+	suite.Equal(`Stack["top" ...1 more]`, fmt.Sprintf("%.1q", s))
+}
+
+func (suite *StackTestSuite) TestStackFormatWhileWriting() {
+	x := NewStack()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 1000; i++ {
+			x.Push(i)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		_ = fmt.Sprint(x)
+		x.IsEmpty()
+	}
+
+	<-done
+	suite.Equal(1000, x.Len())
+}
+
 func TestStackTestSuite(t *testing.T) {
 	suite.Run(t, new(StackTestSuite))
 }
+
+func TestStackConformance(t *testing.T) {
+	suite.Run(t, &godstest.StackSuite{
+		New: func() gods.Stack { return NewStack() },
+	})
+	suite.Run(t, &godstest.StackSuite{
+		New:             func() gods.Stack { return NewStack(gods.WithLock(gods.NoLock)) },
+		SkipConcurrency: true,
+	})
+}
+
+func FuzzStack(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 1, 0, 2, 0, 0, 3, 1, 0})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		s := NewStack()
+
+		var model []interface{}
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 4 {
+			case 0:
+				s.Push(value)
+				model = append(model, value)
+			case 1:
+				popped := s.Pop()
+
+				if len(model) == 0 {
+					if popped != nil {
+						t.Fatalf("popped %v from an empty stack", popped)
+					}
+					continue
+				}
+
+				if popped != model[len(model)-1] {
+					t.Fatalf("expected %v, popped %v", model[len(model)-1], popped)
+				}
+
+				model = model[:len(model)-1]
+			case 2:
+				if len(model) > 0 && s.Peek() != model[len(model)-1] {
+					t.Fatalf("expected %v, peeked %v", model[len(model)-1], s.Peek())
+				}
+			case 3:
+				if value%8 == 0 {
+					s.Clear()
+					model = nil
+				}
+			}
+
+			if s.Len() != len(model) || s.AtomicLen() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), s.Len())
+			}
+		}
+	})
+}
@@ -1,97 +1,84 @@
 package tree
 
 import (
-	"github.com/kucuny/gods/queue"
-	"sync"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+	"sort"
 )
 
-type ComparerType int
-
-const (
-	ComparerLarger = iota
-	ComparerSmaller
-	ComparerEqual
-)
-
-type Comparer func(source, target interface{}) ComparerType
 type Runner func(value *Node)
 
-func IntegerComparer(source, target interface{}) ComparerType {
-	if source.(int) < target.(int) {
-		return ComparerLarger
-	}
-
-	if source.(int) > target.(int) {
-		return ComparerSmaller
-	}
-
-	return ComparerEqual
+type Node struct {
+	left, right *Node
+	gen         uint64
+	Value       interface{}
 }
 
-func Integer64Comparer(source, target interface{}) ComparerType {
-	if source.(int64) < target.(int64) {
-		return ComparerLarger
-	}
-
-	if source.(int64) > target.(int64) {
-		return ComparerSmaller
-	}
-
-	return ComparerEqual
+func NewNode(value interface{}) *Node {
+	return &Node{Value: value, left: nil, right: nil}
 }
 
-func Float32Comparer(source, target interface{}) ComparerType {
-	if source.(float32) < target.(float32) {
-		return ComparerLarger
-	}
+func (n *Node) Left() *Node {
+	return n.left
+}
 
-	if source.(float32) > target.(float32) {
-		return ComparerSmaller
-	}
+func (n *Node) Right() *Node {
+	return n.right
+}
 
-	return ComparerEqual
+func (n *Node) GetValue() interface{} {
+	return n.Value
 }
 
-func Float64Comparer(source, target interface{}) ComparerType {
-	if source.(float64) < target.(float64) {
-		return ComparerLarger
-	}
+func (n *Node) Children() (BinaryNode, BinaryNode) {
+	return binaryNode(n.left), binaryNode(n.right)
+}
 
-	if source.(float64) > target.(float64) {
-		return ComparerSmaller
+func binaryNode(node *Node) BinaryNode {
+	if node == nil {
+		return nil
 	}
 
-	return ComparerEqual
+	return node
 }
 
-type Node struct {
-	left, right *Node
-	Value       interface{}
-}
+var errNoComparer = errors.New("tree: cannot decode into a tree without a comparer")
 
-func NewNode(value interface{}) *Node {
-	return &Node{Value: value, left: nil, right: nil}
-}
+var (
+	_ gods.Set     = (*BinarySearchTree)(nil)
+	_ gods.Ordered = (*BinarySearchTree)(nil)
+)
 
 type BinarySearchTree struct {
 	root     *Node
 	count    int
-	comparer Comparer
-	mutex    *sync.Mutex
+	comparer CompareFunc
+	mutex    gods.Locker
+	element  gods.Decoder
+	codec    gods.Codec
+	gen      uint64
 }
 
-func NewBinarySearchTree(comparer Comparer) *BinarySearchTree {
+func NewBinarySearchTree(comparer Comparer, options ...gods.Option) *BinarySearchTree {
+	opts := gods.NewOptions(options...)
+	_, codec := opts.Codecs()
+
 	return &BinarySearchTree{
 		root:     nil,
 		count:    0,
-		comparer: comparer,
-		mutex:    new(sync.Mutex),
+		comparer: OrderOf(comparer, opts),
+		mutex:    opts.Locker(),
+		element:  opts.ElementDecoder(),
+		codec:    codec,
 	}
 }
 
 func (b *BinarySearchTree) Len() int {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
 
 	return b.count
 }
@@ -100,41 +87,178 @@ func (b *BinarySearchTree) Insert(value interface{}) bool {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	var isSuccess bool = false
-
-	if b.root == nil {
-		b.root = NewNode(value)
-		isSuccess = true
-	} else {
-		node := b.insert(b.root, value)
-
-		if node != nil {
-			isSuccess = true
-		} else {
-			isSuccess = false
-		}
-	}
+	root, inserted := b.insert(b.root, value)
 
-	if isSuccess {
+	if inserted {
+		b.root = root
 		b.count++
 	}
 
-	return isSuccess
+	return inserted
 }
 
 func (b *BinarySearchTree) Remove(value interface{}) *Node {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	b.remove(b.root, value)
-	b.count--
-	return nil
+	var removed *Node
+
+	b.root, removed = b.remove(b.root, value)
+
+	if removed != nil {
+		b.count--
+	}
+
+	return removed
 }
 
-func (b *BinarySearchTree) Search(value interface{}) *Node {
+func (b *BinarySearchTree) Delete(value interface{}) bool {
+	return b.Remove(value) != nil
+}
+
+func (b *BinarySearchTree) Contains(value interface{}) bool {
+	return b.Search(value) != nil
+}
+
+func (b *BinarySearchTree) IsEmpty() bool {
+	return b.Len() == 0
+}
+
+func (b *BinarySearchTree) Clear() {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
+	b.root = nil
+	b.count = 0
+}
+
+func (b *BinarySearchTree) Values() []interface{} {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	values := make([]interface{}, 0, b.count)
+
+	inOrder(b.root, func(node *Node) {
+		values = append(values, node.Value)
+	})
+
+	return values
+}
+
+func (b *BinarySearchTree) Each(fn func(value interface{}) bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	eachNode(b.root, fn)
+}
+
+func (b *BinarySearchTree) First() (interface{}, bool) {
+	if node := b.Min(); node != nil {
+		return node.Value, true
+	}
+
+	return nil, false
+}
+
+func (b *BinarySearchTree) Last() (interface{}, bool) {
+	if node := b.Max(); node != nil {
+		return node.Value, true
+	}
+
+	return nil, false
+}
+
+func (b *BinarySearchTree) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "BinarySearchTree", b.Len(), b.Each)
+}
+
+func (b *BinarySearchTree) String() string {
+	return fmt.Sprint(b)
+}
+
+func (b *BinarySearchTree) WriteDot(w io.Writer, options ...DotOption) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return WriteDot(w, binaryNode(b.root), options...)
+}
+
+func (b *BinarySearchTree) ASCII() string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return ASCII(binaryNode(b.root))
+}
+
+// MarshalJSON encodes the tree as an array in sorted order.
+func (b *BinarySearchTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Values())
+}
+
+// UnmarshalJSON replaces the contents of the tree with a balanced tree built
+// from the decoded values. Duplicates are dropped. The tree must have been
+// created with NewBinarySearchTree so that it has a comparer.
+func (b *BinarySearchTree) UnmarshalJSON(data []byte) error {
+	if b.comparer == nil {
+		return errNoComparer
+	}
+
+	values, err := gods.UnmarshalArray(data, b.element)
+
+	if err != nil {
+		return err
+	}
+
+	b.replace(values)
+
+	return nil
+}
+
+// WriteTo streams the values in sorted order.
+func (b *BinarySearchTree) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindSet, b.codec, b.Each)
+}
+
+// ReadFrom replaces the contents of the tree with a balanced tree built from
+// a stream written by WriteTo.
+func (b *BinarySearchTree) ReadFrom(r io.Reader) (int64, error) {
+	if b.comparer == nil {
+		return 0, errNoComparer
+	}
+
+	var values []interface{}
+
+	n, err := gods.ReadValues(r, gods.KindSet, b.codec, func(value interface{}) {
+		values = append(values, value)
+	})
+
+	if err == nil {
+		b.replace(values)
+	}
+
+	return n, err
+}
+
+func (b *BinarySearchTree) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(b)
+}
+
+func (b *BinarySearchTree) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(b, data)
+}
+
+func (b *BinarySearchTree) GobEncode() ([]byte, error) {
+	return b.MarshalBinary()
+}
+
+func (b *BinarySearchTree) GobDecode(data []byte) error {
+	return b.UnmarshalBinary(data)
+}
+
+func (b *BinarySearchTree) Search(value interface{}) *Node {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
 	if b.root == nil {
 		return nil
 	}
@@ -143,281 +267,301 @@ func (b *BinarySearchTree) Search(value interface{}) *Node {
 }
 
 func (b *BinarySearchTree) TraversePreOrder(runner Runner) {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-	b.preOrder(b.root, runner)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	preOrder(b.root, runner)
 }
 
 func (b *BinarySearchTree) TraversePreOrderResult(runner Runner, resultChan chan interface{}) {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-	b.preOrderResult(b.root, runner, resultChan)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	preOrderResult(b.root, runner, resultChan)
 	close(resultChan)
 }
 
 func (b *BinarySearchTree) TraversePostOrder(runner Runner) {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-	b.postOrder(b.root, runner)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	postOrder(b.root, runner)
 }
 
 func (b *BinarySearchTree) TraversePostOrderResult(runner Runner, resultChan chan interface{}) {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-	b.postOrderResult(b.root, runner, resultChan)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	postOrderResult(b.root, runner, resultChan)
 	close(resultChan)
 }
 
 func (b *BinarySearchTree) TraverseInOrder(runner Runner) {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-	b.inOrder(b.root, runner)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	inOrder(b.root, runner)
 }
 
 func (b *BinarySearchTree) TraverseInOrderResult(runner Runner, resultChan chan interface{}) {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-	b.inOrderResult(b.root, runner, resultChan)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	inOrderResult(b.root, runner, resultChan)
 	close(resultChan)
 }
 
 func (b *BinarySearchTree) TraverseLevelOrder(runner Runner) {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-	b.levelOrder(b.root, runner)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	levelOrder(b.root, runner)
 }
 
 func (b *BinarySearchTree) TraverseLevelOrderResult(runner Runner, resultChan chan interface{}) {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-	b.levelOrderResult(b.root, runner, resultChan)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	levelOrderResult(b.root, runner, resultChan)
 	close(resultChan)
 }
 
 func (b *BinarySearchTree) Min() *Node {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
 
 	if b.root == nil {
 		return nil
 	}
 
-	return b.min(b.root)
+	return minNode(b.root)
 }
 
 func (b *BinarySearchTree) Max() *Node {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
 
 	if b.root == nil {
 		return nil
 	}
 
-	return b.max(b.root)
+	return maxNode(b.root)
 }
 
 func (b *BinarySearchTree) FindMin(node *Node) *Node {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
 
 	if b.root == nil {
 		return nil
 	}
 
-	return b.min(node)
+	return minNode(node)
 }
 
 func (b *BinarySearchTree) FindMax(node *Node) *Node {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
 
 	if b.root == nil {
 		return nil
 	}
 
-	return b.max(node)
+	return maxNode(node)
 }
 
-func (b *BinarySearchTree) insert(node *Node, value interface{}) *Node {
-	if node == nil {
-		return NewNode(value)
+func (b *BinarySearchTree) Validate() error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	count, err := b.validate(b.root, nil, nil, make(map[*Node]bool))
+
+	if err != nil {
+		return err
 	}
 
-	if b.comparer(node.Value, value) == ComparerSmaller {
-		node.left = b.insert(node.left, value)
-		return node
-	} else if b.comparer(node.Value, value) == ComparerLarger {
-		node.right = b.insert(node.right, value)
-		return node
-	} else {
-		return nil
+	if count != b.count {
+		return fmt.Errorf("tree: count is %d but %d nodes are reachable", b.count, count)
 	}
+
+	return nil
 }
 
-func (b *BinarySearchTree) remove(node *Node, removeValue interface{}) {
-	if node == nil {
-		return
-	}
-
-	if b.comparer(node.Value, removeValue) == ComparerSmaller {
-		b.remove(node.left, removeValue)
-	} else if b.comparer(node.Value, removeValue) == ComparerLarger {
-		b.remove(node.right, removeValue)
-	} else if b.comparer(node.Value, removeValue) == ComparerEqual {
-		if node.left == nil && node.right == nil {
-			node = nil
-		} else if node.left != nil {
-			*node = *node.left
-		} else if node.right != nil {
-			*node = *node.right
-		} else {
-			node = nil
+func (b *BinarySearchTree) replace(values []interface{}) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return b.comparer.Compare(values[i], values[j]) < 0
+	})
+
+	unique := values[:0]
+
+	for _, value := range values {
+		if len(unique) == 0 || b.comparer.Compare(unique[len(unique)-1], value) != 0 {
+			unique = append(unique, value)
 		}
 	}
 
-	return
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.root = b.build(unique)
+	b.count = len(unique)
 }
 
-func (b *BinarySearchTree) search(node *Node, value interface{}) *Node {
-	if node == nil {
+func (b *BinarySearchTree) build(values []interface{}) *Node {
+	if len(values) == 0 {
 		return nil
 	}
 
-	if node.Value == value {
-		return node
-	}
+	middle := len(values) / 2
+	node := b.newNode(values[middle])
+	node.left = b.build(values[:middle])
+	node.right = b.build(values[middle+1:])
 
-	if b.comparer(node.Value, value) == ComparerSmaller {
-		return b.search(node.left, value)
-	} else if b.comparer(node.Value, value) == ComparerLarger {
-		return b.search(node.right, value)
-	} else {
-		return node
-	}
+	return node
 }
 
-func (b *BinarySearchTree) preOrder(node *Node, runner Runner) {
+func (b *BinarySearchTree) insert(node *Node, value interface{}) (*Node, bool) {
 	if node == nil {
-		return
+		return b.newNode(value), true
 	}
 
-	runner(node)
-	b.preOrder(node.left, runner)
-	b.preOrder(node.right, runner)
-}
+	switch result := b.comparer.Compare(value, node.Value); {
+	case result < 0:
+		left, inserted := b.insert(node.left, value)
 
-func (b *BinarySearchTree) preOrderResult(node *Node, runner Runner, resultChan chan interface{}) {
-	if node == nil {
-		return
-	}
+		if inserted {
+			node = b.mutable(node)
+			node.left = left
+		}
 
-	resultChan <- node.Value
-	runner(node)
-	b.preOrderResult(node.left, runner, resultChan)
-	b.preOrderResult(node.right, runner, resultChan)
-}
+		return node, inserted
+	case result > 0:
+		right, inserted := b.insert(node.right, value)
 
-func (b *BinarySearchTree) postOrder(node *Node, runner Runner) {
-	if node == nil {
-		return
-	}
+		if inserted {
+			node = b.mutable(node)
+			node.right = right
+		}
 
-	b.postOrder(node.left, runner)
-	b.postOrder(node.right, runner)
-	runner(node)
+		return node, inserted
+	default:
+		return node, false
+	}
 }
 
-func (b *BinarySearchTree) postOrderResult(node *Node, runner Runner, resultChan chan interface{}) {
+func (b *BinarySearchTree) remove(node *Node, removeValue interface{}) (*Node, *Node) {
 	if node == nil {
-		return
+		return nil, nil
 	}
 
-	b.postOrderResult(node.left, runner, resultChan)
-	b.postOrderResult(node.right, runner, resultChan)
-	resultChan <- node.Value
-	runner(node)
-}
+	if result := b.comparer.Compare(removeValue, node.Value); result < 0 {
+		left, removed := b.remove(node.left, removeValue)
 
-func (b *BinarySearchTree) inOrder(node *Node, runner Runner) {
-	if node == nil {
-		return
+		if removed != nil {
+			node = b.mutable(node)
+			node.left = left
+		}
+
+		return node, removed
+	} else if result > 0 {
+		right, removed := b.remove(node.right, removeValue)
+
+		if removed != nil {
+			node = b.mutable(node)
+			node.right = right
+		}
+
+		return node, removed
 	}
 
-	b.inOrder(node.left, runner)
-	runner(node)
-	b.inOrder(node.right, runner)
-}
+	replacement := node.left
 
-func (b *BinarySearchTree) inOrderResult(node *Node, runner Runner, resultChan chan interface{}) {
-	if node == nil {
-		return
+	if node.left == nil {
+		replacement = node.right
+	} else if node.right != nil {
+		var right *Node
+
+		right, replacement = b.removeMin(node.right)
+		replacement = b.mutable(replacement)
+		replacement.left, replacement.right = node.left, right
 	}
 
-	b.inOrderResult(node.left, runner, resultChan)
-	resultChan <- node.Value
-	runner(node)
-	b.inOrderResult(node.right, runner, resultChan)
+	return replacement, b.detach(node)
 }
 
-func (b *BinarySearchTree) levelOrder(node *Node, runner Runner) {
-	if node == nil {
-		return
+func (b *BinarySearchTree) removeMin(node *Node) (*Node, *Node) {
+	if node.left == nil {
+		return node.right, node
 	}
 
-	q := queue.NewQueue()
-	q.Push(node)
+	left, min := b.removeMin(node.left)
+	node = b.mutable(node)
+	node.left = left
 
-	for q.Len() > 0 {
-		n := (*Node)(q.Pop().(*Node))
+	return node, min
+}
 
-		runner(n)
+func (b *BinarySearchTree) newNode(value interface{}) *Node {
+	return &Node{Value: value, gen: b.gen}
+}
 
-		if n.left != nil {
-			q.Push(n.left)
-		}
+// mutable returns node itself when it was created since the last snapshot
+// and a copy otherwise, so snapshots never see later writes.
+func (b *BinarySearchTree) mutable(node *Node) *Node {
+	if node.gen == b.gen {
+		return node
+	}
 
-		if n.right != nil {
-			q.Push(n.right)
-		}
+	return &Node{left: node.left, right: node.right, Value: node.Value, gen: b.gen}
+}
+
+func (b *BinarySearchTree) detach(node *Node) *Node {
+	if node.gen != b.gen {
+		return NewNode(node.Value)
 	}
+
+	node.left, node.right = nil, nil
+
+	return node
 }
 
-func (b *BinarySearchTree) levelOrderResult(node *Node, runner Runner, resultChan chan interface{}) {
+func (b *BinarySearchTree) validate(node, lower, upper *Node, visited map[*Node]bool) (int, error) {
 	if node == nil {
-		return
+		return 0, nil
 	}
 
-	q := queue.NewQueue()
-	q.Push(node)
+	if visited[node] {
+		return 0, fmt.Errorf("tree: cycle detected at %v", node.Value)
+	}
 
-	for q.Len() > 0 {
-		n := (*Node)(q.Pop().(*Node))
+	visited[node] = true
 
-		resultChan <- n.Value
-		runner(n)
+	if lower != nil && b.comparer.Compare(lower.Value, node.Value) >= 0 {
+		return 0, fmt.Errorf("tree: %v is not greater than its ancestor %v", node.Value, lower.Value)
+	}
 
-		if n.left != nil {
-			q.Push(n.left)
-		}
+	if upper != nil && b.comparer.Compare(upper.Value, node.Value) <= 0 {
+		return 0, fmt.Errorf("tree: %v is not less than its ancestor %v", node.Value, upper.Value)
+	}
 
-		if n.right != nil {
-			q.Push(n.right)
-		}
+	left, err := b.validate(node.left, lower, node, visited)
+
+	if err != nil {
+		return 0, err
 	}
-}
 
-func (b *BinarySearchTree) min(node *Node) *Node {
-	if node.left == nil {
-		return node
+	right, err := b.validate(node.right, node, upper, visited)
+
+	if err != nil {
+		return 0, err
 	}
 
-	return b.min(node.left)
+	return left + right + 1, nil
 }
 
-func (b *BinarySearchTree) max(node *Node) *Node {
-	if node.right == nil {
-		return node
+func (b *BinarySearchTree) search(node *Node, value interface{}) *Node {
+	if node == nil {
+		return nil
 	}
 
-	return b.max(node.right)
+	switch result := b.comparer.Compare(value, node.Value); {
+	case result < 0:
+		return b.search(node.left, value)
+	case result > 0:
+		return b.search(node.right, value)
+	default:
+		return node
+	}
 }
@@ -1,9 +1,16 @@
 package tree
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
+	"github.com/kucuny/gods/queue"
 	"github.com/stretchr/testify/suite"
+	"sync"
 	"testing"
-	"fmt"
 )
 
 type BinarySearchTreeTestSuite struct {
@@ -262,6 +269,240 @@ func (suite *BinarySearchTreeTestSuite) TestBianrySearchTreeIntRemoveNodeIsSucce
 	suite.bstInt.TraverseInOrder(runner)
 }
 
+func (suite *BinarySearchTreeTestSuite) TestBianrySearchTreeIntConcurrentReaders() {
+	for i := 0; i < 100; i++ {
+		suite.bstInt.Insert(i)
+	}
+
+	var wg sync.WaitGroup
+
+	for r := 0; r < 8; r++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				suite.Equal(i, suite.bstInt.Search(i).Value)
+			}
+
+			suite.Equal(0, suite.bstInt.Min().Value)
+			suite.Equal(99, suite.bstInt.Max().Value)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (suite *BinarySearchTreeTestSuite) TestBianrySearchTreeIntInsertDuplicate() {
+	suite.True(suite.bstInt.Insert(10))
+	suite.True(suite.bstInt.Insert(5))
+	suite.True(suite.bstInt.Insert(3))
+	suite.False(suite.bstInt.Insert(5))
+	suite.False(suite.bstInt.Insert(3))
+
+	suite.Equal(3, suite.bstInt.Len())
+	suite.Equal([]interface{}{3, 5, 10}, suite.bstInt.Values())
+}
+
+func (suite *BinarySearchTreeTestSuite) TestBianrySearchTreeIntRemoveNodeWithTwoChildren() {
+	for _, value := range []int{10, 5, 15, 3, 7, 12, 20, 6} {
+		suite.bstInt.Insert(value)
+	}
+
+	removed := suite.bstInt.Remove(5)
+	suite.Equal(5, removed.Value)
+	suite.Equal(7, suite.bstInt.Len())
+	suite.Equal([]interface{}{3, 6, 7, 10, 12, 15, 20}, suite.bstInt.Values())
+
+	suite.Nil(suite.bstInt.Remove(5))
+	suite.Equal(7, suite.bstInt.Len())
+
+	suite.True(suite.bstInt.Delete(10))
+	suite.Equal([]interface{}{3, 6, 7, 12, 15, 20}, suite.bstInt.Values())
+	suite.Equal(12, suite.bstInt.root.Value)
+}
+
+func (suite *BinarySearchTreeTestSuite) TestBianrySearchTreeIntContainer() {
+	suite.True(suite.bstInt.IsEmpty())
+
+	first, ok := suite.bstInt.First()
+	suite.False(ok)
+	suite.Nil(first)
+
+	for _, value := range []int{4, 2, 6, 1, 3} {
+		suite.bstInt.Insert(value)
+	}
+
+	suite.False(suite.bstInt.IsEmpty())
+	suite.True(suite.bstInt.Contains(3))
+	suite.False(suite.bstInt.Contains(5))
+
+	first, _ = suite.bstInt.First()
+	last, _ := suite.bstInt.Last()
+	suite.Equal(1, first)
+	suite.Equal(6, last)
+
+	var visited []interface{}
+	suite.bstInt.Each(func(value interface{}) bool {
+		visited = append(visited, value)
+		return len(visited) < 3
+	})
+	suite.Equal([]interface{}{1, 2, 3}, visited)
+
+	suite.bstInt.Clear()
+	suite.True(suite.bstInt.IsEmpty())
+	suite.Nil(suite.bstInt.Min())
+}
+
+func (suite *BinarySearchTreeTestSuite) TestBianrySearchTreeIntValidate() {
+	suite.Nil(suite.bstInt.Validate())
+
+	for _, value := range []int{10, 5, 15} {
+		suite.bstInt.Insert(value)
+	}
+
+	suite.Nil(suite.bstInt.Validate())
+
+	suite.bstInt.root.left.Value = 20
+	suite.Error(suite.bstInt.Validate())
+
+	suite.bstInt.root.left.Value = 5
+	suite.bstInt.count++
+	suite.Error(suite.bstInt.Validate())
+
+	suite.bstInt.count--
+	suite.bstInt.root.left.right = suite.bstInt.root.left
+	suite.Error(suite.bstInt.Validate())
+}
+
+func (suite *BinarySearchTreeTestSuite) TestBinarySearchTreeJSON() {
+	b := NewBinarySearchTree(IntegerComparer)
+
+	for _, value := range []int{5, 1, 4, 2, 3} {
+		b.Insert(value)
+	}
+
+	data, err := json.Marshal(b)
+	suite.NoError(err)
+	suite.JSONEq(`[1,2,3,4,5]`, string(data))
+
+	decoded := NewBinarySearchTree(IntegerComparer, gods.WithElement[int]())
+	suite.NoError(json.Unmarshal([]byte(`[7,1,6,2,5,3,4,4,1]`), decoded))
+	suite.Equal([]interface{}{1, 2, 3, 4, 5, 6, 7}, decoded.Values())
+	suite.Equal(7, decoded.Len())
+	suite.Equal(4, decoded.root.Value)
+	suite.NoError(decoded.Validate())
+
+	var preOrder []interface{}
+	decoded.TraversePreOrder(func(node *Node) { preOrder = append(preOrder, node.Value) })
+	suite.Equal([]interface{}{4, 2, 1, 3, 6, 5, 7}, preOrder)
+
+	var zero BinarySearchTree
+	suite.Error(json.Unmarshal(data, &zero))
+}
+
+func (suite *BinarySearchTreeTestSuite) TestBinarySearchTreeBinary() {
+	b := NewBinarySearchTree(IntegerComparer, gods.WithCodec(gods.IntCodec))
+
+	for i := 1; i <= 15; i++ {
+		b.Insert(i)
+	}
+
+	var buffer bytes.Buffer
+
+	_, err := b.WriteTo(&buffer)
+	suite.NoError(err)
+
+	decoded := NewBinarySearchTree(IntegerComparer, gods.WithCodec(gods.IntCodec))
+	_, err = decoded.ReadFrom(&buffer)
+	suite.NoError(err)
+	suite.Equal(b.Values(), decoded.Values())
+	suite.Equal(8, decoded.root.Value)
+	suite.NoError(decoded.Validate())
+
+	var zero BinarySearchTree
+	_, err = zero.ReadFrom(&buffer)
+	suite.Error(err)
+
+	strings := NewBinarySearchTree(StringComparer)
+	strings.Insert("b")
+	strings.Insert("a")
+
+	suite.NoError(gob.NewEncoder(&buffer).Encode(strings))
+
+	decoded = NewBinarySearchTree(StringComparer)
+	suite.NoError(gob.NewDecoder(&buffer).Decode(decoded))
+	suite.Equal([]interface{}{"a", "b"}, decoded.Values())
+
+	data, err := strings.MarshalBinary()
+	suite.NoError(err)
+	suite.ErrorIs(queue.NewQueue().UnmarshalBinary(data), gods.ErrKindMismatch)
+}
+
+func (suite *BinarySearchTreeTestSuite) TestBinarySearchTreeFormat() {
+	b := NewBinarySearchTree(IntegerComparer)
+	b.Insert(2)
+	b.Insert(1)
+	b.Insert(3)
+
+	suite.Equal("BinarySearchTree[1 2 3]", b.String())
+	suite.Equal("BinarySearchTree[1 ...2 more]", fmt.Sprintf("%.1v", b))
+	suite.Equal(" 2\n/ \\\n1 3\n", b.ASCII())
+}
+
 func TestBinarySearchTreeTestSuite(t *testing.T) {
 	suite.Run(t, new(BinarySearchTreeTestSuite))
 }
+
+func TestBinarySearchTreeConformance(t *testing.T) {
+	suite.Run(t, &godstest.SetSuite{
+		New: func() gods.Set { return NewBinarySearchTree(IntegerComparer) },
+	})
+}
+
+func FuzzBinarySearchTree(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 5, 0, 15, 0, 3, 0, 7, 1, 5, 1, 10, 2, 7})
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 1, 1, 1, 2, 1, 3, 3, 0})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		b := NewBinarySearchTree(IntegerComparer)
+		model := make(map[int]bool)
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 4 {
+			case 0:
+				if b.Insert(value) == model[value] {
+					t.Fatalf("insert %d disagrees with model", value)
+				}
+
+				model[value] = true
+			case 1:
+				if (b.Remove(value) != nil) != model[value] {
+					t.Fatalf("remove %d disagrees with model", value)
+				}
+
+				delete(model, value)
+			case 2:
+				if b.Contains(value) != model[value] {
+					t.Fatalf("contains %d disagrees with model", value)
+				}
+			case 3:
+				if value%8 == 0 {
+					b.Clear()
+					model = make(map[int]bool)
+				}
+			}
+
+			if err := b.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if b.Len() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), b.Len())
+			}
+		}
+	})
+}
@@ -0,0 +1,870 @@
+package tree
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+	"sort"
+)
+
+// DefaultBTreeDegree is used by NewBTree when the requested minimum degree
+// is below 2.
+const DefaultBTreeDegree = 32
+
+var errLoadLength = errors.New("tree: Load needs one value per key")
+
+type bTreeItem struct {
+	key, value interface{}
+}
+
+// bTreeOwner marks the nodes a tree may modify in place. It is not zero
+// sized so that every allocation has a distinct address.
+type bTreeOwner struct {
+	_ byte
+}
+
+type bTreeNode struct {
+	items    []bTreeItem
+	children []*bTreeNode
+	owner    *bTreeOwner
+}
+
+func (n *bTreeNode) leaf() bool {
+	return len(n.children) == 0
+}
+
+var (
+	_ gods.Map     = (*BTree)(nil)
+	_ gods.Ordered = (*BTree)(nil)
+)
+
+// BTree is an ordered map stored in a B-tree of minimum degree t: every
+// node but the root holds between t-1 and 2t-1 entries in one contiguous
+// slice, which keeps lookups cache friendly and the number of heap objects
+// low. Clone is constant time; the two trees share nodes until either one
+// writes to them.
+type BTree struct {
+	root     *bTreeNode
+	degree   int
+	count    int
+	comparer CompareFunc
+	owner    *bTreeOwner
+	lock     gods.LockMode
+	mutex    gods.Locker
+	key      gods.KeyDecoder
+	element  gods.Decoder
+	keyCodec gods.Codec
+	codec    gods.Codec
+}
+
+func NewBTree(comparer Comparer, degree int, options ...gods.Option) *BTree {
+	opts := gods.NewOptions(options...)
+	keyCodec, codec := opts.Codecs()
+
+	if degree < 2 {
+		degree = DefaultBTreeDegree
+	}
+
+	return &BTree{
+		root:     nil,
+		degree:   degree,
+		count:    0,
+		comparer: OrderOf(comparer, opts),
+		owner:    &bTreeOwner{},
+		lock:     opts.Lock,
+		mutex:    opts.Locker(),
+		key:      opts.KeyDecoder(),
+		element:  opts.ElementDecoder(),
+		keyCodec: keyCodec,
+		codec:    codec,
+	}
+}
+
+func (t *BTree) Degree() int {
+	return t.degree
+}
+
+func (t *BTree) Len() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.count
+}
+
+func (t *BTree) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+func (t *BTree) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.root = nil
+	t.count = 0
+}
+
+// Insert sets key to value and reports whether the key is new.
+func (t *BTree) Insert(key, value interface{}) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.root == nil {
+		t.root = t.newNode()
+	}
+
+	t.root = t.mutable(t.root)
+
+	if len(t.root.items) == t.maxItems() {
+		root := t.newNode()
+		root.children = append(root.children, t.root)
+		t.split(root, 0)
+		t.root = root
+	}
+
+	inserted := t.insert(t.root, key, value)
+
+	if inserted {
+		t.count++
+	}
+
+	return inserted
+}
+
+func (t *BTree) Get(key interface{}) (interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if item := t.search(key); item != nil {
+		return item.value, true
+	}
+
+	return nil, false
+}
+
+func (t *BTree) Contains(key interface{}) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+func (t *BTree) Delete(key interface{}) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// Deleting restructures nodes on the way down, so make sure the key is
+	// present before copying anything shared with a clone.
+	if t.search(key) == nil {
+		return false
+	}
+
+	t.root = t.mutable(t.root)
+	t.remove(t.root, key)
+
+	if len(t.root.items) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+
+	t.count--
+
+	return true
+}
+
+func (t *BTree) Keys() []interface{} {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	keys := make([]interface{}, 0, t.count)
+
+	t.walk(t.root, nil, nil, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys
+}
+
+func (t *BTree) Values() []interface{} {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	values := make([]interface{}, 0, t.count)
+
+	t.walk(t.root, nil, nil, func(key, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (t *BTree) Each(fn func(key interface{}) bool) {
+	t.Range(nil, nil, func(key, value interface{}) bool {
+		return fn(key)
+	})
+}
+
+func (t *BTree) First() (interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.root == nil {
+		return nil, false
+	}
+
+	node := t.root
+
+	for !node.leaf() {
+		node = node.children[0]
+	}
+
+	return node.items[0].key, true
+}
+
+func (t *BTree) Last() (interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.root == nil {
+		return nil, false
+	}
+
+	node := t.root
+
+	for !node.leaf() {
+		node = node.children[len(node.children)-1]
+	}
+
+	return node.items[len(node.items)-1].key, true
+}
+
+// Range calls fn in key order for each entry with from <= key < to until fn
+// returns false. A nil bound is unbounded. fn must not modify the tree.
+func (t *BTree) Range(from, to interface{}, fn func(key, value interface{}) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	t.walk(t.root, from, to, fn)
+}
+
+// Load replaces the contents of the tree with the given entries, building
+// it bottom up with nearly full nodes. The keys need not be sorted; for
+// duplicate keys the last value wins. values may be nil for set semantics.
+func (t *BTree) Load(keys, values []interface{}) error {
+	if values != nil && len(values) != len(keys) {
+		return errLoadLength
+	}
+
+	items := make([]bTreeItem, len(keys))
+
+	for i, key := range keys {
+		items[i].key = key
+
+		if values != nil {
+			items[i].value = values[i]
+		}
+	}
+
+	t.load(items)
+
+	return nil
+}
+
+// Clone returns a tree with the same contents and options in constant time.
+// Nodes are shared and copied lazily by whichever tree writes to them
+// first.
+func (t *BTree) Clone() *BTree {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.owner = &bTreeOwner{}
+
+	return &BTree{
+		root:     t.root,
+		degree:   t.degree,
+		count:    t.count,
+		comparer: t.comparer,
+		owner:    &bTreeOwner{},
+		lock:     t.lock,
+		mutex:    gods.NewLocker(t.lock),
+		key:      t.key,
+		element:  t.element,
+		keyCodec: t.keyCodec,
+		codec:    t.codec,
+	}
+}
+
+// Height returns the number of levels, 0 for an empty tree.
+func (t *BTree) Height() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	height := 0
+
+	for node := t.root; node != nil; height++ {
+		if node.leaf() {
+			node = nil
+		} else {
+			node = node.children[0]
+		}
+	}
+
+	return height
+}
+
+func (t *BTree) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "BTree", t.Len(), func(fn func(key, value interface{}) bool) {
+		t.Range(nil, nil, fn)
+	})
+}
+
+func (t *BTree) String() string {
+	return fmt.Sprint(t)
+}
+
+// MarshalJSON encodes the tree as an object with keys in sorted order.
+func (t *BTree) MarshalJSON() ([]byte, error) {
+	keys := make([]interface{}, 0, t.Len())
+	values := make([]interface{}, 0, t.Len())
+
+	t.Range(nil, nil, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+
+	return gods.MarshalObject(keys, values)
+}
+
+// UnmarshalJSON replaces the contents of the tree. The tree must have been
+// created with NewBTree so that it has a comparer.
+func (t *BTree) UnmarshalJSON(data []byte) error {
+	if t.comparer == nil {
+		return errNoComparer
+	}
+
+	keys, values, err := gods.UnmarshalObject(data, t.key, t.element)
+
+	if err != nil {
+		return err
+	}
+
+	return t.Load(keys, values)
+}
+
+// WriteTo streams the entries in key order.
+func (t *BTree) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteEntries(w, gods.KindMap, t.keyCodec, t.codec, func(fn func(key, value interface{}) bool) {
+		t.Range(nil, nil, fn)
+	})
+}
+
+// ReadFrom replaces the contents of the tree with a stream written by
+// WriteTo. The tree is left unchanged if the stream is invalid.
+func (t *BTree) ReadFrom(r io.Reader) (int64, error) {
+	if t.comparer == nil {
+		return 0, errNoComparer
+	}
+
+	var items []bTreeItem
+
+	n, err := gods.ReadEntries(r, gods.KindMap, t.keyCodec, t.codec, func(key, value interface{}) {
+		items = append(items, bTreeItem{key: key, value: value})
+	})
+
+	if err == nil {
+		t.load(items)
+	}
+
+	return n, err
+}
+
+func (t *BTree) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(t)
+}
+
+func (t *BTree) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(t, data)
+}
+
+func (t *BTree) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+func (t *BTree) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
+
+// Validate checks the ordering, node sizes, uniform leaf depth and count.
+func (t *BTree) Validate() error {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.root == nil {
+		if t.count != 0 {
+			return fmt.Errorf("tree: count is %d but the tree is empty", t.count)
+		}
+
+		return nil
+	}
+
+	count, _, err := t.validate(t.root, nil, nil, true)
+
+	if err != nil {
+		return err
+	}
+
+	if count != t.count {
+		return fmt.Errorf("tree: count is %d but %d entries are reachable", t.count, count)
+	}
+
+	return nil
+}
+
+func (t *BTree) maxItems() int {
+	return 2*t.degree - 1
+}
+
+func (t *BTree) newNode() *bTreeNode {
+	return &bTreeNode{items: make([]bTreeItem, 0, t.maxItems()), owner: t.owner}
+}
+
+// mutable returns node itself when this tree owns it and a private copy
+// otherwise. Callers must store the result in the parent.
+func (t *BTree) mutable(node *bTreeNode) *bTreeNode {
+	if node.owner == t.owner {
+		return node
+	}
+
+	clone := &bTreeNode{items: make([]bTreeItem, len(node.items), t.maxItems()), owner: t.owner}
+	copy(clone.items, node.items)
+
+	if !node.leaf() {
+		clone.children = make([]*bTreeNode, len(node.children), t.maxItems()+1)
+		copy(clone.children, node.children)
+	}
+
+	return clone
+}
+
+// find returns the index of the first entry not less than key and whether
+// it equals key.
+func (t *BTree) find(node *bTreeNode, key interface{}) (int, bool) {
+	i := sort.Search(len(node.items), func(i int) bool {
+		return t.comparer.Compare(node.items[i].key, key) >= 0
+	})
+
+	return i, i < len(node.items) && t.comparer.Compare(node.items[i].key, key) == 0
+}
+
+func (t *BTree) search(key interface{}) *bTreeItem {
+	for node := t.root; node != nil; {
+		i, found := t.find(node, key)
+
+		if found {
+			return &node.items[i]
+		}
+
+		if node.leaf() {
+			return nil
+		}
+
+		node = node.children[i]
+	}
+
+	return nil
+}
+
+// insert adds the entry below node, which must be owned and not full,
+// splitting full children on the way down.
+func (t *BTree) insert(node *bTreeNode, key, value interface{}) bool {
+	for {
+		i, found := t.find(node, key)
+
+		if found {
+			node.items[i].value = value
+			return false
+		}
+
+		if node.leaf() {
+			node.items = insertAt(node.items, i, bTreeItem{key: key, value: value})
+			return true
+		}
+
+		child := t.mutable(node.children[i])
+		node.children[i] = child
+
+		if len(child.items) == t.maxItems() {
+			t.split(node, i)
+
+			switch result := t.comparer.Compare(key, node.items[i].key); {
+			case result == 0:
+				node.items[i].value = value
+				return false
+			case result > 0:
+				i++
+			}
+
+			child = node.children[i]
+		}
+
+		node = child
+	}
+}
+
+// split moves the upper half of the full, owned child i of parent into a
+// new sibling and lifts the median into parent.
+func (t *BTree) split(parent *bTreeNode, i int) {
+	child := parent.children[i]
+	middle := t.degree - 1
+
+	sibling := t.newNode()
+	sibling.items = append(sibling.items, child.items[middle+1:]...)
+
+	if !child.leaf() {
+		sibling.children = make([]*bTreeNode, 0, t.maxItems()+1)
+		sibling.children = append(sibling.children, child.children[middle+1:]...)
+		child.children = truncate(child.children, middle+1)
+	}
+
+	median := child.items[middle]
+	child.items = truncate(child.items, middle)
+
+	parent.items = insertAt(parent.items, i, median)
+	parent.children = insertAt(parent.children, i+1, sibling)
+}
+
+// remove deletes key, which must be present, below node. node must be owned
+// and, unless it is the root, hold at least degree entries.
+func (t *BTree) remove(node *bTreeNode, key interface{}) {
+	for {
+		i, found := t.find(node, key)
+
+		if node.leaf() {
+			node.items = removeAt(node.items, i)
+			return
+		}
+
+		if !found {
+			node = t.grow(node, i)
+			continue
+		}
+
+		if len(node.children[i].items) >= t.degree {
+			left := t.mutable(node.children[i])
+			node.children[i] = left
+			node.items[i] = t.removeMax(left)
+
+			return
+		}
+
+		if len(node.children[i+1].items) >= t.degree {
+			right := t.mutable(node.children[i+1])
+			node.children[i+1] = right
+			node.items[i] = t.removeMin(right)
+
+			return
+		}
+
+		t.merge(node, i)
+		node = node.children[i]
+	}
+}
+
+func (t *BTree) removeMin(node *bTreeNode) bTreeItem {
+	for !node.leaf() {
+		node = t.grow(node, 0)
+	}
+
+	item := node.items[0]
+	node.items = removeAt(node.items, 0)
+
+	return item
+}
+
+func (t *BTree) removeMax(node *bTreeNode) bTreeItem {
+	for !node.leaf() {
+		node = t.grow(node, len(node.children)-1)
+	}
+
+	item := node.items[len(node.items)-1]
+	node.items = truncate(node.items, len(node.items)-1)
+
+	return item
+}
+
+// grow returns the owned child of node to descend into for child index i,
+// first making sure it holds at least degree entries by borrowing from a
+// sibling or merging with one.
+func (t *BTree) grow(node *bTreeNode, i int) *bTreeNode {
+	child := t.mutable(node.children[i])
+	node.children[i] = child
+
+	if len(child.items) >= t.degree {
+		return child
+	}
+
+	if i > 0 && len(node.children[i-1].items) >= t.degree {
+		left := t.mutable(node.children[i-1])
+		node.children[i-1] = left
+
+		child.items = insertAt(child.items, 0, node.items[i-1])
+		node.items[i-1] = left.items[len(left.items)-1]
+		left.items = truncate(left.items, len(left.items)-1)
+
+		if !left.leaf() {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = truncate(left.children, len(left.children)-1)
+		}
+
+		return child
+	}
+
+	if i < len(node.items) && len(node.children[i+1].items) >= t.degree {
+		right := t.mutable(node.children[i+1])
+		node.children[i+1] = right
+
+		child.items = append(child.items, node.items[i])
+		node.items[i] = right.items[0]
+		right.items = removeAt(right.items, 0)
+
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+
+		return child
+	}
+
+	if i == len(node.items) {
+		i--
+	}
+
+	t.merge(node, i)
+
+	return node.children[i]
+}
+
+// merge joins child i+1 and the separating entry into child i. Both children
+// must hold degree-1 entries.
+func (t *BTree) merge(node *bTreeNode, i int) {
+	left := t.mutable(node.children[i])
+	right := node.children[i+1]
+
+	left.items = append(left.items, node.items[i])
+	left.items = append(left.items, right.items...)
+	left.children = append(left.children, right.children...)
+
+	node.children[i] = left
+	node.items = removeAt(node.items, i)
+	node.children = removeAt(node.children, i+1)
+}
+
+func (t *BTree) walk(node *bTreeNode, from, to interface{}, fn func(key, value interface{}) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	start := 0
+
+	if from != nil {
+		start, _ = t.find(node, from)
+	}
+
+	for i := start; i <= len(node.items); i++ {
+		if !node.leaf() {
+			// Only the first child visited can hold keys below from.
+			if !t.walk(node.children[i], from, to, fn) {
+				return false
+			}
+
+			from = nil
+		}
+
+		if i == len(node.items) {
+			break
+		}
+
+		if to != nil && t.comparer.Compare(node.items[i].key, to) >= 0 {
+			return false
+		}
+
+		if !fn(node.items[i].key, node.items[i].value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *BTree) load(items []bTreeItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return t.comparer.Compare(items[i].key, items[j].key) < 0
+	})
+
+	unique := items[:0]
+
+	for _, item := range items {
+		if n := len(unique); n > 0 && t.comparer.Compare(unique[n-1].key, item.key) == 0 {
+			unique[n-1].value = item.value
+		} else {
+			unique = append(unique, item)
+		}
+	}
+
+	// capacity[h] is the most entries a subtree of height h can hold.
+	capacity := []int{t.maxItems()}
+
+	for capacity[len(capacity)-1] < len(unique) {
+		capacity = append(capacity, capacity[len(capacity)-1]*2*t.degree+t.maxItems())
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.root = nil
+	t.count = len(unique)
+
+	if len(unique) > 0 {
+		t.root = t.build(unique, capacity, len(capacity)-1, true)
+	}
+}
+
+// build makes a subtree of the given height from sorted items. It splits
+// the items into as few children as the child capacity allows, but never
+// fewer than a node must have, and sizes them evenly so each child is
+// within bounds.
+func (t *BTree) build(items []bTreeItem, capacity []int, height int, root bool) *bTreeNode {
+	node := t.newNode()
+
+	if height == 0 {
+		node.items = append(node.items, items...)
+		return node
+	}
+
+	children := (len(items) + capacity[height-1] + 1) / (capacity[height-1] + 1)
+
+	if root {
+		children = max(children, 2)
+	} else {
+		children = max(children, t.degree)
+	}
+
+	node.children = make([]*bTreeNode, 0, t.maxItems()+1)
+
+	// Each child takes its share of the items plus one separator, except
+	// the last, which has no separator after it.
+	total := len(items) + 1
+	start := 0
+
+	for c := 0; c < children; c++ {
+		end := start + total/children - 1
+
+		if c < total%children {
+			end++
+		}
+
+		node.children = append(node.children, t.build(items[start:end], capacity, height-1, false))
+
+		if end < len(items) {
+			node.items = append(node.items, items[end])
+		}
+
+		start = end + 1
+	}
+
+	return node
+}
+
+func (t *BTree) validate(node *bTreeNode, lower, upper *bTreeItem, root bool) (int, int, error) {
+	if n := len(node.items); n > t.maxItems() || (!root && n < t.degree-1) || (root && n == 0) {
+		return 0, 0, fmt.Errorf("tree: node with %d entries violates degree %d", n, t.degree)
+	}
+
+	if !node.leaf() && len(node.children) != len(node.items)+1 {
+		return 0, 0, fmt.Errorf("tree: node with %d entries has %d children", len(node.items), len(node.children))
+	}
+
+	for i := range node.items {
+		item := &node.items[i]
+
+		if lower != nil && t.comparer.Compare(item.key, lower.key) <= 0 {
+			return 0, 0, fmt.Errorf("tree: %v is not greater than %v", item.key, lower.key)
+		}
+
+		lower = item
+	}
+
+	if upper != nil && t.comparer.Compare(node.items[len(node.items)-1].key, upper.key) >= 0 {
+		return 0, 0, fmt.Errorf("tree: %v is not less than %v", node.items[len(node.items)-1].key, upper.key)
+	}
+
+	count := len(node.items)
+
+	if node.leaf() {
+		return count, 1, nil
+	}
+
+	depth := -1
+
+	for i, child := range node.children {
+		var low, high *bTreeItem
+
+		if i > 0 {
+			low = &node.items[i-1]
+		}
+
+		if i < len(node.items) {
+			high = &node.items[i]
+		}
+
+		n, d, err := t.validate(child, low, high, false)
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if depth != -1 && d != depth {
+			return 0, 0, fmt.Errorf("tree: leaves at depths %d and %d", depth+1, d+1)
+		}
+
+		depth = d
+		count += n
+	}
+
+	return count, depth + 1, nil
+}
+
+func insertAt[T any](s []T, i int, value T) []T {
+	var zero T
+
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = value
+
+	return s
+}
+
+// removeAt and truncate clear the vacated slots so the backing array does
+// not keep removed entries alive.
+func removeAt[T any](s []T, i int) []T {
+	var zero T
+
+	copy(s[i:], s[i+1:])
+	s[len(s)-1] = zero
+
+	return s[:len(s)-1]
+}
+
+func truncate[T any](s []T, n int) []T {
+	var zero T
+
+	for i := n; i < len(s); i++ {
+		s[i] = zero
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,409 @@
+package tree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type BTreeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *BTreeTestSuite) tree(degree int, keys ...int) *BTree {
+	t := NewBTree(IntegerComparer, degree, gods.WithKeyCodec(gods.IntCodec), gods.WithCodec(gods.IntCodec))
+
+	for _, key := range keys {
+		t.Insert(key, key*10)
+	}
+
+	return t
+}
+
+func (suite *BTreeTestSuite) TestBTreeInsertGetDelete() {
+	t := suite.tree(2)
+
+	suite.True(t.Insert(5, "a"))
+	suite.False(t.Insert(5, "b"))
+	suite.Equal(1, t.Len())
+
+	value, ok := t.Get(5)
+	suite.True(ok)
+	suite.Equal("b", value)
+
+	suite.True(t.Delete(5))
+	suite.False(t.Delete(5))
+	suite.True(t.IsEmpty())
+	suite.Equal(0, t.Height())
+
+	_, ok = t.Get(5)
+	suite.False(ok)
+}
+
+func (suite *BTreeTestSuite) TestBTreeDegree() {
+	suite.Equal(DefaultBTreeDegree, NewBTree(IntegerComparer, 0).Degree())
+	suite.Equal(3, NewBTree(IntegerComparer, 3).Degree())
+}
+
+func (suite *BTreeTestSuite) TestBTreeRandomized() {
+	for _, degree := range []int{2, 3, 8} {
+		r := rand.New(rand.NewSource(int64(degree)))
+		t := suite.tree(degree)
+		model := make(map[int]int)
+
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(500)
+
+			if r.Intn(3) == 0 {
+				_, present := model[key]
+				suite.Equal(present, t.Delete(key))
+				delete(model, key)
+			} else {
+				_, present := model[key]
+				suite.Equal(!present, t.Insert(key, i))
+				model[key] = i
+			}
+
+			if i%250 == 0 {
+				suite.Require().NoError(t.Validate())
+			}
+		}
+
+		suite.Require().NoError(t.Validate())
+		suite.Equal(len(model), t.Len())
+
+		keys := make([]int, 0, len(model))
+
+		for key := range model {
+			keys = append(keys, key)
+		}
+
+		sort.Ints(keys)
+
+		for i, key := range t.Keys() {
+			suite.Equal(keys[i], key)
+
+			value, ok := t.Get(key)
+			suite.True(ok)
+			suite.Equal(model[keys[i]], value)
+		}
+	}
+}
+
+func (suite *BTreeTestSuite) TestBTreeOrdered() {
+	t := suite.tree(2, 5, 3, 8, 1, 9, 7)
+
+	first, ok := t.First()
+	suite.True(ok)
+	suite.Equal(1, first)
+
+	last, ok := t.Last()
+	suite.True(ok)
+	suite.Equal(9, last)
+
+	suite.Equal([]interface{}{1, 3, 5, 7, 8, 9}, t.Keys())
+	suite.Equal([]interface{}{10, 30, 50, 70, 80, 90}, t.Values())
+
+	_, ok = suite.tree(2).First()
+	suite.False(ok)
+
+	_, ok = suite.tree(2).Last()
+	suite.False(ok)
+}
+
+func (suite *BTreeTestSuite) TestBTreeRange() {
+	keys := make([]int, 100)
+
+	for i := range keys {
+		keys[i] = i * 2
+	}
+
+	t := suite.tree(3, keys...)
+
+	collect := func(from, to interface{}, limit int) []interface{} {
+		var visited []interface{}
+
+		t.Range(from, to, func(key, value interface{}) bool {
+			visited = append(visited, key)
+			return len(visited) < limit
+		})
+
+		return visited
+	}
+
+	suite.Equal([]interface{}{10, 12, 14}, collect(9, 15, 100))
+	suite.Equal([]interface{}{10, 12, 14}, collect(10, 16, 100))
+	suite.Equal([]interface{}{0, 2}, collect(nil, 3, 100))
+	suite.Equal([]interface{}{196, 198}, collect(195, nil, 100))
+	suite.Equal([]interface{}{40, 42, 44}, collect(40, nil, 3))
+	suite.Empty(collect(500, nil, 100))
+	suite.Len(collect(nil, nil, 1000), 100)
+}
+
+func (suite *BTreeTestSuite) TestBTreeLoad() {
+	for _, degree := range []int{2, 3, 5} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 9, 10, 50, 333, 1000} {
+			t := suite.tree(degree)
+			keys := make([]interface{}, n)
+
+			for i, value := range rand.New(rand.NewSource(int64(n))).Perm(n) {
+				keys[i] = value
+			}
+
+			suite.NoError(t.Load(keys, keys))
+			suite.Require().NoError(t.Validate(), "degree %d, n %d", degree, n)
+			suite.Equal(n, t.Len())
+
+			for i, key := range t.Keys() {
+				suite.Equal(i, key)
+			}
+
+			t.Insert(n, n)
+			t.Delete(0)
+			suite.NoError(t.Validate())
+		}
+	}
+
+	t := suite.tree(2, 100)
+	suite.NoError(t.Load([]interface{}{3, 1, 3, 2}, []interface{}{"a", "b", "c", "d"}))
+	suite.Equal([]interface{}{1, 2, 3}, t.Keys())
+	suite.Equal([]interface{}{"b", "d", "c"}, t.Values())
+
+	suite.NoError(t.Load([]interface{}{4}, nil))
+	suite.Equal([]interface{}{nil}, t.Values())
+
+	suite.Error(t.Load([]interface{}{1, 2}, []interface{}{1}))
+	suite.Equal([]interface{}{4}, t.Keys())
+}
+
+func (suite *BTreeTestSuite) TestBTreeLoadIsPacked() {
+	t := suite.tree(4)
+	keys := make([]interface{}, 10000)
+
+	for i := range keys {
+		keys[i] = i
+	}
+
+	suite.NoError(t.Load(keys, nil))
+
+	incremental := suite.tree(4)
+
+	for i := range keys {
+		incremental.Insert(i, nil)
+	}
+
+	suite.LessOrEqual(t.Height(), incremental.Height())
+}
+
+func (suite *BTreeTestSuite) TestBTreeClone() {
+	original := suite.tree(2)
+
+	for i := 0; i < 200; i++ {
+		original.Insert(i, i)
+	}
+
+	clone := original.Clone()
+
+	for i := 0; i < 200; i += 2 {
+		original.Delete(i)
+		clone.Insert(i, -i)
+	}
+
+	clone.Insert(500, 500)
+	original.Insert(600, 600)
+
+	suite.NoError(original.Validate())
+	suite.NoError(clone.Validate())
+	suite.Equal(101, original.Len())
+	suite.Equal(201, clone.Len())
+	suite.False(original.Contains(500))
+	suite.False(clone.Contains(600))
+
+	value, _ := clone.Get(10)
+	suite.Equal(-10, value)
+
+	value, _ = clone.Get(11)
+	suite.Equal(11, value)
+
+	again := clone.Clone()
+	again.Clear()
+
+	suite.Equal(201, clone.Len())
+	suite.True(again.IsEmpty())
+}
+
+func (suite *BTreeTestSuite) TestBTreeCloneRandomized() {
+	r := rand.New(rand.NewSource(7))
+	trees := []*BTree{suite.tree(2)}
+	models := []map[int]int{{}}
+
+	for i := 0; i < 3000; i++ {
+		which := r.Intn(len(trees))
+		t, model := trees[which], models[which]
+		key := r.Intn(300)
+
+		switch r.Intn(10) {
+		case 0:
+			clone := make(map[int]int, len(model))
+
+			for k, v := range model {
+				clone[k] = v
+			}
+
+			trees = append(trees, t.Clone())
+			models = append(models, clone)
+		case 1, 2, 3:
+			t.Delete(key)
+			delete(model, key)
+		default:
+			t.Insert(key, i)
+			model[key] = i
+		}
+	}
+
+	for i, t := range trees {
+		suite.Require().NoError(t.Validate())
+		suite.Equal(len(models[i]), t.Len())
+
+		t.Range(nil, nil, func(key, value interface{}) bool {
+			suite.Equal(models[i][key.(int)], value)
+			return true
+		})
+	}
+}
+
+func (suite *BTreeTestSuite) TestBTreeJSON() {
+	t := NewBTree(IntegerComparer, 2, gods.WithKey[int](), gods.WithElement[string]())
+	t.Insert(2, "b")
+	t.Insert(1, "a")
+
+	data, err := json.Marshal(t)
+	suite.NoError(err)
+	suite.JSONEq(`{"1":"a","2":"b"}`, string(data))
+
+	decoded := NewBTree(IntegerComparer, 3, gods.WithKey[int](), gods.WithElement[string]())
+	suite.NoError(json.Unmarshal(data, decoded))
+	suite.Equal([]interface{}{1, 2}, decoded.Keys())
+	suite.Equal([]interface{}{"a", "b"}, decoded.Values())
+
+	var zero BTree
+	suite.Error(json.Unmarshal(data, &zero))
+}
+
+func (suite *BTreeTestSuite) TestBTreeBinary() {
+	t := suite.tree(2, 3, 1, 2)
+
+	data, err := t.MarshalBinary()
+	suite.NoError(err)
+
+	decoded := suite.tree(4)
+	suite.NoError(decoded.UnmarshalBinary(data))
+	suite.Equal(t.Keys(), decoded.Keys())
+	suite.Equal(t.Values(), decoded.Values())
+	suite.NoError(decoded.Validate())
+
+	var buf bytes.Buffer
+	suite.NoError(gob.NewEncoder(&buf).Encode(t))
+
+	decoded = suite.tree(2)
+	suite.NoError(gob.NewDecoder(&buf).Decode(decoded))
+	suite.Equal(t.Keys(), decoded.Keys())
+
+	suite.Error(decoded.UnmarshalBinary([]byte("junk")))
+	suite.Equal(t.Keys(), decoded.Keys())
+}
+
+func (suite *BTreeTestSuite) TestBTreeFormat() {
+	t := NewBTree(IntegerComparer, 2)
+	t.Insert(2, "b")
+	t.Insert(1, "a")
+
+	suite.Equal("BTree[1:a 2:b]", t.String())
+	suite.Equal("BTree[1:a ...1 more]", fmt.Sprintf("%.1v", t))
+}
+
+func TestBTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(BTreeTestSuite))
+}
+
+func TestBTreeConformance(t *testing.T) {
+	suite.Run(t, &godstest.MapSuite{
+		New: func() gods.Map { return NewBTree(IntegerComparer, 2) },
+	})
+}
+
+func FuzzBTree(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 5, 0, 15, 0, 3, 0, 7, 1, 5, 1, 10, 2, 7})
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 3, 0, 1, 1, 1, 2, 1, 3, 3, 0})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		tree := NewBTree(IntegerComparer, 2)
+		model := make(map[int]int)
+
+		var clone *BTree
+		var cloneModel map[int]int
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			key := int(ops[i+1])
+			_, present := model[key]
+
+			switch ops[i] % 4 {
+			case 0:
+				if tree.Insert(key, i) == present {
+					t.Fatalf("insert %d disagrees with model", key)
+				}
+
+				model[key] = i
+			case 1:
+				if tree.Delete(key) != present {
+					t.Fatalf("delete %d disagrees with model", key)
+				}
+
+				delete(model, key)
+			case 2:
+				if value, ok := tree.Get(key); ok != present || (ok && value != model[key]) {
+					t.Fatalf("get %d disagrees with model", key)
+				}
+			case 3:
+				clone, cloneModel = tree.Clone(), make(map[int]int, len(model))
+
+				for k, v := range model {
+					cloneModel[k] = v
+				}
+			}
+
+			if err := tree.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if tree.Len() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), tree.Len())
+			}
+		}
+
+		if clone != nil {
+			if err := clone.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if clone.Len() != len(cloneModel) {
+				t.Fatalf("clone changed: expected length %d, got %d", len(cloneModel), clone.Len())
+			}
+
+			clone.Range(nil, nil, func(key, value interface{}) bool {
+				if cloneModel[key.(int)] != value {
+					t.Fatalf("clone changed at %v", key)
+				}
+
+				return true
+			})
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+)
+
+var (
+	_ gods.Set     = (*BTreeSet)(nil)
+	_ gods.Ordered = (*BTreeSet)(nil)
+)
+
+// BTreeSet is an ordered set backed by a BTree with nil values.
+type BTreeSet struct {
+	tree *BTree
+}
+
+func NewBTreeSet(comparer Comparer, degree int, options ...gods.Option) *BTreeSet {
+	return &BTreeSet{tree: NewBTree(comparer, degree, options...)}
+}
+
+func (s *BTreeSet) Len() int {
+	return s.tree.Len()
+}
+
+func (s *BTreeSet) IsEmpty() bool {
+	return s.tree.IsEmpty()
+}
+
+func (s *BTreeSet) Clear() {
+	s.tree.Clear()
+}
+
+func (s *BTreeSet) Insert(value interface{}) bool {
+	return s.tree.Insert(value, nil)
+}
+
+func (s *BTreeSet) Delete(value interface{}) bool {
+	return s.tree.Delete(value)
+}
+
+func (s *BTreeSet) Contains(value interface{}) bool {
+	return s.tree.Contains(value)
+}
+
+func (s *BTreeSet) Values() []interface{} {
+	return s.tree.Keys()
+}
+
+func (s *BTreeSet) Each(fn func(value interface{}) bool) {
+	s.tree.Each(fn)
+}
+
+func (s *BTreeSet) First() (interface{}, bool) {
+	return s.tree.First()
+}
+
+func (s *BTreeSet) Last() (interface{}, bool) {
+	return s.tree.Last()
+}
+
+// Range calls fn in order for each value with from <= value < to until fn
+// returns false. A nil bound is unbounded.
+func (s *BTreeSet) Range(from, to interface{}, fn func(value interface{}) bool) {
+	s.tree.Range(from, to, func(key, value interface{}) bool {
+		return fn(key)
+	})
+}
+
+// Load replaces the contents of the set. The values need not be sorted and
+// duplicates are dropped.
+func (s *BTreeSet) Load(values []interface{}) {
+	_ = s.tree.Load(values, nil)
+}
+
+func (s *BTreeSet) Clone() *BTreeSet {
+	return &BTreeSet{tree: s.tree.Clone()}
+}
+
+func (s *BTreeSet) Validate() error {
+	return s.tree.Validate()
+}
+
+func (s *BTreeSet) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "BTreeSet", s.Len(), s.Each)
+}
+
+func (s *BTreeSet) String() string {
+	return fmt.Sprint(s)
+}
+
+// MarshalJSON encodes the set as an array in sorted order.
+func (s *BTreeSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Values())
+}
+
+func (s *BTreeSet) UnmarshalJSON(data []byte) error {
+	if s.tree == nil || s.tree.comparer == nil {
+		return errNoComparer
+	}
+
+	values, err := gods.UnmarshalArray(data, s.tree.element)
+
+	if err != nil {
+		return err
+	}
+
+	s.Load(values)
+
+	return nil
+}
+
+// WriteTo streams the values in sorted order, in the same format as
+// BinarySearchTree.WriteTo.
+func (s *BTreeSet) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindSet, s.tree.codec, s.Each)
+}
+
+func (s *BTreeSet) ReadFrom(r io.Reader) (int64, error) {
+	if s.tree == nil || s.tree.comparer == nil {
+		return 0, errNoComparer
+	}
+
+	var values []interface{}
+
+	n, err := gods.ReadValues(r, gods.KindSet, s.tree.codec, func(value interface{}) {
+		values = append(values, value)
+	})
+
+	if err == nil {
+		s.Load(values)
+	}
+
+	return n, err
+}
+
+func (s *BTreeSet) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(s)
+}
+
+func (s *BTreeSet) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(s, data)
+}
+
+func (s *BTreeSet) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+func (s *BTreeSet) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,91 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type BTreeSetTestSuite struct {
+	suite.Suite
+}
+
+func (suite *BTreeSetTestSuite) TestBTreeSet() {
+	s := NewBTreeSet(IntegerComparer, 2)
+
+	for _, value := range []int{5, 3, 8, 1, 5} {
+		s.Insert(value)
+	}
+
+	suite.Equal(4, s.Len())
+	suite.Equal([]interface{}{1, 3, 5, 8}, s.Values())
+	suite.True(s.Contains(3))
+	suite.True(s.Delete(3))
+	suite.False(s.Contains(3))
+
+	first, _ := s.First()
+	last, _ := s.Last()
+	suite.Equal(1, first)
+	suite.Equal(8, last)
+
+	var visited []interface{}
+
+	s.Range(2, 8, func(value interface{}) bool {
+		visited = append(visited, value)
+		return true
+	})
+
+	suite.Equal([]interface{}{5}, visited)
+	suite.NoError(s.Validate())
+}
+
+func (suite *BTreeSetTestSuite) TestBTreeSetLoadAndClone() {
+	s := NewBTreeSet(IntegerComparer, 3)
+	s.Load([]interface{}{4, 2, 2, 9})
+
+	clone := s.Clone()
+	clone.Insert(1)
+	s.Delete(9)
+
+	suite.Equal([]interface{}{2, 4}, s.Values())
+	suite.Equal([]interface{}{1, 2, 4, 9}, clone.Values())
+}
+
+func (suite *BTreeSetTestSuite) TestBTreeSetEncoding() {
+	s := NewBTreeSet(IntegerComparer, 2, gods.WithElement[int](), gods.WithCodec(gods.IntCodec))
+	s.Load([]interface{}{3, 1, 2})
+
+	data, err := json.Marshal(s)
+	suite.NoError(err)
+	suite.JSONEq(`[1,2,3]`, string(data))
+
+	decoded := NewBTreeSet(IntegerComparer, 2, gods.WithElement[int]())
+	suite.NoError(json.Unmarshal([]byte(`[3,3,1]`), decoded))
+	suite.Equal([]interface{}{1, 3}, decoded.Values())
+
+	data, err = s.MarshalBinary()
+	suite.NoError(err)
+
+	bst := NewBinarySearchTree(IntegerComparer, gods.WithCodec(gods.IntCodec))
+	suite.NoError(bst.UnmarshalBinary(data))
+	suite.Equal([]interface{}{1, 2, 3}, bst.Values())
+
+	var zero BTreeSet
+	suite.Error(json.Unmarshal(data, &zero))
+
+	suite.Equal("BTreeSet[1 2 3]", s.String())
+	suite.Equal("BTreeSet[1 ...2 more]", fmt.Sprintf("%.1v", s))
+}
+
+func TestBTreeSetTestSuite(t *testing.T) {
+	suite.Run(t, new(BTreeSetTestSuite))
+}
+
+func TestBTreeSetConformance(t *testing.T) {
+	suite.Run(t, &godstest.SetSuite{
+		New: func() gods.Set { return NewBTreeSet(IntegerComparer, 2) },
+	})
+}
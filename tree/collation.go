@@ -0,0 +1,120 @@
+package tree
+
+import (
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseInsensitiveStringComparer compares strings rune by rune after simple
+// Unicode case folding, so "apple" sorts before "Zebra".
+func CaseInsensitiveStringComparer(source, target interface{}) ComparerType {
+	s, t := source.(string), target.(string)
+
+	for s != "" && t != "" {
+		sr, sn := utf8.DecodeRuneInString(s)
+		tr, tn := utf8.DecodeRuneInString(t)
+
+		if sf, tf := foldRune(sr), foldRune(tr); sf != tf {
+			return order(sf < tf, sf > tf)
+		}
+
+		s, t = s[sn:], t[tn:]
+	}
+
+	return order(s == "" && t != "", s != "" && t == "")
+}
+
+// NormalizedStringComparer compares the NFC forms of two strings, so
+// precomposed and decomposed spellings of the same text are equal.
+func NormalizedStringComparer(source, target interface{}) ComparerType {
+	return StringComparer(norm.NFC.String(source.(string)), norm.NFC.String(target.(string)))
+}
+
+// NaturalStringComparer orders runs of digits by their numeric value, so
+// "file2" sorts before "file10".
+func NaturalStringComparer(source, target interface{}) ComparerType {
+	s, t := source.(string), target.(string)
+
+	for s != "" && t != "" {
+		if isDigit(s[0]) && isDigit(t[0]) {
+			sd, td := digitRun(s), digitRun(t)
+
+			if result := compareNumbers(s[:sd], t[:td]); result != ComparerEqual {
+				return result
+			}
+
+			s, t = s[sd:], t[td:]
+			continue
+		}
+
+		sr, sn := utf8.DecodeRuneInString(s)
+		tr, tn := utf8.DecodeRuneInString(t)
+
+		if sr != tr {
+			return order(sr < tr, sr > tr)
+		}
+
+		s, t = s[sn:], t[tn:]
+	}
+
+	return order(s == "" && t != "", s != "" && t == "")
+}
+
+// CollatorComparer orders strings using the Unicode collation rules of the
+// given locale. Options such as collate.IgnoreCase and collate.Numeric are
+// passed through to the collator.
+func CollatorComparer(tag language.Tag, options ...collate.Option) Comparer {
+	collator := collate.New(tag, options...)
+	mutex := new(sync.Mutex)
+
+	return func(source, target interface{}) ComparerType {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return threeWay(collator.CompareString(source.(string), target.(string)))
+	}
+}
+
+func foldRune(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(r))
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func digitRun(s string) int {
+	n := 0
+
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+
+	return n
+}
+
+func compareNumbers(source, target string) ComparerType {
+	trimmedSource, trimmedTarget := trimZeros(source), trimZeros(target)
+
+	if len(trimmedSource) != len(trimmedTarget) {
+		return order(len(trimmedSource) < len(trimmedTarget), len(trimmedSource) > len(trimmedTarget))
+	}
+
+	if result := StringComparer(trimmedSource, trimmedTarget); result != ComparerEqual {
+		return result
+	}
+
+	return order(len(source) < len(target), len(source) > len(target))
+}
+
+func trimZeros(s string) string {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+
+	return s
+}
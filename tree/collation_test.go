@@ -0,0 +1,66 @@
+package tree
+
+import (
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"testing"
+)
+
+type CollationTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CollationTestSuite) sorted(comparer Comparer, values ...string) []interface{} {
+	b := NewBinarySearchTree(comparer)
+
+	for _, value := range values {
+		b.Insert(value)
+	}
+
+	return b.Values()
+}
+
+func (suite *CollationTestSuite) TestCaseInsensitiveStringComparer() {
+	suite.Equal([]interface{}{"apple", "Banana", "Zebra"}, suite.sorted(CaseInsensitiveStringComparer, "Zebra", "apple", "Banana"))
+	suite.Equal(ComparerType(ComparerEqual), CaseInsensitiveStringComparer("ÉCOLE", "école"))
+	suite.Equal(ComparerType(ComparerLarger), CaseInsensitiveStringComparer("app", "APPLE"))
+
+	tieBroken := Then(CaseInsensitiveStringComparer, StringComparer)
+	suite.Equal([]interface{}{"Apple", "apple", "Zebra"}, suite.sorted(tieBroken, "apple", "Zebra", "Apple"))
+}
+
+func (suite *CollationTestSuite) TestNormalizedStringComparer() {
+	composed := "caf\u00e9"
+	decomposed := "cafe\u0301"
+
+	suite.Equal(ComparerType(ComparerLarger), StringComparer(decomposed, composed))
+	suite.Equal(ComparerType(ComparerEqual), NormalizedStringComparer(decomposed, composed))
+	suite.Len(suite.sorted(NormalizedStringComparer, composed, decomposed), 1)
+}
+
+func (suite *CollationTestSuite) TestNaturalStringComparer() {
+	suite.Equal(
+		[]interface{}{"file1", "file2", "file02", "file10", "file10a", "file10b", "files"},
+		suite.sorted(NaturalStringComparer, "file10", "file2", "files", "file10b", "file1", "file02", "file10a"),
+	)
+	suite.Equal(ComparerType(ComparerLarger), NaturalStringComparer("v1.9", "v1.10"))
+	suite.Equal(ComparerType(ComparerEqual), NaturalStringComparer("a007", "a007"))
+	suite.Equal(ComparerType(ComparerLarger), NaturalStringComparer("99999999999999999999", "100000000000000000000"))
+}
+
+func (suite *CollationTestSuite) TestCollatorComparer() {
+	english := CollatorComparer(language.English)
+	suite.Equal([]interface{}{"Äpfel", "apple", "Zebra"}, suite.sorted(english, "Zebra", "Äpfel", "apple"))
+
+	swedish := CollatorComparer(language.Swedish)
+	suite.Equal([]interface{}{"apple", "Zebra", "Äpfel"}, suite.sorted(swedish, "Zebra", "Äpfel", "apple"))
+
+	numeric := CollatorComparer(language.English, collate.Numeric, collate.IgnoreCase)
+	suite.Equal([]interface{}{"File2", "file10"}, suite.sorted(numeric, "file10", "File2"))
+	suite.Equal(ComparerType(ComparerEqual), numeric("FILE2", "file2"))
+}
+
+func TestCollationTestSuite(t *testing.T) {
+	suite.Run(t, new(CollationTestSuite))
+}
@@ -0,0 +1,191 @@
+package tree
+
+import (
+	"bytes"
+	"github.com/kucuny/gods"
+	"strings"
+	"time"
+)
+
+type ComparerType int
+
+const (
+	ComparerLarger = iota
+	ComparerSmaller
+	ComparerEqual
+)
+
+// Comparer reports ComparerLarger when target is larger than source and
+// ComparerSmaller when target is smaller. Compare converts it to the usual
+// three-way contract.
+type Comparer func(source, target interface{}) ComparerType
+
+// CompareFunc follows the cmp.Compare contract: negative when a < b, zero
+// when a == b and positive when a > b. The ordered containers sort by a
+// CompareFunc; pass one with gods.WithCompare.
+type CompareFunc func(a, b interface{}) int
+
+// OrderOf returns the order a container built with comparer and opts sorts
+// by: the gods.WithCompare option if set, otherwise comparer. It is nil if
+// neither is set.
+func OrderOf(comparer Comparer, opts *gods.Options) CompareFunc {
+	if opts.Compare != nil {
+		return opts.Compare
+	}
+
+	if comparer == nil {
+		return nil
+	}
+
+	return comparer.Compare
+}
+
+func (c Comparer) Compare(a, b interface{}) int {
+	switch c(a, b) {
+	case ComparerLarger:
+		return -1
+	case ComparerSmaller:
+		return 1
+	}
+
+	return 0
+}
+
+func (f CompareFunc) Compare(a, b interface{}) int {
+	return f(a, b)
+}
+
+func (f CompareFunc) Comparer() Comparer {
+	return func(source, target interface{}) ComparerType {
+		return threeWay(f(source, target))
+	}
+}
+
+// FromCompare adapts a typed three-way function such as cmp.Compare[int]
+// for use with the ordered containers.
+func FromCompare[T any](compare func(a, b T) int) Comparer {
+	return func(source, target interface{}) ComparerType {
+		return threeWay(compare(source.(T), target.(T)))
+	}
+}
+
+// ToCompare adapts a Comparer for use with slices.SortFunc and friends.
+func ToCompare[T any](comparer Comparer) func(a, b T) int {
+	return func(a, b T) int {
+		return comparer.Compare(a, b)
+	}
+}
+
+func IntegerComparer(source, target interface{}) ComparerType {
+	return order(source.(int) < target.(int), source.(int) > target.(int))
+}
+
+func Integer8Comparer(source, target interface{}) ComparerType {
+	return order(source.(int8) < target.(int8), source.(int8) > target.(int8))
+}
+
+func Integer16Comparer(source, target interface{}) ComparerType {
+	return order(source.(int16) < target.(int16), source.(int16) > target.(int16))
+}
+
+func Integer32Comparer(source, target interface{}) ComparerType {
+	return order(source.(int32) < target.(int32), source.(int32) > target.(int32))
+}
+
+func Integer64Comparer(source, target interface{}) ComparerType {
+	return order(source.(int64) < target.(int64), source.(int64) > target.(int64))
+}
+
+func UnsignedIntegerComparer(source, target interface{}) ComparerType {
+	return order(source.(uint) < target.(uint), source.(uint) > target.(uint))
+}
+
+func UnsignedInteger8Comparer(source, target interface{}) ComparerType {
+	return order(source.(uint8) < target.(uint8), source.(uint8) > target.(uint8))
+}
+
+func UnsignedInteger16Comparer(source, target interface{}) ComparerType {
+	return order(source.(uint16) < target.(uint16), source.(uint16) > target.(uint16))
+}
+
+func UnsignedInteger32Comparer(source, target interface{}) ComparerType {
+	return order(source.(uint32) < target.(uint32), source.(uint32) > target.(uint32))
+}
+
+func UnsignedInteger64Comparer(source, target interface{}) ComparerType {
+	return order(source.(uint64) < target.(uint64), source.(uint64) > target.(uint64))
+}
+
+func UintptrComparer(source, target interface{}) ComparerType {
+	return order(source.(uintptr) < target.(uintptr), source.(uintptr) > target.(uintptr))
+}
+
+// Float comparers order NaN before every other value and treat NaNs as equal,
+// so a NaN cannot break the ordering of a tree.
+func Float32Comparer(source, target interface{}) ComparerType {
+	return floatOrder(float64(source.(float32)), float64(target.(float32)))
+}
+
+func Float64Comparer(source, target interface{}) ComparerType {
+	return floatOrder(source.(float64), target.(float64))
+}
+
+func StringComparer(source, target interface{}) ComparerType {
+	return threeWay(strings.Compare(source.(string), target.(string)))
+}
+
+func BytesComparer(source, target interface{}) ComparerType {
+	return threeWay(bytes.Compare(source.([]byte), target.([]byte)))
+}
+
+func TimeComparer(source, target interface{}) ComparerType {
+	return order(source.(time.Time).Before(target.(time.Time)), source.(time.Time).After(target.(time.Time)))
+}
+
+func Reverse(comparer Comparer) Comparer {
+	return func(source, target interface{}) ComparerType {
+		return comparer(target, source)
+	}
+}
+
+func Then(first, second Comparer) Comparer {
+	return func(source, target interface{}) ComparerType {
+		if result := first(source, target); result != ComparerEqual {
+			return result
+		}
+
+		return second(source, target)
+	}
+}
+
+func By(key func(value interface{}) interface{}, comparer Comparer) Comparer {
+	return func(source, target interface{}) ComparerType {
+		return comparer(key(source), key(target))
+	}
+}
+
+func order(less, greater bool) ComparerType {
+	if less {
+		return ComparerLarger
+	}
+
+	if greater {
+		return ComparerSmaller
+	}
+
+	return ComparerEqual
+}
+
+func threeWay(result int) ComparerType {
+	return order(result < 0, result > 0)
+}
+
+func floatOrder(source, target float64) ComparerType {
+	sourceNaN, targetNaN := source != source, target != target
+
+	if sourceNaN || targetNaN {
+		return order(sourceNaN && !targetNaN, targetNaN && !sourceNaN)
+	}
+
+	return order(source < target, source > target)
+}
@@ -0,0 +1,185 @@
+package tree
+
+import (
+	"cmp"
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+type ComparerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ComparerTestSuite) assertOrder(comparer Comparer, smaller, larger interface{}) {
+	suite.Equal(ComparerType(ComparerLarger), comparer(smaller, larger))
+	suite.Equal(ComparerType(ComparerSmaller), comparer(larger, smaller))
+	suite.Equal(ComparerType(ComparerEqual), comparer(smaller, smaller))
+}
+
+func (suite *ComparerTestSuite) TestComparerIntegers() {
+	suite.assertOrder(IntegerComparer, -1, 1)
+	suite.assertOrder(Integer8Comparer, int8(math.MinInt8), int8(math.MaxInt8))
+	suite.assertOrder(Integer16Comparer, int16(-300), int16(300))
+	suite.assertOrder(Integer32Comparer, int32(-70000), int32(70000))
+	suite.assertOrder(Integer64Comparer, int64(math.MinInt64), int64(math.MaxInt64))
+	suite.assertOrder(UnsignedIntegerComparer, uint(0), uint(math.MaxUint32))
+	suite.assertOrder(UnsignedInteger8Comparer, uint8(1), uint8(255))
+	suite.assertOrder(UnsignedInteger16Comparer, uint16(1), uint16(65535))
+	suite.assertOrder(UnsignedInteger32Comparer, uint32(1), uint32(math.MaxUint32))
+	suite.assertOrder(UnsignedInteger64Comparer, uint64(1), uint64(math.MaxUint64))
+	suite.assertOrder(UintptrComparer, uintptr(1), uintptr(2))
+}
+
+func (suite *ComparerTestSuite) TestComparerFloatsWithNaN() {
+	suite.assertOrder(Float32Comparer, float32(-1.5), float32(2.5))
+	suite.assertOrder(Float64Comparer, -1.5, 2.5)
+	suite.assertOrder(Float64Comparer, math.NaN(), math.Inf(-1))
+	suite.assertOrder(Float32Comparer, float32(math.NaN()), float32(0))
+	suite.Equal(ComparerType(ComparerEqual), Float64Comparer(math.NaN(), math.NaN()))
+
+	b := NewBinarySearchTree(Float64Comparer)
+	b.Insert(1.0)
+	b.Insert(math.NaN())
+	b.Insert(-1.0)
+	b.Insert(math.NaN())
+
+	suite.Equal(3, b.Len())
+	suite.Nil(b.Validate())
+	suite.True(math.IsNaN(b.Min().Value.(float64)))
+}
+
+func (suite *ComparerTestSuite) TestComparerStringsBytesTimes() {
+	suite.assertOrder(StringComparer, "Zebra", "apple")
+	suite.assertOrder(BytesComparer, []byte{1, 2}, []byte{1, 2, 0})
+
+	now := time.Now()
+	suite.assertOrder(TimeComparer, now, now.Add(time.Nanosecond))
+	suite.Equal(ComparerType(ComparerEqual), TimeComparer(now, now.UTC()))
+}
+
+func (suite *ComparerTestSuite) TestComparerReverse() {
+	suite.assertOrder(Reverse(IntegerComparer), 2, 1)
+}
+
+func (suite *ComparerTestSuite) TestComparerCompositeKeys() {
+	type person struct {
+		last, first string
+		age         int
+	}
+
+	comparer := Then(
+		By(func(value interface{}) interface{} { return value.(person).last }, StringComparer),
+		Then(
+			By(func(value interface{}) interface{} { return value.(person).first }, StringComparer),
+			Reverse(By(func(value interface{}) interface{} { return value.(person).age }, IntegerComparer)),
+		),
+	)
+
+	suite.assertOrder(comparer, person{"Kim", "Zed", 30}, person{"Lee", "Ann", 20})
+	suite.assertOrder(comparer, person{"Kim", "Ann", 30}, person{"Kim", "Bob", 20})
+	suite.assertOrder(comparer, person{"Kim", "Ann", 40}, person{"Kim", "Ann", 30})
+
+	b := NewBinarySearchTree(comparer)
+	b.Insert(person{"Lee", "Ann", 20})
+	b.Insert(person{"Kim", "Ann", 30})
+	b.Insert(person{"Kim", "Ann", 40})
+
+	suite.Equal([]interface{}{
+		person{"Kim", "Ann", 40},
+		person{"Kim", "Ann", 30},
+		person{"Lee", "Ann", 20},
+	}, b.Values())
+}
+
+func (suite *ComparerTestSuite) TestComparerCompareMatchesCmp() {
+	floats := []float64{math.NaN(), math.Inf(-1), -1, 0, 1, math.Inf(1)}
+
+	for _, a := range floats {
+		for _, b := range floats {
+			suite.Equal(cmp.Compare(a, b), Comparer(Float64Comparer).Compare(a, b), "%v %v", a, b)
+		}
+	}
+
+	for _, a := range []int{-2, 0, 2} {
+		for _, b := range []int{-2, 0, 2} {
+			suite.Equal(cmp.Compare(a, b), Comparer(IntegerComparer).Compare(a, b))
+		}
+	}
+}
+
+func (suite *ComparerTestSuite) TestComparerFromCompare() {
+	comparer := FromCompare(cmp.Compare[string])
+	suite.assertOrder(comparer, "a", "b")
+
+	b := NewBinarySearchTree(FromCompare(func(a, b int) int { return b - a }))
+	b.Insert(1)
+	b.Insert(3)
+	b.Insert(2)
+
+	suite.Equal([]interface{}{3, 2, 1}, b.Values())
+
+	reversed := CompareFunc(func(a, b interface{}) int { return cmp.Compare(b.(int), a.(int)) }).Comparer()
+	suite.assertOrder(reversed, 2, 1)
+}
+
+func (suite *ComparerTestSuite) TestComparerWithCompare() {
+	option := gods.WithCompare(cmp.Compare[int])
+	ordered := []interface{}{1, 2, 3}
+
+	b := NewBinarySearchTree(nil, option)
+	s := NewSplayTree(nil, option)
+	t := NewTreap(nil, option)
+	set := NewBTreeSet(nil, 2, option)
+	m := NewBTree(nil, 2, option)
+	i := NewIntervalTree(nil, option)
+	immutable := NewImmutableTree(nil, option)
+
+	for _, value := range []int{3, 1, 2} {
+		b.Insert(value)
+		s.Insert(value)
+		t.Insert(value)
+		set.Insert(value)
+		m.Insert(value, nil)
+		i.Insert(value, value+1, nil)
+		immutable = immutable.Insert(value, nil)
+	}
+
+	suite.Equal(ordered, b.Values())
+	suite.Equal(ordered, s.Values())
+	suite.Equal(ordered, t.Values())
+	suite.Equal(ordered, set.Values())
+	suite.Equal(ordered, m.Keys())
+	suite.Equal([]Interval{{1, 2}, {2, 3}, {3, 4}}, i.Intervals())
+	suite.Equal(ordered, immutable.Keys())
+
+	reversed := NewBinarySearchTree(IntegerComparer, gods.WithCompare(func(a, b int) int { return b - a }))
+	reversed.Insert(1)
+	reversed.Insert(2)
+	suite.Equal([]interface{}{2, 1}, reversed.Values())
+
+	named := NewBinarySearchTree(nil, gods.WithCompare(CompareFunc(Comparer(StringComparer).Compare)))
+	named.Insert("b")
+	named.Insert("a")
+	suite.Equal([]interface{}{"a", "b"}, named.Values())
+}
+
+func (suite *ComparerTestSuite) TestComparerToCompare() {
+	values := []int{3, 1, 2}
+	slices.SortFunc(values, ToCompare[int](IntegerComparer))
+	suite.Equal([]int{1, 2, 3}, values)
+
+	names := []string{"file10", "file2", "file1"}
+	slices.SortFunc(names, ToCompare[string](NaturalStringComparer))
+	suite.Equal([]string{"file1", "file2", "file10"}, names)
+
+	_, found := slices.BinarySearchFunc(values, 2, ToCompare[int](IntegerComparer))
+	suite.True(found)
+}
+
+func TestComparerTestSuite(t *testing.T) {
+	suite.Run(t, new(ComparerTestSuite))
+}
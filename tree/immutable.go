@@ -0,0 +1,401 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+)
+
+// ImmutableNode is a node of an ImmutableTree. Nodes are never modified
+// after creation, so they may be shared between versions.
+type ImmutableNode struct {
+	left, right *ImmutableNode
+	height      int
+	key, value  interface{}
+}
+
+func (n *ImmutableNode) Key() interface{} {
+	return n.key
+}
+
+func (n *ImmutableNode) Value() interface{} {
+	return n.value
+}
+
+func (n *ImmutableNode) Height() int {
+	return n.height
+}
+
+func (n *ImmutableNode) Left() *ImmutableNode {
+	return n.left
+}
+
+func (n *ImmutableNode) Right() *ImmutableNode {
+	return n.right
+}
+
+func (n *ImmutableNode) GetValue() interface{} {
+	return n.key
+}
+
+func (n *ImmutableNode) Children() (BinaryNode, BinaryNode) {
+	return immutableBinaryNode(n.left), immutableBinaryNode(n.right)
+}
+
+var _ gods.Iterable = (*ImmutableTree)(nil)
+
+// ImmutableTree is a persistent AVL tree mapping keys to values. Insert and
+// Remove return a new version that shares every unchanged subtree with the
+// old one, so each version can be read concurrently without locking. Use
+// nil values for set semantics.
+type ImmutableTree struct {
+	root     *ImmutableNode
+	count    int
+	comparer CompareFunc
+}
+
+// NewImmutableTree creates an empty tree. Of the options only WithCompare
+// applies.
+func NewImmutableTree(comparer Comparer, options ...gods.Option) *ImmutableTree {
+	opts := gods.NewOptions(options...)
+
+	return &ImmutableTree{
+		root:     nil,
+		count:    0,
+		comparer: OrderOf(comparer, opts),
+	}
+}
+
+func (t *ImmutableTree) Len() int {
+	return t.count
+}
+
+func (t *ImmutableTree) IsEmpty() bool {
+	return t.count == 0
+}
+
+func (t *ImmutableTree) Root() *ImmutableNode {
+	return t.root
+}
+
+// Insert returns a version with key set to value.
+func (t *ImmutableTree) Insert(key, value interface{}) *ImmutableTree {
+	root, added := t.insert(t.root, key, value)
+	count := t.count
+
+	if added {
+		count++
+	}
+
+	return &ImmutableTree{root: root, count: count, comparer: t.comparer}
+}
+
+// Remove returns a version without key, or t itself if key is absent.
+func (t *ImmutableTree) Remove(key interface{}) *ImmutableTree {
+	root, removed := t.remove(t.root, key)
+
+	if !removed {
+		return t
+	}
+
+	return &ImmutableTree{root: root, count: t.count - 1, comparer: t.comparer}
+}
+
+func (t *ImmutableTree) Get(key interface{}) (interface{}, bool) {
+	for node := t.root; node != nil; {
+		switch result := t.comparer.Compare(key, node.key); {
+		case result < 0:
+			node = node.left
+		case result > 0:
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+
+	return nil, false
+}
+
+func (t *ImmutableTree) Contains(key interface{}) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+func (t *ImmutableTree) Keys() []interface{} {
+	keys := make([]interface{}, 0, t.count)
+
+	t.Range(nil, nil, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys
+}
+
+func (t *ImmutableTree) Values() []interface{} {
+	values := make([]interface{}, 0, t.count)
+
+	t.Range(nil, nil, func(key, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (t *ImmutableTree) Each(fn func(key interface{}) bool) {
+	t.Range(nil, nil, func(key, value interface{}) bool {
+		return fn(key)
+	})
+}
+
+func (t *ImmutableTree) First() (interface{}, bool) {
+	if t.root == nil {
+		return nil, false
+	}
+
+	node := t.root
+
+	for node.left != nil {
+		node = node.left
+	}
+
+	return node.key, true
+}
+
+func (t *ImmutableTree) Last() (interface{}, bool) {
+	if t.root == nil {
+		return nil, false
+	}
+
+	node := t.root
+
+	for node.right != nil {
+		node = node.right
+	}
+
+	return node.key, true
+}
+
+// Range calls fn in key order for keys in [from, to) until fn returns
+// false. A nil bound is unbounded.
+func (t *ImmutableTree) Range(from, to interface{}, fn func(key, value interface{}) bool) {
+	t.walk(t.root, from, to, fn)
+}
+
+func (t *ImmutableTree) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "ImmutableTree", t.count, func(fn func(key, value interface{}) bool) {
+		t.Range(nil, nil, fn)
+	})
+}
+
+func (t *ImmutableTree) String() string {
+	return fmt.Sprint(t)
+}
+
+func (t *ImmutableTree) ASCII() string {
+	return ASCII(immutableBinaryNode(t.root))
+}
+
+func (t *ImmutableTree) Validate() error {
+	count, _, err := t.validate(t.root, nil, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if count != t.count {
+		return fmt.Errorf("tree: count is %d but %d nodes are reachable", t.count, count)
+	}
+
+	return nil
+}
+
+func (t *ImmutableTree) walk(node *ImmutableNode, from, to interface{}, fn func(key, value interface{}) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	afterFrom := from == nil || t.comparer.Compare(node.key, from) >= 0
+	beforeTo := to == nil || t.comparer.Compare(node.key, to) < 0
+
+	if afterFrom && !t.walk(node.left, from, to, fn) {
+		return false
+	}
+
+	if afterFrom && beforeTo && !fn(node.key, node.value) {
+		return false
+	}
+
+	if beforeTo {
+		return t.walk(node.right, from, to, fn)
+	}
+
+	return true
+}
+
+func (t *ImmutableTree) insert(node *ImmutableNode, key, value interface{}) (*ImmutableNode, bool) {
+	if node == nil {
+		return newImmutableNode(key, value, nil, nil), true
+	}
+
+	var added bool
+
+	switch result := t.comparer.Compare(key, node.key); {
+	case result < 0:
+		left, ok := t.insert(node.left, key, value)
+		node, added = balance(node.key, node.value, left, node.right), ok
+	case result > 0:
+		right, ok := t.insert(node.right, key, value)
+		node, added = balance(node.key, node.value, node.left, right), ok
+	default:
+		node = newImmutableNode(node.key, value, node.left, node.right)
+	}
+
+	return node, added
+}
+
+func (t *ImmutableTree) remove(node *ImmutableNode, key interface{}) (*ImmutableNode, bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	switch result := t.comparer.Compare(key, node.key); {
+	case result < 0:
+		left, removed := t.remove(node.left, key)
+
+		if !removed {
+			return node, false
+		}
+
+		return balance(node.key, node.value, left, node.right), true
+	case result > 0:
+		right, removed := t.remove(node.right, key)
+
+		if !removed {
+			return node, false
+		}
+
+		return balance(node.key, node.value, node.left, right), true
+	}
+
+	if node.left == nil {
+		return node.right, true
+	}
+
+	if node.right == nil {
+		return node.left, true
+	}
+
+	min, right := removeImmutableMin(node.right)
+
+	return balance(min.key, min.value, node.left, right), true
+}
+
+func (t *ImmutableTree) validate(node *ImmutableNode, lower, upper *ImmutableNode) (int, int, error) {
+	if node == nil {
+		return 0, 0, nil
+	}
+
+	if lower != nil && t.comparer.Compare(lower.key, node.key) >= 0 {
+		return 0, 0, fmt.Errorf("tree: %v is not greater than its ancestor %v", node.key, lower.key)
+	}
+
+	if upper != nil && t.comparer.Compare(upper.key, node.key) <= 0 {
+		return 0, 0, fmt.Errorf("tree: %v is not less than its ancestor %v", node.key, upper.key)
+	}
+
+	left, leftHeight, err := t.validate(node.left, lower, node)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	right, rightHeight, err := t.validate(node.right, node, upper)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+		return 0, 0, fmt.Errorf("tree: %v is unbalanced (%d, %d)", node.key, leftHeight, rightHeight)
+	}
+
+	if height := maxHeight(leftHeight, rightHeight) + 1; node.height != height {
+		return 0, 0, fmt.Errorf("tree: %v has height %d, expected %d", node.key, node.height, height)
+	}
+
+	return left + right + 1, node.height, nil
+}
+
+func newImmutableNode(key, value interface{}, left, right *ImmutableNode) *ImmutableNode {
+	return &ImmutableNode{
+		left:   left,
+		right:  right,
+		height: maxHeight(height(left), height(right)) + 1,
+		key:    key,
+		value:  value,
+	}
+}
+
+func removeImmutableMin(node *ImmutableNode) (*ImmutableNode, *ImmutableNode) {
+	if node.left == nil {
+		return node, node.right
+	}
+
+	min, left := removeImmutableMin(node.left)
+
+	return min, balance(node.key, node.value, left, node.right)
+}
+
+// balance builds a node from key, value and two subtrees whose heights
+// differ by at most two, rotating as needed. It never modifies its inputs.
+func balance(key, value interface{}, left, right *ImmutableNode) *ImmutableNode {
+	switch hl, hr := height(left), height(right); {
+	case hl > hr+1:
+		if height(left.left) >= height(left.right) {
+			return newImmutableNode(left.key, left.value, left.left, newImmutableNode(key, value, left.right, right))
+		}
+
+		pivot := left.right
+
+		return newImmutableNode(pivot.key, pivot.value,
+			newImmutableNode(left.key, left.value, left.left, pivot.left),
+			newImmutableNode(key, value, pivot.right, right))
+	case hr > hl+1:
+		if height(right.right) >= height(right.left) {
+			return newImmutableNode(right.key, right.value, newImmutableNode(key, value, left, right.left), right.right)
+		}
+
+		pivot := right.left
+
+		return newImmutableNode(pivot.key, pivot.value,
+			newImmutableNode(key, value, left, pivot.left),
+			newImmutableNode(right.key, right.value, pivot.right, right.right))
+	}
+
+	return newImmutableNode(key, value, left, right)
+}
+
+func height(node *ImmutableNode) int {
+	if node == nil {
+		return 0
+	}
+
+	return node.height
+}
+
+func maxHeight(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func immutableBinaryNode(node *ImmutableNode) BinaryNode {
+	if node == nil {
+		return nil
+	}
+
+	return node
+}
@@ -0,0 +1,275 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+type ImmutableTreeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ImmutableTreeTestSuite) build(keys ...int) *ImmutableTree {
+	t := NewImmutableTree(IntegerComparer)
+
+	for _, key := range keys {
+		t = t.Insert(key, fmt.Sprint(key))
+	}
+
+	return t
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeInsertAndGet() {
+	t := suite.build(5, 3, 8, 1, 4)
+
+	suite.Equal(5, t.Len())
+	suite.False(t.IsEmpty())
+	suite.Equal([]interface{}{1, 3, 4, 5, 8}, t.Keys())
+	suite.Equal([]interface{}{"1", "3", "4", "5", "8"}, t.Values())
+
+	value, ok := t.Get(4)
+	suite.True(ok)
+	suite.Equal("4", value)
+
+	_, ok = t.Get(7)
+	suite.False(ok)
+	suite.False(t.Contains(7))
+
+	replaced := t.Insert(4, "four")
+	suite.Equal(5, replaced.Len())
+
+	value, _ = replaced.Get(4)
+	suite.Equal("four", value)
+
+	first, _ := t.First()
+	last, _ := t.Last()
+	suite.Equal(1, first)
+	suite.Equal(8, last)
+	suite.NoError(t.Validate())
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeEmpty() {
+	t := NewImmutableTree(IntegerComparer)
+
+	suite.True(t.IsEmpty())
+	suite.Empty(t.Keys())
+	suite.Same(t, t.Remove(1))
+
+	_, ok := t.First()
+	suite.False(ok)
+
+	_, ok = t.Last()
+	suite.False(ok)
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeOldVersionsUnchanged() {
+	versions := []*ImmutableTree{NewImmutableTree(IntegerComparer)}
+
+	for i := 0; i < 50; i++ {
+		versions = append(versions, versions[len(versions)-1].Insert(i, i))
+	}
+
+	for i := 0; i < 50; i += 2 {
+		versions = append(versions, versions[len(versions)-1].Remove(i))
+	}
+
+	for i, version := range versions[:51] {
+		suite.Equal(i, version.Len())
+		suite.NoError(version.Validate())
+
+		for key := 0; key < 50; key++ {
+			suite.Equal(key < i, version.Contains(key))
+		}
+	}
+
+	last := versions[len(versions)-1]
+	suite.Equal(25, last.Len())
+	suite.NoError(last.Validate())
+	suite.False(last.Contains(0))
+	suite.True(last.Contains(1))
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeSharesUnchangedPaths() {
+	t := suite.build(4, 2, 6, 1, 3, 5, 7)
+	inserted := t.Insert(8, "8")
+
+	suite.NotSame(t.Root(), inserted.Root())
+	suite.Same(t.Root().Left(), inserted.Root().Left())
+
+	removed := t.Remove(1)
+
+	suite.Same(t.Root().Right(), removed.Root().Right())
+	suite.Same(t.Root().Left().Right(), removed.Root().Left().Right())
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeStaysBalanced() {
+	t := NewImmutableTree(IntegerComparer)
+
+	for i := 0; i < 1024; i++ {
+		t = t.Insert(i, nil)
+	}
+
+	suite.NoError(t.Validate())
+	suite.LessOrEqual(t.Root().Height(), int(1.45*math.Log2(1024))+1)
+
+	for i := 0; i < 1000; i++ {
+		t = t.Remove(i)
+	}
+
+	suite.NoError(t.Validate())
+	suite.Equal(24, t.Len())
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeRange() {
+	t := suite.build(1, 2, 3, 4, 5, 6)
+
+	collect := func(from, to interface{}, limit int) []interface{} {
+		var keys []interface{}
+
+		t.Range(from, to, func(key, value interface{}) bool {
+			keys = append(keys, key)
+			return len(keys) < limit
+		})
+
+		return keys
+	}
+
+	suite.Equal([]interface{}{2, 3, 4}, collect(2, 5, 10))
+	suite.Equal([]interface{}{1, 2}, collect(nil, 3, 10))
+	suite.Equal([]interface{}{5, 6}, collect(5, nil, 10))
+	suite.Equal([]interface{}{1, 2}, collect(nil, nil, 2))
+	suite.Empty(collect(4, 4, 10))
+
+	var each []interface{}
+
+	t.Each(func(key interface{}) bool {
+		each = append(each, key)
+		return key.(int) < 3
+	})
+
+	suite.Equal([]interface{}{1, 2, 3}, each)
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeRandomized() {
+	r := rand.New(rand.NewSource(1))
+	t := NewImmutableTree(IntegerComparer)
+	model := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(300)
+
+		if r.Intn(3) == 0 {
+			t = t.Remove(key)
+			delete(model, key)
+		} else {
+			t = t.Insert(key, i)
+			model[key] = i
+		}
+	}
+
+	suite.NoError(t.Validate())
+
+	var keys []int
+
+	for key := range model {
+		keys = append(keys, key)
+	}
+
+	sort.Ints(keys)
+
+	suite.Equal(len(keys), t.Len())
+
+	for i, key := range t.Keys() {
+		suite.Equal(keys[i], key)
+
+		value, _ := t.Get(key)
+		suite.Equal(model[keys[i]], value)
+	}
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeConcurrentReaders() {
+	snapshot := suite.build(1, 2, 3, 4, 5, 6, 7, 8)
+	current := snapshot
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 200; j++ {
+				suite.Equal(8, len(snapshot.Keys()))
+				suite.True(snapshot.Contains(5))
+			}
+		}()
+	}
+
+	for i := 0; i < 200; i++ {
+		current = current.Insert(100+i, nil).Remove(i % 8)
+	}
+
+	wg.Wait()
+
+	suite.NoError(current.Validate())
+	suite.Equal([]interface{}{1, 2, 3, 4, 5, 6, 7, 8}, snapshot.Keys())
+}
+
+func (suite *ImmutableTreeTestSuite) TestImmutableTreeFormat() {
+	t := suite.build(2, 1, 3)
+
+	suite.Equal("ImmutableTree[1:1 2:2 3:3]", t.String())
+	suite.Equal(" 2\n/ \\\n1 3\n", t.ASCII())
+}
+
+func TestImmutableTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(ImmutableTreeTestSuite))
+}
+
+func FuzzImmutableTree(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 5, 0, 15, 0, 3, 0, 7, 1, 5, 1, 10, 2, 7})
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 1, 1, 1, 2, 1, 3, 3, 0})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		tree := NewImmutableTree(IntegerComparer)
+		previous, previousLen := tree, 0
+		model := make(map[int]bool)
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 4 {
+			case 0:
+				tree = tree.Insert(value, nil)
+				model[value] = true
+			case 1:
+				tree = tree.Remove(value)
+				delete(model, value)
+			case 2:
+				if tree.Contains(value) != model[value] {
+					t.Fatalf("contains %d disagrees with model", value)
+				}
+			case 3:
+				previous, previousLen = tree, tree.Len()
+			}
+
+			if err := tree.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if tree.Len() != len(model) {
+				t.Fatalf("expected length %d, got %d", len(model), tree.Len())
+			}
+		}
+
+		if previous.Len() != previousLen || previous.Validate() != nil {
+			t.Fatal("saved version changed")
+		}
+	})
+}
@@ -0,0 +1,441 @@
+package tree
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kucuny/gods"
+)
+
+var errInvalidInterval = errors.New("tree: interval starts after it ends")
+
+// Interval is a closed interval [Start, End].
+type Interval struct {
+	Start, End interface{}
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%v, %v]", i.Start, i.End)
+}
+
+type intervalNode struct {
+	start, end  interface{}
+	value       interface{}
+	maxEnd      interface{}
+	height      int
+	left, right *intervalNode
+}
+
+func intervalHeight(n *intervalNode) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+// IntervalTree maps closed intervals to values. It is an AVL tree ordered
+// by start and then end, where every node also records the largest end in
+// its subtree, so that overlap and stabbing queries skip every subtree that
+// ends too early or starts too late. Insert, Delete and Get take O(log n). A
+// query reporting k intervals takes O(min(n, (k+1) log n)): a visited node
+// that does not overlap may still have matches below it, so the search
+// cannot be charged to the results alone. The comparer orders endpoints.
+type IntervalTree struct {
+	root     *intervalNode
+	count    int
+	comparer CompareFunc
+	mutex    gods.Locker
+}
+
+func NewIntervalTree(comparer Comparer, options ...gods.Option) *IntervalTree {
+	opts := gods.NewOptions(options...)
+
+	return &IntervalTree{
+		root:     nil,
+		count:    0,
+		comparer: OrderOf(comparer, opts),
+		mutex:    opts.Locker(),
+	}
+}
+
+func (t *IntervalTree) Len() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.count
+}
+
+func (t *IntervalTree) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+func (t *IntervalTree) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.root = nil
+	t.count = 0
+}
+
+func (t *IntervalTree) Height() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return intervalHeight(t.root)
+}
+
+// Insert maps [start, end] to value and reports whether the interval was
+// new. An interval that is already present has its value replaced.
+func (t *IntervalTree) Insert(start, end, value interface{}) (bool, error) {
+	if t.comparer.Compare(start, end) > 0 {
+		return false, errInvalidInterval
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var added bool
+
+	t.root, added = t.insert(t.root, start, end, value)
+
+	if added {
+		t.count++
+	}
+
+	return added, nil
+}
+
+func (t *IntervalTree) Delete(start, end interface{}) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var removed bool
+
+	t.root, removed = t.remove(t.root, start, end)
+
+	if removed {
+		t.count--
+	}
+
+	return removed
+}
+
+func (t *IntervalTree) Get(start, end interface{}) (interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for node := t.root; node != nil; {
+		switch result := t.compare(start, end, node); {
+		case result < 0:
+			node = node.left
+		case result > 0:
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+
+	return nil, false
+}
+
+func (t *IntervalTree) Contains(start, end interface{}) bool {
+	_, ok := t.Get(start, end)
+	return ok
+}
+
+// Overlapping calls fn, ordered by start and then end, for each interval
+// sharing at least one point with [start, end] until fn returns false.
+func (t *IntervalTree) Overlapping(start, end interface{}, fn func(interval Interval, value interface{}) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	t.overlapping(t.root, start, end, fn)
+}
+
+// Containing calls fn, ordered by start and then end, for each interval
+// containing point until fn returns false.
+func (t *IntervalTree) Containing(point interface{}, fn func(interval Interval, value interface{}) bool) {
+	t.Overlapping(point, point, fn)
+}
+
+// Each calls fn for every interval, ordered by start and then end, until
+// fn returns false.
+func (t *IntervalTree) Each(fn func(interval Interval, value interface{}) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	var stack []*intervalNode
+
+	for node := t.root; node != nil || len(stack) > 0; node = node.right {
+		for ; node != nil; node = node.left {
+			stack = append(stack, node)
+		}
+
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !fn(Interval{Start: node.start, End: node.end}, node.value) {
+			return
+		}
+	}
+}
+
+func (t *IntervalTree) Intervals() []Interval {
+	intervals := make([]Interval, 0, t.Len())
+
+	t.Each(func(interval Interval, value interface{}) bool {
+		intervals = append(intervals, interval)
+		return true
+	})
+
+	return intervals
+}
+
+func (t *IntervalTree) Values() []interface{} {
+	values := make([]interface{}, 0, t.Len())
+
+	t.Each(func(interval Interval, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (t *IntervalTree) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "IntervalTree", t.Len(), func(fn func(key, value interface{}) bool) {
+		t.Each(func(interval Interval, value interface{}) bool {
+			return fn(interval, value)
+		})
+	})
+}
+
+func (t *IntervalTree) String() string {
+	return fmt.Sprint(t)
+}
+
+// Validate checks the ordering, the balance, the heights and the recorded
+// largest ends.
+func (t *IntervalTree) Validate() error {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	count, err := t.validate(t.root, nil, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if count != t.count {
+		return fmt.Errorf("tree: count is %d but %d nodes are reachable", t.count, count)
+	}
+
+	return nil
+}
+
+func (t *IntervalTree) validate(node, lower, upper *intervalNode) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+
+	if t.comparer.Compare(node.start, node.end) > 0 {
+		return 0, fmt.Errorf("tree: [%v, %v] starts after it ends", node.start, node.end)
+	}
+
+	if lower != nil && t.compare(lower.start, lower.end, node) >= 0 {
+		return 0, fmt.Errorf("tree: [%v, %v] is not after its ancestor [%v, %v]", node.start, node.end, lower.start, lower.end)
+	}
+
+	if upper != nil && t.compare(upper.start, upper.end, node) <= 0 {
+		return 0, fmt.Errorf("tree: [%v, %v] is not before its ancestor [%v, %v]", node.start, node.end, upper.start, upper.end)
+	}
+
+	left, err := t.validate(node.left, lower, node)
+
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := t.validate(node.right, node, upper)
+
+	if err != nil {
+		return 0, err
+	}
+
+	leftHeight, rightHeight := intervalHeight(node.left), intervalHeight(node.right)
+
+	if leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+		return 0, fmt.Errorf("tree: [%v, %v] is unbalanced (%d, %d)", node.start, node.end, leftHeight, rightHeight)
+	}
+
+	if height := maxHeight(leftHeight, rightHeight) + 1; node.height != height {
+		return 0, fmt.Errorf("tree: [%v, %v] has height %d, expected %d", node.start, node.end, node.height, height)
+	}
+
+	maxEnd := node.end
+
+	for _, child := range []*intervalNode{node.left, node.right} {
+		if child != nil && t.comparer.Compare(child.maxEnd, maxEnd) > 0 {
+			maxEnd = child.maxEnd
+		}
+	}
+
+	if t.comparer.Compare(node.maxEnd, maxEnd) != 0 {
+		return 0, fmt.Errorf("tree: [%v, %v] records largest end %v, expected %v", node.start, node.end, node.maxEnd, maxEnd)
+	}
+
+	return left + right + 1, nil
+}
+
+// compare orders [start, end] against node by start and then end.
+func (t *IntervalTree) compare(start, end interface{}, node *intervalNode) int {
+	if result := t.comparer.Compare(start, node.start); result != 0 {
+		return result
+	}
+
+	return t.comparer.Compare(end, node.end)
+}
+
+func (t *IntervalTree) overlapping(node *intervalNode, start, end interface{}, fn func(interval Interval, value interface{}) bool) bool {
+	// Nothing below ends at or after start.
+	if node == nil || t.comparer.Compare(node.maxEnd, start) < 0 {
+		return true
+	}
+
+	if !t.overlapping(node.left, start, end, fn) {
+		return false
+	}
+
+	// This node and everything to its right start after end.
+	if t.comparer.Compare(node.start, end) > 0 {
+		return true
+	}
+
+	if t.comparer.Compare(node.end, start) >= 0 && !fn(Interval{Start: node.start, End: node.end}, node.value) {
+		return false
+	}
+
+	return t.overlapping(node.right, start, end, fn)
+}
+
+func (t *IntervalTree) insert(node *intervalNode, start, end, value interface{}) (*intervalNode, bool) {
+	if node == nil {
+		return &intervalNode{start: start, end: end, value: value, maxEnd: end, height: 1}, true
+	}
+
+	var added bool
+
+	switch result := t.compare(start, end, node); {
+	case result < 0:
+		node.left, added = t.insert(node.left, start, end, value)
+	case result > 0:
+		node.right, added = t.insert(node.right, start, end, value)
+	default:
+		node.value = value
+
+		return node, false
+	}
+
+	return t.balance(node), added
+}
+
+func (t *IntervalTree) remove(node *intervalNode, start, end interface{}) (*intervalNode, bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	var removed bool
+
+	switch result := t.compare(start, end, node); {
+	case result < 0:
+		node.left, removed = t.remove(node.left, start, end)
+	case result > 0:
+		node.right, removed = t.remove(node.right, start, end)
+	default:
+		if node.left == nil {
+			return node.right, true
+		}
+
+		if node.right == nil {
+			return node.left, true
+		}
+
+		min, right := t.removeMin(node.right)
+		min.left, min.right = node.left, right
+
+		return t.balance(min), true
+	}
+
+	if !removed {
+		return node, false
+	}
+
+	return t.balance(node), true
+}
+
+func (t *IntervalTree) removeMin(node *intervalNode) (*intervalNode, *intervalNode) {
+	if node.left == nil {
+		return node, node.right
+	}
+
+	min, left := t.removeMin(node.left)
+	node.left = left
+
+	return min, t.balance(node)
+}
+
+// update recomputes the height and the largest end of node from its
+// children.
+func (t *IntervalTree) update(node *intervalNode) {
+	node.height = maxHeight(intervalHeight(node.left), intervalHeight(node.right)) + 1
+	node.maxEnd = node.end
+
+	if node.left != nil && t.comparer.Compare(node.left.maxEnd, node.maxEnd) > 0 {
+		node.maxEnd = node.left.maxEnd
+	}
+
+	if node.right != nil && t.comparer.Compare(node.right.maxEnd, node.maxEnd) > 0 {
+		node.maxEnd = node.right.maxEnd
+	}
+}
+
+// balance updates node and restores the AVL invariant when its subtree
+// heights differ by two.
+func (t *IntervalTree) balance(node *intervalNode) *intervalNode {
+	t.update(node)
+
+	switch hl, hr := intervalHeight(node.left), intervalHeight(node.right); {
+	case hl > hr+1:
+		if intervalHeight(node.left.left) < intervalHeight(node.left.right) {
+			node.left = t.rotateLeft(node.left)
+		}
+
+		return t.rotateRight(node)
+	case hr > hl+1:
+		if intervalHeight(node.right.right) < intervalHeight(node.right.left) {
+			node.right = t.rotateRight(node.right)
+		}
+
+		return t.rotateLeft(node)
+	}
+
+	return node
+}
+
+func (t *IntervalTree) rotateLeft(node *intervalNode) *intervalNode {
+	pivot := node.right
+	node.right, pivot.left = pivot.left, node
+	t.update(node)
+	t.update(pivot)
+
+	return pivot
+}
+
+func (t *IntervalTree) rotateRight(node *intervalNode) *intervalNode {
+	pivot := node.left
+	node.left, pivot.right = pivot.right, node
+	t.update(node)
+	t.update(pivot)
+
+	return pivot
+}
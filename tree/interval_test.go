@@ -0,0 +1,239 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type IntervalTreeTestSuite struct {
+	suite.Suite
+	tree *IntervalTree
+}
+
+func (suite *IntervalTreeTestSuite) SetupTest() {
+	suite.tree = NewIntervalTree(IntegerComparer)
+
+	for _, reservation := range []struct {
+		start, end int
+		name       string
+	}{
+		{9, 11, "standup"},
+		{10, 12, "review"},
+		{13, 14, "lunch"},
+		{8, 17, "shift"},
+		{15, 16, "demo"},
+		{12, 12, "call"},
+	} {
+		added, err := suite.tree.Insert(reservation.start, reservation.end, reservation.name)
+		suite.NoError(err)
+		suite.True(added)
+	}
+}
+
+func (suite *IntervalTreeTestSuite) overlapping(start, end int) []interface{} {
+	var names []interface{}
+
+	suite.tree.Overlapping(start, end, func(interval Interval, value interface{}) bool {
+		names = append(names, value)
+		return true
+	})
+
+	return names
+}
+
+func (suite *IntervalTreeTestSuite) containing(point int) []interface{} {
+	var names []interface{}
+
+	suite.tree.Containing(point, func(interval Interval, value interface{}) bool {
+		names = append(names, value)
+		return true
+	})
+
+	return names
+}
+
+func (suite *IntervalTreeTestSuite) TestIntervalTree() {
+	suite.Equal(6, suite.tree.Len())
+	suite.Equal([]Interval{{8, 17}, {9, 11}, {10, 12}, {12, 12}, {13, 14}, {15, 16}}, suite.tree.Intervals())
+	suite.Equal([]interface{}{"shift", "standup", "review", "call", "lunch", "demo"}, suite.tree.Values())
+	suite.NoError(suite.tree.Validate())
+
+	value, ok := suite.tree.Get(13, 14)
+	suite.True(ok)
+	suite.Equal("lunch", value)
+
+	_, ok = suite.tree.Get(13, 15)
+	suite.False(ok)
+
+	added, err := suite.tree.Insert(13, 14, "long lunch")
+	suite.NoError(err)
+	suite.False(added)
+	value, _ = suite.tree.Get(13, 14)
+	suite.Equal("long lunch", value)
+
+	_, err = suite.tree.Insert(5, 4, "backwards")
+	suite.ErrorIs(err, errInvalidInterval)
+	suite.Equal(6, suite.tree.Len())
+
+	suite.True(suite.tree.Delete(8, 17))
+	suite.False(suite.tree.Delete(8, 17))
+	suite.False(suite.tree.Contains(8, 17))
+	suite.Equal(5, suite.tree.Len())
+	suite.NoError(suite.tree.Validate())
+
+	suite.tree.Clear()
+	suite.True(suite.tree.IsEmpty())
+	suite.Equal(0, suite.tree.Height())
+	suite.Nil(suite.overlapping(0, 100))
+}
+
+func (suite *IntervalTreeTestSuite) TestIntervalTreeOverlapping() {
+	suite.Equal([]interface{}{"shift", "standup", "review"}, suite.overlapping(10, 11))
+	suite.Equal([]interface{}{"shift", "review", "call", "lunch"}, suite.overlapping(12, 13))
+	suite.Equal([]interface{}{"shift", "demo"}, suite.overlapping(16, 20))
+	suite.Nil(suite.overlapping(18, 20))
+	suite.Nil(suite.overlapping(0, 7))
+	suite.Equal([]interface{}{"shift"}, suite.overlapping(0, 8))
+
+	var first []Interval
+
+	suite.tree.Overlapping(0, 100, func(interval Interval, value interface{}) bool {
+		first = append(first, interval)
+		return len(first) < 2
+	})
+
+	suite.Equal([]Interval{{8, 17}, {9, 11}}, first)
+}
+
+func (suite *IntervalTreeTestSuite) TestIntervalTreeContaining() {
+	suite.Equal([]interface{}{"shift", "review", "call"}, suite.containing(12))
+	suite.Equal([]interface{}{"shift", "standup"}, suite.containing(9))
+	suite.Equal([]interface{}{"shift"}, suite.containing(17))
+	suite.Nil(suite.containing(7))
+}
+
+func (suite *IntervalTreeTestSuite) TestIntervalTreeFormat() {
+	tree := NewIntervalTree(IntegerComparer)
+	tree.Insert(1, 3, "a")
+	tree.Insert(2, 2, "b")
+
+	suite.Equal("IntervalTree[[1, 3]:a [2, 2]:b]", tree.String())
+	suite.Equal("IntervalTree[[1, 3]:a ...1 more]", fmt.Sprintf("%.1v", tree))
+}
+
+func (suite *IntervalTreeTestSuite) TestIntervalTreeRandom() {
+	random := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree(IntegerComparer)
+	model := make(map[Interval]int)
+
+	for i := 0; i < 3000; i++ {
+		start := random.Intn(1000)
+		interval := Interval{start, start + random.Intn(50)}
+
+		if random.Intn(3) == 0 {
+			_, present := model[interval]
+			suite.Equal(present, tree.Delete(interval.Start, interval.End))
+			delete(model, interval)
+		} else {
+			_, present := model[interval]
+			added, err := tree.Insert(interval.Start, interval.End, i)
+			suite.NoError(err)
+			suite.Equal(!present, added)
+			model[interval] = i
+		}
+	}
+
+	suite.NoError(tree.Validate())
+	suite.Equal(len(model), tree.Len())
+	suite.LessOrEqual(tree.Height(), 15)
+
+	for query := 0; query < 200; query++ {
+		start := random.Intn(1100) - 50
+		end := start + random.Intn(30)
+
+		var expected []Interval
+
+		for interval := range model {
+			if interval.Start.(int) <= end && interval.End.(int) >= start {
+				expected = append(expected, interval)
+			}
+		}
+
+		sort.Slice(expected, func(i, j int) bool {
+			if expected[i].Start != expected[j].Start {
+				return expected[i].Start.(int) < expected[j].Start.(int)
+			}
+
+			return expected[i].End.(int) < expected[j].End.(int)
+		})
+
+		var found []Interval
+
+		tree.Overlapping(start, end, func(interval Interval, value interface{}) bool {
+			suite.Equal(model[interval], value)
+			found = append(found, interval)
+			return true
+		})
+
+		suite.Equal(expected, found)
+	}
+}
+
+func TestIntervalTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(IntervalTreeTestSuite))
+}
+
+func FuzzIntervalTree(f *testing.F) {
+	f.Add([]byte{0, 10, 5, 0, 3, 2, 1, 10, 5, 2, 4, 1})
+	f.Add([]byte{0, 1, 1, 0, 1, 0, 2, 1, 1, 1, 0, 0})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		tree := NewIntervalTree(IntegerComparer)
+		model := make(map[Interval]bool)
+
+		for i := 0; i+2 < len(ops); i += 3 {
+			start, end := int(ops[i+1]), int(ops[i+1])+int(ops[i+2]%32)
+
+			switch ops[i] % 3 {
+			case 0:
+				if added, _ := tree.Insert(start, end, nil); added == model[Interval{start, end}] {
+					t.Fatalf("insert [%d, %d] disagrees with model", start, end)
+				}
+
+				model[Interval{start, end}] = true
+			case 1:
+				if tree.Delete(start, end) != model[Interval{start, end}] {
+					t.Fatalf("delete [%d, %d] disagrees with model", start, end)
+				}
+
+				delete(model, Interval{start, end})
+			case 2:
+				count := 0
+
+				for interval := range model {
+					if interval.Start.(int) <= end && interval.End.(int) >= start {
+						count++
+					}
+				}
+
+				found := 0
+
+				tree.Overlapping(start, end, func(interval Interval, value interface{}) bool {
+					found++
+					return true
+				})
+
+				if found != count {
+					t.Fatalf("[%d, %d] overlaps %d intervals, found %d", start, end, count, found)
+				}
+			}
+
+			if err := tree.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
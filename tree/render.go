@@ -0,0 +1,210 @@
+package tree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// BinaryNode is a node of a binary tree that the renderers can walk. Missing
+// children must be returned as a nil interface, not a typed nil pointer.
+type BinaryNode interface {
+	GetValue() interface{}
+	Children() (left, right BinaryNode)
+}
+
+type DotOptions struct {
+	Name       string
+	NilLeaves  bool
+	Label      func(node BinaryNode) string
+	Attributes func(node BinaryNode) map[string]string
+}
+
+type DotOption func(options *DotOptions)
+
+func WithGraphName(name string) DotOption {
+	return func(options *DotOptions) {
+		options.Name = name
+	}
+}
+
+// WithNilLeaves draws missing children as points, which also shows whether
+// an only child is on the left or the right.
+func WithNilLeaves() DotOption {
+	return func(options *DotOptions) {
+		options.NilLeaves = true
+	}
+}
+
+func WithLabel(label func(node BinaryNode) string) DotOption {
+	return func(options *DotOptions) {
+		options.Label = label
+	}
+}
+
+// WithAttributes adds Graphviz attributes such as color or fillcolor to each
+// node, for example to show red-black colors or AVL heights.
+func WithAttributes(attributes func(node BinaryNode) map[string]string) DotOption {
+	return func(options *DotOptions) {
+		options.Attributes = attributes
+	}
+}
+
+// WriteDot writes the tree rooted at root as a Graphviz digraph.
+func WriteDot(w io.Writer, root BinaryNode, options ...DotOption) error {
+	opts := &DotOptions{
+		Name: "tree",
+		Label: func(node BinaryNode) string {
+			return fmt.Sprint(node.GetValue())
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	writer := bufio.NewWriter(w)
+	nodes, nils := 0, 0
+
+	var walk func(node BinaryNode) string
+
+	walk = func(node BinaryNode) string {
+		id := fmt.Sprintf("n%d", nodes)
+		nodes++
+
+		fmt.Fprintf(writer, "\t%s [label=\"%s\"", id, dotEscape(opts.Label(node)))
+
+		if opts.Attributes != nil {
+			attributes := opts.Attributes(node)
+			keys := make([]string, 0, len(attributes))
+
+			for key := range attributes {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				fmt.Fprintf(writer, ", %s=\"%s\"", key, dotEscape(attributes[key]))
+			}
+		}
+
+		writer.WriteString("];\n")
+
+		left, right := node.Children()
+
+		for _, child := range []BinaryNode{left, right} {
+			if child != nil {
+				fmt.Fprintf(writer, "\t%s -> %s;\n", id, walk(child))
+			} else if opts.NilLeaves {
+				fmt.Fprintf(writer, "\tnil%d [shape=point];\n\t%s -> nil%d;\n", nils, id, nils)
+				nils++
+			}
+		}
+
+		return id
+	}
+
+	fmt.Fprintf(writer, "digraph \"%s\" {\n", dotEscape(opts.Name))
+
+	if root != nil {
+		walk(root)
+	}
+
+	writer.WriteString("}\n")
+
+	return writer.Flush()
+}
+
+// ASCII draws the tree rooted at root top down, one line per row.
+func ASCII(root BinaryNode) string {
+	if root == nil {
+		return ""
+	}
+
+	lines, _, _ := asciiLines(root)
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func asciiLines(node BinaryNode) ([]string, int, int) {
+	label := fmt.Sprint(node.GetValue())
+	width := utf8.RuneCountInString(label)
+	left, right := node.Children()
+
+	if left == nil && right == nil {
+		return []string{label}, width, width / 2
+	}
+
+	if right == nil {
+		lines, n, x := asciiLines(left)
+		first := spaces(x+1) + underscores(n-x-1) + label
+		second := spaces(x) + "/" + spaces(n-x-1+width)
+
+		return append([]string{first, second}, pad(lines, width, false)...), n + width, n + width/2
+	}
+
+	if left == nil {
+		lines, n, x := asciiLines(right)
+		first := label + underscores(x) + spaces(n-x)
+		second := spaces(width+x) + "\\" + spaces(n-x-1)
+
+		return append([]string{first, second}, pad(lines, width, true)...), n + width, width / 2
+	}
+
+	leftLines, n, x := asciiLines(left)
+	rightLines, m, y := asciiLines(right)
+
+	first := spaces(x+1) + underscores(n-x-1) + label + underscores(y) + spaces(m-y)
+	second := spaces(x) + "/" + spaces(n-x-1+width+y) + "\\" + spaces(m-y-1)
+	lines := []string{first, second}
+
+	for i := 0; i < len(leftLines) || i < len(rightLines); i++ {
+		l, r := spaces(n), spaces(m)
+
+		if i < len(leftLines) {
+			l = leftLines[i]
+		}
+
+		if i < len(rightLines) {
+			r = rightLines[i]
+		}
+
+		lines = append(lines, l+spaces(width)+r)
+	}
+
+	return lines, n + m + width, n + width/2
+}
+
+func pad(lines []string, gap int, before bool) []string {
+	padded := make([]string, len(lines))
+
+	for i, line := range lines {
+		if before {
+			padded[i] = spaces(gap) + line
+		} else {
+			padded[i] = line + spaces(gap)
+		}
+	}
+
+	return padded
+}
+
+func spaces(n int) string {
+	return strings.Repeat(" ", n)
+}
+
+func underscores(n int) string {
+	return strings.Repeat("_", n)
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
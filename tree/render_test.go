@@ -0,0 +1,124 @@
+package tree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type RenderTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RenderTestSuite) tree(values ...int) *BinarySearchTree {
+	b := NewBinarySearchTree(IntegerComparer)
+
+	for _, value := range values {
+		b.Insert(value)
+	}
+
+	return b
+}
+
+func (suite *RenderTestSuite) TestASCII() {
+	suite.Equal("", ASCII(nil))
+	suite.Equal("1\n", suite.tree(1).ASCII())
+
+	expected := strings.Join([]string{
+		"      __50_____",
+		"     /         \\",
+		"    30_     __70_",
+		"   /   \\   /     \\",
+		"  20  40  60_   80_",
+		" /           \\     \\",
+		"10          65    100",
+		"",
+	}, "\n")
+
+	suite.Equal(expected, suite.tree(50, 30, 70, 20, 40, 60, 80, 10, 65, 100).ASCII())
+}
+
+func (suite *RenderTestSuite) TestASCIIOnlyChildren() {
+	suite.Equal(" 3\n/\n1\n", suite.tree(3, 1).ASCII())
+	suite.Equal("1\n \\\n 3\n", suite.tree(1, 3).ASCII())
+	suite.Equal(" 10\n/\n5\n", suite.tree(10, 5).ASCII())
+}
+
+func (suite *RenderTestSuite) TestWriteDot() {
+	var buffer bytes.Buffer
+
+	suite.NoError(suite.tree(2, 1, 3).WriteDot(&buffer))
+	suite.Equal(`digraph "tree" {
+	n0 [label="2"];
+	n1 [label="1"];
+	n0 -> n1;
+	n2 [label="3"];
+	n0 -> n2;
+}
+`, buffer.String())
+
+	buffer.Reset()
+	suite.NoError(WriteDot(&buffer, nil, WithGraphName(`my "tree"`)))
+	suite.Equal("digraph \"my \\\"tree\\\"\" {\n}\n", buffer.String())
+}
+
+func (suite *RenderTestSuite) TestWriteDotOptions() {
+	var buffer bytes.Buffer
+
+	b := suite.tree(1, 2)
+	err := b.WriteDot(&buffer,
+		WithNilLeaves(),
+		WithLabel(func(node BinaryNode) string { return fmt.Sprintf("v=%v\n\"x\"", node.GetValue()) }),
+		WithAttributes(func(node BinaryNode) map[string]string {
+			if node.GetValue() == 2 {
+				return map[string]string{"style": "filled", "color": "red"}
+			}
+
+			return nil
+		}),
+	)
+
+	suite.NoError(err)
+	suite.Equal(`digraph "tree" {
+	n0 [label="v=1\n\"x\""];
+	nil0 [shape=point];
+	n0 -> nil0;
+	n1 [label="v=2\n\"x\"", color="red", style="filled"];
+	nil1 [shape=point];
+	n1 -> nil1;
+	nil2 [shape=point];
+	n1 -> nil2;
+	n0 -> n1;
+}
+`, buffer.String())
+}
+
+func (suite *RenderTestSuite) TestWriteDotError() {
+	broken := errors.New("broken")
+	suite.ErrorIs(suite.tree(1).WriteDot(failingWriter{broken}), broken)
+}
+
+func (suite *RenderTestSuite) TestNodeChildren() {
+	b := suite.tree(2, 1)
+	left, right := b.root.Children()
+
+	suite.Equal(1, left.GetValue())
+	suite.Nil(right)
+	suite.Equal(b.root.left, b.root.Left())
+	suite.Nil(b.root.Right())
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (f failingWriter) Write(p []byte) (int, error) {
+	return 0, f.err
+}
+
+func TestRenderTestSuite(t *testing.T) {
+	suite.Run(t, new(RenderTestSuite))
+}
@@ -0,0 +1,95 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+)
+
+var (
+	_ gods.Iterable = (*BinarySearchTreeSnapshot)(nil)
+)
+
+// BinarySearchTreeSnapshot is a read-only view of a BinarySearchTree as it
+// was when Snapshot was called. Reads never take the tree's lock.
+type BinarySearchTreeSnapshot struct {
+	tree *BinarySearchTree
+}
+
+// Snapshot returns a view of the current contents in constant time. Nodes
+// are shared with the tree and copied by the tree on its next write to them.
+func (b *BinarySearchTree) Snapshot() *BinarySearchTreeSnapshot {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.gen++
+
+	return &BinarySearchTreeSnapshot{
+		tree: &BinarySearchTree{
+			root:     b.root,
+			count:    b.count,
+			comparer: b.comparer,
+			mutex:    gods.NewLocker(gods.NoLock),
+			element:  b.element,
+			codec:    b.codec,
+		},
+	}
+}
+
+func (s *BinarySearchTreeSnapshot) Len() int {
+	return s.tree.Len()
+}
+
+func (s *BinarySearchTreeSnapshot) IsEmpty() bool {
+	return s.tree.IsEmpty()
+}
+
+func (s *BinarySearchTreeSnapshot) Contains(value interface{}) bool {
+	return s.tree.Contains(value)
+}
+
+func (s *BinarySearchTreeSnapshot) Values() []interface{} {
+	return s.tree.Values()
+}
+
+func (s *BinarySearchTreeSnapshot) Each(fn func(value interface{}) bool) {
+	s.tree.Each(fn)
+}
+
+func (s *BinarySearchTreeSnapshot) First() (interface{}, bool) {
+	return s.tree.First()
+}
+
+func (s *BinarySearchTreeSnapshot) Last() (interface{}, bool) {
+	return s.tree.Last()
+}
+
+func (s *BinarySearchTreeSnapshot) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "BinarySearchTreeSnapshot", s.Len(), s.Each)
+}
+
+func (s *BinarySearchTreeSnapshot) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *BinarySearchTreeSnapshot) WriteDot(w io.Writer, options ...DotOption) error {
+	return s.tree.WriteDot(w, options...)
+}
+
+func (s *BinarySearchTreeSnapshot) ASCII() string {
+	return s.tree.ASCII()
+}
+
+func (s *BinarySearchTreeSnapshot) MarshalJSON() ([]byte, error) {
+	return s.tree.MarshalJSON()
+}
+
+// WriteTo writes the same stream as BinarySearchTree.WriteTo, so a backup
+// taken from a snapshot can be restored with ReadFrom.
+func (s *BinarySearchTreeSnapshot) WriteTo(w io.Writer) (int64, error) {
+	return s.tree.WriteTo(w)
+}
+
+func (s *BinarySearchTreeSnapshot) MarshalBinary() ([]byte, error) {
+	return s.tree.MarshalBinary()
+}
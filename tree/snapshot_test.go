@@ -0,0 +1,165 @@
+package tree
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SnapshotTestSuite) tree(values ...int) *BinarySearchTree {
+	b := NewBinarySearchTree(IntegerComparer, gods.WithCodec(gods.IntCodec))
+
+	for _, value := range values {
+		b.Insert(value)
+	}
+
+	return b
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotIsolation() {
+	b := suite.tree(50, 30, 70, 20, 40, 60, 80)
+	snapshot := b.Snapshot()
+	before := snapshot.ASCII()
+
+	suite.True(b.Delete(50))
+	suite.True(b.Delete(20))
+	suite.True(b.Insert(65))
+	suite.True(b.Insert(10))
+
+	suite.Equal([]interface{}{10, 30, 40, 60, 65, 70, 80}, b.Values())
+	suite.Equal([]interface{}{20, 30, 40, 50, 60, 70, 80}, snapshot.Values())
+	suite.Equal(before, snapshot.ASCII())
+	suite.Equal(7, snapshot.Len())
+	suite.True(snapshot.Contains(50))
+	suite.False(snapshot.Contains(65))
+	suite.NoError(b.Validate())
+	suite.NoError(snapshot.tree.Validate())
+
+	first, ok := snapshot.First()
+	suite.True(ok)
+	suite.Equal(20, first)
+
+	last, ok := snapshot.Last()
+	suite.True(ok)
+	suite.Equal(80, last)
+
+	b.Clear()
+
+	suite.Equal(7, snapshot.Len())
+	suite.True(b.Snapshot().IsEmpty())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotRemoveReturnsDetachedNode() {
+	b := suite.tree(2, 1, 3)
+	snapshot := b.Snapshot()
+
+	removed := b.Remove(2)
+
+	suite.Equal(2, removed.Value)
+	suite.Nil(removed.Left())
+	suite.Nil(removed.Right())
+	suite.Equal([]interface{}{1, 2, 3}, snapshot.Values())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotRandomized() {
+	r := rand.New(rand.NewSource(42))
+	b := suite.tree()
+
+	var snapshots []*BinarySearchTreeSnapshot
+	var expected [][]interface{}
+
+	for i := 0; i < 2000; i++ {
+		value := r.Intn(200)
+
+		if r.Intn(2) == 0 {
+			b.Insert(value)
+		} else {
+			b.Delete(value)
+		}
+
+		if i%100 == 0 {
+			snapshots = append(snapshots, b.Snapshot())
+			expected = append(expected, b.Values())
+		}
+	}
+
+	suite.NoError(b.Validate())
+
+	for i, snapshot := range snapshots {
+		suite.Equal(expected[i], snapshot.Values())
+		suite.NoError(snapshot.tree.Validate())
+	}
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotEncoding() {
+	b := suite.tree(2, 1, 3)
+	snapshot := b.Snapshot()
+	b.Insert(4)
+
+	data, err := json.Marshal(snapshot)
+	suite.NoError(err)
+	suite.JSONEq(`[1,2,3]`, string(data))
+
+	data, err = snapshot.MarshalBinary()
+	suite.NoError(err)
+
+	restored := suite.tree()
+	suite.NoError(restored.UnmarshalBinary(data))
+	suite.Equal([]interface{}{1, 2, 3}, restored.Values())
+
+	var buf bytes.Buffer
+	suite.NoError(snapshot.WriteDot(&buf))
+	suite.Contains(buf.String(), "digraph")
+
+	suite.Equal("BinarySearchTreeSnapshot[1 2 3]", snapshot.String())
+	suite.Equal("BinarySearchTreeSnapshot[1 ...2 more]", fmt.Sprintf("%.1v", snapshot))
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotConcurrentWriters() {
+	b := suite.tree()
+
+	for _, value := range rand.New(rand.NewSource(1)).Perm(100) {
+		b.Insert(value)
+	}
+
+	snapshot := b.Snapshot()
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 1000; i++ {
+			b.Delete(i % 100)
+			b.Insert(i % 100)
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		sum := 0
+
+		snapshot.Each(func(value interface{}) bool {
+			sum += value.(int)
+			return true
+		})
+
+		suite.Equal(4950, sum)
+	}
+
+	wg.Wait()
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotTestSuite))
+}
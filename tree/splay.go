@@ -0,0 +1,478 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+	"sort"
+)
+
+var (
+	_ gods.Set     = (*SplayTree)(nil)
+	_ gods.Ordered = (*SplayTree)(nil)
+)
+
+// SplayTree is a self-adjusting binary search tree. Insert, Remove, Search
+// and Contains move the node they reach to the root, so recently accessed
+// values are found in a few steps and every operation is O(log n)
+// amortized. Because lookups restructure the tree they take the write lock.
+type SplayTree struct {
+	root     *Node
+	count    int
+	comparer CompareFunc
+	mutex    gods.Locker
+	element  gods.Decoder
+	codec    gods.Codec
+}
+
+func NewSplayTree(comparer Comparer, options ...gods.Option) *SplayTree {
+	opts := gods.NewOptions(options...)
+	_, codec := opts.Codecs()
+
+	return &SplayTree{
+		root:     nil,
+		count:    0,
+		comparer: OrderOf(comparer, opts),
+		mutex:    opts.Locker(),
+		element:  opts.ElementDecoder(),
+		codec:    codec,
+	}
+}
+
+func (s *SplayTree) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.count
+}
+
+func (s *SplayTree) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+func (s *SplayTree) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.root = nil
+	s.count = 0
+}
+
+// Root returns the most recently accessed node.
+func (s *SplayTree) Root() *Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.root
+}
+
+func (s *SplayTree) Insert(value interface{}) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.root == nil {
+		s.root = NewNode(value)
+		s.count++
+
+		return true
+	}
+
+	s.root = s.splay(s.root, value)
+	result := s.comparer.Compare(value, s.root.Value)
+
+	if result == 0 {
+		return false
+	}
+
+	node := NewNode(value)
+
+	if result < 0 {
+		node.left, node.right = s.root.left, s.root
+		s.root.left = nil
+	} else {
+		node.left, node.right = s.root, s.root.right
+		s.root.right = nil
+	}
+
+	s.root = node
+	s.count++
+
+	return true
+}
+
+func (s *SplayTree) Remove(value interface{}) *Node {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.root == nil {
+		return nil
+	}
+
+	s.root = s.splay(s.root, value)
+
+	if s.comparer.Compare(value, s.root.Value) != 0 {
+		return nil
+	}
+
+	removed := s.root
+
+	if removed.left == nil {
+		s.root = removed.right
+	} else {
+		// Every value on the left is smaller, so splaying for value brings
+		// the largest to the top with no right child.
+		s.root = s.splay(removed.left, value)
+		s.root.right = removed.right
+	}
+
+	removed.left, removed.right = nil, nil
+	s.count--
+
+	return removed
+}
+
+func (s *SplayTree) Delete(value interface{}) bool {
+	return s.Remove(value) != nil
+}
+
+func (s *SplayTree) Contains(value interface{}) bool {
+	return s.Search(value) != nil
+}
+
+// Search returns the node holding value and splays it to the root. When the
+// value is absent, the last node visited is splayed instead.
+func (s *SplayTree) Search(value interface{}) *Node {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.root == nil {
+		return nil
+	}
+
+	s.root = s.splay(s.root, value)
+
+	if s.comparer.Compare(value, s.root.Value) != 0 {
+		return nil
+	}
+
+	return s.root
+}
+
+func (s *SplayTree) Values() []interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	values := make([]interface{}, 0, s.count)
+
+	inOrder(s.root, func(node *Node) {
+		values = append(values, node.Value)
+	})
+
+	return values
+}
+
+func (s *SplayTree) Each(fn func(value interface{}) bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	eachNode(s.root, fn)
+}
+
+func (s *SplayTree) First() (interface{}, bool) {
+	if node := s.Min(); node != nil {
+		return node.Value, true
+	}
+
+	return nil, false
+}
+
+func (s *SplayTree) Last() (interface{}, bool) {
+	if node := s.Max(); node != nil {
+		return node.Value, true
+	}
+
+	return nil, false
+}
+
+// Min returns the smallest node without splaying it.
+func (s *SplayTree) Min() *Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.root == nil {
+		return nil
+	}
+
+	return minNode(s.root)
+}
+
+// Max returns the largest node without splaying it.
+func (s *SplayTree) Max() *Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.root == nil {
+		return nil
+	}
+
+	return maxNode(s.root)
+}
+
+func (s *SplayTree) TraversePreOrder(runner Runner) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	preOrder(s.root, runner)
+}
+
+func (s *SplayTree) TraversePreOrderResult(runner Runner, resultChan chan interface{}) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	preOrderResult(s.root, runner, resultChan)
+	close(resultChan)
+}
+
+func (s *SplayTree) TraversePostOrder(runner Runner) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	postOrder(s.root, runner)
+}
+
+func (s *SplayTree) TraversePostOrderResult(runner Runner, resultChan chan interface{}) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	postOrderResult(s.root, runner, resultChan)
+	close(resultChan)
+}
+
+func (s *SplayTree) TraverseInOrder(runner Runner) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	inOrder(s.root, runner)
+}
+
+func (s *SplayTree) TraverseInOrderResult(runner Runner, resultChan chan interface{}) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	inOrderResult(s.root, runner, resultChan)
+	close(resultChan)
+}
+
+func (s *SplayTree) TraverseLevelOrder(runner Runner) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	levelOrder(s.root, runner)
+}
+
+func (s *SplayTree) TraverseLevelOrderResult(runner Runner, resultChan chan interface{}) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	levelOrderResult(s.root, runner, resultChan)
+	close(resultChan)
+}
+
+func (s *SplayTree) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "SplayTree", s.Len(), s.Each)
+}
+
+func (s *SplayTree) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *SplayTree) WriteDot(w io.Writer, options ...DotOption) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return WriteDot(w, binaryNode(s.root), options...)
+}
+
+func (s *SplayTree) ASCII() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return ASCII(binaryNode(s.root))
+}
+
+// MarshalJSON encodes the tree as an array in sorted order.
+func (s *SplayTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Values())
+}
+
+// UnmarshalJSON replaces the contents of the tree with a balanced tree built
+// from the decoded values. Duplicates are dropped.
+func (s *SplayTree) UnmarshalJSON(data []byte) error {
+	if s.comparer == nil {
+		return errNoComparer
+	}
+
+	values, err := gods.UnmarshalArray(data, s.element)
+
+	if err != nil {
+		return err
+	}
+
+	s.replace(values)
+
+	return nil
+}
+
+// WriteTo streams the values in sorted order, in the same format as
+// BinarySearchTree.WriteTo.
+func (s *SplayTree) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindSet, s.codec, s.Each)
+}
+
+func (s *SplayTree) ReadFrom(r io.Reader) (int64, error) {
+	if s.comparer == nil {
+		return 0, errNoComparer
+	}
+
+	var values []interface{}
+
+	n, err := gods.ReadValues(r, gods.KindSet, s.codec, func(value interface{}) {
+		values = append(values, value)
+	})
+
+	if err == nil {
+		s.replace(values)
+	}
+
+	return n, err
+}
+
+func (s *SplayTree) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(s)
+}
+
+func (s *SplayTree) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(s, data)
+}
+
+func (s *SplayTree) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+func (s *SplayTree) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// Validate checks the ordering and the count.
+func (s *SplayTree) Validate() error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var previous *Node
+	var err error
+	count := 0
+
+	inOrder(s.root, func(node *Node) {
+		if err == nil && previous != nil && s.comparer.Compare(previous.Value, node.Value) >= 0 {
+			err = fmt.Errorf("tree: %v is not greater than %v", node.Value, previous.Value)
+		}
+
+		previous = node
+		count++
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if count != s.count {
+		return fmt.Errorf("tree: count is %d but %d nodes are reachable", s.count, count)
+	}
+
+	return nil
+}
+
+func (s *SplayTree) replace(values []interface{}) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return s.comparer.Compare(values[i], values[j]) < 0
+	})
+
+	unique := values[:0]
+
+	for _, value := range values {
+		if len(unique) == 0 || s.comparer.Compare(unique[len(unique)-1], value) != 0 {
+			unique = append(unique, value)
+		}
+	}
+
+	var build func(values []interface{}) *Node
+
+	build = func(values []interface{}) *Node {
+		if len(values) == 0 {
+			return nil
+		}
+
+		middle := len(values) / 2
+		node := NewNode(values[middle])
+		node.left = build(values[:middle])
+		node.right = build(values[middle+1:])
+
+		return node
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.root = build(unique)
+	s.count = len(unique)
+}
+
+// splay performs a top-down splay of the subtree rooted at node: the node
+// holding value, or the last node on its search path, becomes the new root.
+func (s *SplayTree) splay(node *Node, value interface{}) *Node {
+	var header Node
+
+	left, right := &header, &header
+
+	for {
+		result := s.comparer.Compare(value, node.Value)
+
+		if result < 0 {
+			if node.left == nil {
+				break
+			}
+
+			if s.comparer.Compare(value, node.left.Value) < 0 {
+				child := node.left
+				node.left, child.right = child.right, node
+				node = child
+
+				if node.left == nil {
+					break
+				}
+			}
+
+			right.left = node
+			right = node
+			node = node.left
+		} else if result > 0 {
+			if node.right == nil {
+				break
+			}
+
+			if s.comparer.Compare(value, node.right.Value) > 0 {
+				child := node.right
+				node.right, child.left = child.left, node
+				node = child
+
+				if node.right == nil {
+					break
+				}
+			}
+
+			left.right = node
+			left = node
+			node = node.right
+		} else {
+			break
+		}
+	}
+
+	left.right, right.left = node.left, node.right
+	node.left, node.right = header.right, header.left
+
+	return node
+}
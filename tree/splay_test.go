@@ -0,0 +1,256 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type SplayTreeTestSuite struct {
+	suite.Suite
+	splay *SplayTree
+}
+
+func (suite *SplayTreeTestSuite) SetupTest() {
+	suite.splay = NewSplayTree(IntegerComparer)
+}
+
+func (suite *SplayTreeTestSuite) TestSplayTreeInsertMovesToRoot() {
+	for _, value := range []int{20, 6, 7, 35, 10, 25, 8} {
+		suite.True(suite.splay.Insert(value))
+		suite.Equal(value, suite.splay.Root().Value)
+	}
+
+	suite.False(suite.splay.Insert(7))
+	suite.Equal(7, suite.splay.Root().Value)
+	suite.Equal(7, suite.splay.Len())
+	suite.Equal([]interface{}{6, 7, 8, 10, 20, 25, 35}, suite.splay.Values())
+	suite.NoError(suite.splay.Validate())
+}
+
+func (suite *SplayTreeTestSuite) TestSplayTreeSearch() {
+	for _, value := range []int{20, 6, 7, 35, 10, 25, 8} {
+		suite.splay.Insert(value)
+	}
+
+	node := suite.splay.Search(25)
+	suite.NotNil(node)
+	suite.Equal(25, node.Value)
+	suite.Same(node, suite.splay.Root())
+
+	suite.Nil(suite.splay.Search(30))
+	root := suite.splay.Root().Value
+	suite.True(root == 25 || root == 35)
+
+	suite.True(suite.splay.Contains(6))
+	suite.Equal(6, suite.splay.Root().Value)
+	suite.NoError(suite.splay.Validate())
+
+	suite.Nil(NewSplayTree(IntegerComparer).Search(1))
+}
+
+func (suite *SplayTreeTestSuite) TestSplayTreeRemove() {
+	for _, value := range []int{20, 6, 7, 35, 10, 25, 8} {
+		suite.splay.Insert(value)
+	}
+
+	node := suite.splay.Remove(10)
+	suite.NotNil(node)
+	suite.Equal(10, node.Value)
+	suite.Nil(node.left)
+	suite.Nil(node.right)
+	suite.Equal(8, suite.splay.Root().Value)
+
+	suite.Nil(suite.splay.Remove(10))
+	suite.True(suite.splay.Delete(6))
+	suite.False(suite.splay.Delete(6))
+
+	suite.Equal(5, suite.splay.Len())
+	suite.Equal([]interface{}{7, 8, 20, 25, 35}, suite.splay.Values())
+	suite.NoError(suite.splay.Validate())
+
+	for _, value := range []int{7, 8, 20, 25, 35} {
+		suite.True(suite.splay.Delete(value))
+	}
+
+	suite.True(suite.splay.IsEmpty())
+	suite.Nil(suite.splay.Root())
+	suite.Nil(suite.splay.Remove(1))
+}
+
+func (suite *SplayTreeTestSuite) TestSplayTreeMinMax() {
+	_, ok := suite.splay.First()
+	suite.False(ok)
+	suite.Nil(suite.splay.Min())
+	suite.Nil(suite.splay.Max())
+
+	for _, value := range []int{20, 6, 7, 35, 10} {
+		suite.splay.Insert(value)
+	}
+
+	first, _ := suite.splay.First()
+	last, _ := suite.splay.Last()
+	suite.Equal(6, first)
+	suite.Equal(35, last)
+	suite.Equal(10, suite.splay.Root().Value)
+}
+
+func (suite *SplayTreeTestSuite) TestSplayTreeTraverse() {
+	for _, value := range []int{20, 6, 35} {
+		suite.splay.Insert(value)
+	}
+
+	// Inserting 35 last leaves it at the root with 20 and then 6 on the left.
+	collect := func(traverse func(Runner, chan interface{})) []interface{} {
+		resultChan := make(chan interface{})
+		var result []interface{}
+
+		go traverse(func(node *Node) {}, resultChan)
+
+		for r := range resultChan {
+			result = append(result, r)
+		}
+
+		return result
+	}
+
+	suite.Equal([]interface{}{35, 20, 6}, collect(suite.splay.TraversePreOrderResult))
+	suite.Equal([]interface{}{6, 20, 35}, collect(suite.splay.TraverseInOrderResult))
+	suite.Equal([]interface{}{6, 20, 35}, collect(suite.splay.TraversePostOrderResult))
+	suite.Equal([]interface{}{35, 20, 6}, collect(suite.splay.TraverseLevelOrderResult))
+
+	var visited []interface{}
+	suite.splay.TraverseInOrder(func(node *Node) { visited = append(visited, node.Value) })
+	suite.splay.TraversePreOrder(func(node *Node) { visited = append(visited, node.Value) })
+	suite.splay.TraversePostOrder(func(node *Node) { visited = append(visited, node.Value) })
+	suite.splay.TraverseLevelOrder(func(node *Node) { visited = append(visited, node.Value) })
+	suite.Equal([]interface{}{6, 20, 35, 35, 20, 6, 6, 20, 35, 35, 20, 6}, visited)
+}
+
+func (suite *SplayTreeTestSuite) TestSplayTreeRandom() {
+	random := rand.New(rand.NewSource(1))
+	model := make(map[int]bool)
+
+	for i := 0; i < 5000; i++ {
+		value := random.Intn(500)
+
+		switch random.Intn(3) {
+		case 0:
+			suite.Equal(!model[value], suite.splay.Insert(value))
+			model[value] = true
+		case 1:
+			suite.Equal(model[value], suite.splay.Delete(value))
+			delete(model, value)
+		case 2:
+			suite.Equal(model[value], suite.splay.Contains(value))
+		}
+	}
+
+	expected := make([]int, 0, len(model))
+
+	for value := range model {
+		expected = append(expected, value)
+	}
+
+	sort.Ints(expected)
+
+	values := make([]int, 0, suite.splay.Len())
+
+	for _, value := range suite.splay.Values() {
+		values = append(values, value.(int))
+	}
+
+	suite.Equal(expected, values)
+	suite.NoError(suite.splay.Validate())
+}
+
+func (suite *SplayTreeTestSuite) TestSplayTreeEncoding() {
+	s := NewSplayTree(IntegerComparer, gods.WithElement[int](), gods.WithCodec(gods.IntCodec))
+
+	for _, value := range []int{3, 1, 2} {
+		s.Insert(value)
+	}
+
+	data, err := json.Marshal(s)
+	suite.NoError(err)
+	suite.JSONEq(`[1,2,3]`, string(data))
+
+	decoded := NewSplayTree(IntegerComparer, gods.WithElement[int]())
+	suite.NoError(json.Unmarshal([]byte(`[3,3,1,2]`), decoded))
+	suite.Equal([]interface{}{1, 2, 3}, decoded.Values())
+	suite.Equal(2, decoded.Root().Value)
+	suite.NoError(decoded.Validate())
+
+	data, err = s.MarshalBinary()
+	suite.NoError(err)
+
+	bst := NewBinarySearchTree(IntegerComparer, gods.WithCodec(gods.IntCodec))
+	suite.NoError(bst.UnmarshalBinary(data))
+	suite.Equal([]interface{}{1, 2, 3}, bst.Values())
+
+	var zero SplayTree
+	suite.Error(json.Unmarshal(data, &zero))
+
+	suite.Equal("SplayTree[1 2 3]", s.String())
+	suite.Equal("SplayTree[1 ...2 more]", fmt.Sprintf("%.1v", s))
+}
+
+func TestSplayTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(SplayTreeTestSuite))
+}
+
+func TestSplayTreeConformance(t *testing.T) {
+	suite.Run(t, &godstest.SetSuite{
+		New: func() gods.Set { return NewSplayTree(IntegerComparer) },
+	})
+}
+
+func FuzzSplayTree(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 5, 0, 15, 2, 5, 1, 10, 2, 7, 1, 5})
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 1, 2, 2, 3, 1, 1, 1, 3})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		tree := NewSplayTree(IntegerComparer)
+		model := make(map[int]bool)
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 3 {
+			case 0:
+				if tree.Insert(value) == model[value] {
+					t.Fatalf("insert %d disagrees with model", value)
+				}
+
+				model[value] = true
+			case 1:
+				if tree.Delete(value) != model[value] {
+					t.Fatalf("delete %d disagrees with model", value)
+				}
+
+				delete(model, value)
+			case 2:
+				if tree.Contains(value) != model[value] {
+					t.Fatalf("contains %d disagrees with model", value)
+				}
+
+				if model[value] && tree.Root().Value != value {
+					t.Fatalf("search %d did not splay it to the root", value)
+				}
+			}
+
+			if err := tree.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if tree.Len() != len(model) {
+				t.Fatalf("len is %d, model has %d", tree.Len(), len(model))
+			}
+		}
+	})
+}
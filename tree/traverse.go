@@ -0,0 +1,143 @@
+package tree
+
+import (
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/queue"
+)
+
+// The traversals below work on any tree of Nodes, so BinarySearchTree and
+// SplayTree share them.
+
+func eachNode(node *Node, fn func(value interface{}) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	return eachNode(node.left, fn) && fn(node.Value) && eachNode(node.right, fn)
+}
+
+func preOrder(node *Node, runner Runner) {
+	if node == nil {
+		return
+	}
+
+	runner(node)
+	preOrder(node.left, runner)
+	preOrder(node.right, runner)
+}
+
+func preOrderResult(node *Node, runner Runner, resultChan chan interface{}) {
+	if node == nil {
+		return
+	}
+
+	resultChan <- node.Value
+	runner(node)
+	preOrderResult(node.left, runner, resultChan)
+	preOrderResult(node.right, runner, resultChan)
+}
+
+func postOrder(node *Node, runner Runner) {
+	if node == nil {
+		return
+	}
+
+	postOrder(node.left, runner)
+	postOrder(node.right, runner)
+	runner(node)
+}
+
+func postOrderResult(node *Node, runner Runner, resultChan chan interface{}) {
+	if node == nil {
+		return
+	}
+
+	postOrderResult(node.left, runner, resultChan)
+	postOrderResult(node.right, runner, resultChan)
+	resultChan <- node.Value
+	runner(node)
+}
+
+func inOrder(node *Node, runner Runner) {
+	if node == nil {
+		return
+	}
+
+	inOrder(node.left, runner)
+	runner(node)
+	inOrder(node.right, runner)
+}
+
+func inOrderResult(node *Node, runner Runner, resultChan chan interface{}) {
+	if node == nil {
+		return
+	}
+
+	inOrderResult(node.left, runner, resultChan)
+	resultChan <- node.Value
+	runner(node)
+	inOrderResult(node.right, runner, resultChan)
+}
+
+func levelOrder(node *Node, runner Runner) {
+	if node == nil {
+		return
+	}
+
+	q := queue.NewQueue(gods.WithLock(gods.NoLock))
+	q.Push(node)
+
+	for q.Len() > 0 {
+		n := (*Node)(q.Pop().(*Node))
+
+		runner(n)
+
+		if n.left != nil {
+			q.Push(n.left)
+		}
+
+		if n.right != nil {
+			q.Push(n.right)
+		}
+	}
+}
+
+func levelOrderResult(node *Node, runner Runner, resultChan chan interface{}) {
+	if node == nil {
+		return
+	}
+
+	q := queue.NewQueue(gods.WithLock(gods.NoLock))
+	q.Push(node)
+
+	for q.Len() > 0 {
+		n := (*Node)(q.Pop().(*Node))
+
+		resultChan <- n.Value
+		runner(n)
+
+		if n.left != nil {
+			q.Push(n.left)
+		}
+
+		if n.right != nil {
+			q.Push(n.right)
+		}
+	}
+}
+
+func minNode(node *Node) *Node {
+	if node.left == nil {
+		return node
+	}
+
+	return minNode(node.left)
+}
+
+func maxNode(node *Node) *Node {
+	if node.right == nil {
+		return node
+	}
+
+	return maxNode(node.right)
+}
@@ -0,0 +1,679 @@
+package tree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+	"math/rand"
+	"sort"
+)
+
+var errTreapOrder = errors.New("tree: Merge and Join need every value on the left below every value on the right")
+
+// treapOwner marks the nodes a treap may modify in place, like bTreeOwner.
+type treapOwner struct {
+	_ byte
+}
+
+type treapNode struct {
+	value       interface{}
+	priority    uint64
+	size        int
+	left, right *treapNode
+	owner       *treapOwner
+}
+
+func treapSize(n *treapNode) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+var (
+	_ gods.Set     = (*Treap)(nil)
+	_ gods.Ordered = (*Treap)(nil)
+)
+
+// Treap is an ordered set balanced by random heap priorities. Split, Merge
+// and Join run in expected O(log n), and Union, Intersection and Difference
+// of sets of sizes m <= n in expected O(m log(n/m + 1)). They return new
+// treaps and leave their operands unchanged: the results share subtrees
+// with the operands, and every tree copies shared nodes before modifying
+// them.
+type Treap struct {
+	root     *treapNode
+	comparer CompareFunc
+	owner    *treapOwner
+	lock     gods.LockMode
+	mutex    gods.Locker
+	element  gods.Decoder
+	codec    gods.Codec
+}
+
+func NewTreap(comparer Comparer, options ...gods.Option) *Treap {
+	opts := gods.NewOptions(options...)
+	_, codec := opts.Codecs()
+
+	return &Treap{
+		root:     nil,
+		comparer: OrderOf(comparer, opts),
+		owner:    &treapOwner{},
+		lock:     opts.Lock,
+		mutex:    opts.Locker(),
+		element:  opts.ElementDecoder(),
+		codec:    codec,
+	}
+}
+
+func (t *Treap) Len() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return treapSize(t.root)
+}
+
+func (t *Treap) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+func (t *Treap) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.root = nil
+}
+
+func (t *Treap) Insert(value interface{}) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.search(value) != nil {
+		return false
+	}
+
+	t.root = t.insert(t.root, &treapNode{value: value, priority: rand.Uint64(), size: 1, owner: t.owner})
+
+	return true
+}
+
+func (t *Treap) Delete(value interface{}) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.search(value) == nil {
+		return false
+	}
+
+	t.root = t.remove(t.root, value)
+
+	return true
+}
+
+func (t *Treap) Contains(value interface{}) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.search(value) != nil
+}
+
+func (t *Treap) Values() []interface{} {
+	values := make([]interface{}, 0, t.Len())
+
+	t.Each(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (t *Treap) Each(fn func(value interface{}) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	var stack []*treapNode
+
+	for node := t.root; node != nil || len(stack) > 0; node = node.right {
+		for ; node != nil; node = node.left {
+			stack = append(stack, node)
+		}
+
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !fn(node.value) {
+			return
+		}
+	}
+}
+
+func (t *Treap) First() (interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.root == nil {
+		return nil, false
+	}
+
+	node := t.root
+
+	for node.left != nil {
+		node = node.left
+	}
+
+	return node.value, true
+}
+
+func (t *Treap) Last() (interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.root == nil {
+		return nil, false
+	}
+
+	node := t.root
+
+	for node.right != nil {
+		node = node.right
+	}
+
+	return node.value, true
+}
+
+// Split returns a treap with the values less than value and a treap with
+// the rest.
+func (t *Treap) Split(value interface{}) (*Treap, *Treap) {
+	root := t.share()
+	left, right := t.derive(), t.derive()
+
+	l, middle, r := left.split(root, value)
+
+	if middle != nil {
+		r = left.join(nil, middle, r)
+	}
+
+	left.root, right.root = l, r
+
+	return left, right
+}
+
+// Merge returns the concatenation of t and right. Every value of t must be
+// less than every value of right.
+func (t *Treap) Merge(right *Treap) (*Treap, error) {
+	l, r := t.share(), right.share()
+
+	if l != nil && r != nil && t.comparer.Compare(treapMax(l).value, treapMin(r).value) >= 0 {
+		return nil, errTreapOrder
+	}
+
+	result := t.derive()
+	result.root = result.merge(l, r)
+
+	return result, nil
+}
+
+// Join is Merge with value placed between t and right. Every value of t
+// must be less than value, and value less than every value of right.
+func (t *Treap) Join(value interface{}, right *Treap) (*Treap, error) {
+	l, r := t.share(), right.share()
+
+	if (l != nil && t.comparer.Compare(treapMax(l).value, value) >= 0) ||
+		(r != nil && t.comparer.Compare(value, treapMin(r).value) >= 0) {
+		return nil, errTreapOrder
+	}
+
+	result := t.derive()
+	result.root = result.join(l, &treapNode{value: value, priority: rand.Uint64(), size: 1, owner: result.owner}, r)
+
+	return result, nil
+}
+
+// Union returns the values in t or other. Where both hold equal values,
+// either may be kept.
+func (t *Treap) Union(other *Treap) *Treap {
+	a, b := t.share(), other.share()
+	result := t.derive()
+	result.root = result.union(a, b)
+
+	return result
+}
+
+// Intersection returns the values of t that other also holds.
+func (t *Treap) Intersection(other *Treap) *Treap {
+	a, b := t.share(), other.share()
+	result := t.derive()
+	result.root = result.intersection(a, b)
+
+	return result
+}
+
+// Difference returns the values of t that other does not hold.
+func (t *Treap) Difference(other *Treap) *Treap {
+	a, b := t.share(), other.share()
+	result := t.derive()
+	result.root = result.difference(a, b)
+
+	return result
+}
+
+// Clone returns a copy of the treap in O(1).
+func (t *Treap) Clone() *Treap {
+	root := t.share()
+	clone := t.derive()
+	clone.root = root
+
+	return clone
+}
+
+// Load replaces the contents of the treap in O(n log n), or O(n) when the
+// values are already sorted. Duplicates are dropped.
+func (t *Treap) Load(values []interface{}) {
+	sorted := make([]interface{}, len(values))
+	copy(sorted, values)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return t.comparer.Compare(sorted[i], sorted[j]) < 0
+	})
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.root = t.build(sorted)
+}
+
+func (t *Treap) Format(f fmt.State, verb rune) {
+	gods.FormatValues(f, verb, "Treap", t.Len(), t.Each)
+}
+
+func (t *Treap) String() string {
+	return fmt.Sprint(t)
+}
+
+// MarshalJSON encodes the treap as an array in sorted order.
+func (t *Treap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Values())
+}
+
+func (t *Treap) UnmarshalJSON(data []byte) error {
+	if t.comparer == nil {
+		return errNoComparer
+	}
+
+	values, err := gods.UnmarshalArray(data, t.element)
+
+	if err != nil {
+		return err
+	}
+
+	t.Load(values)
+
+	return nil
+}
+
+// WriteTo streams the values in sorted order, in the same format as
+// BinarySearchTree.WriteTo.
+func (t *Treap) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteValues(w, gods.KindSet, t.codec, t.Each)
+}
+
+func (t *Treap) ReadFrom(r io.Reader) (int64, error) {
+	if t.comparer == nil {
+		return 0, errNoComparer
+	}
+
+	var values []interface{}
+
+	n, err := gods.ReadValues(r, gods.KindSet, t.codec, func(value interface{}) {
+		values = append(values, value)
+	})
+
+	if err == nil {
+		t.Load(values)
+	}
+
+	return n, err
+}
+
+func (t *Treap) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(t)
+}
+
+func (t *Treap) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(t, data)
+}
+
+func (t *Treap) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+func (t *Treap) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
+
+// Validate checks the ordering, the heap order of the priorities and the
+// subtree sizes.
+func (t *Treap) Validate() error {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	_, err := t.validate(t.root, nil, nil)
+
+	return err
+}
+
+func (t *Treap) validate(n *treapNode, low, high *treapNode) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+
+	if low != nil && t.comparer.Compare(low.value, n.value) >= 0 {
+		return 0, fmt.Errorf("tree: %v is not greater than %v", n.value, low.value)
+	}
+
+	if high != nil && t.comparer.Compare(n.value, high.value) >= 0 {
+		return 0, fmt.Errorf("tree: %v is not less than %v", n.value, high.value)
+	}
+
+	for _, child := range []*treapNode{n.left, n.right} {
+		if child != nil && child.priority > n.priority {
+			return 0, fmt.Errorf("tree: %v has a higher priority than its parent %v", child.value, n.value)
+		}
+	}
+
+	left, err := t.validate(n.left, low, n)
+
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := t.validate(n.right, n, high)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if n.size != left+right+1 {
+		return 0, fmt.Errorf("tree: %v has size %d but %d nodes below it", n.value, n.size, left+right+1)
+	}
+
+	return n.size, nil
+}
+
+// share returns the root after giving t a new owner, so that t copies its
+// current nodes before modifying them and they may be shared.
+func (t *Treap) share() *treapNode {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.owner = &treapOwner{}
+
+	return t.root
+}
+
+// derive returns an empty treap with the options of t.
+func (t *Treap) derive() *Treap {
+	return &Treap{
+		comparer: t.comparer,
+		owner:    &treapOwner{},
+		lock:     t.lock,
+		mutex:    gods.NewLocker(t.lock),
+		element:  t.element,
+		codec:    t.codec,
+	}
+}
+
+func (t *Treap) mutable(n *treapNode) *treapNode {
+	if n.owner == t.owner {
+		return n
+	}
+
+	clone := *n
+	clone.owner = t.owner
+
+	return &clone
+}
+
+func (t *Treap) update(n *treapNode) *treapNode {
+	n.size = treapSize(n.left) + treapSize(n.right) + 1
+
+	return n
+}
+
+func (t *Treap) search(value interface{}) *treapNode {
+	node := t.root
+
+	for node != nil {
+		result := t.comparer.Compare(value, node.value)
+
+		if result == 0 {
+			return node
+		}
+
+		if result < 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	return nil
+}
+
+func (t *Treap) insert(n, node *treapNode) *treapNode {
+	if n == nil {
+		return node
+	}
+
+	if node.priority > n.priority {
+		node.left, _, node.right = t.split(n, node.value)
+
+		return t.update(node)
+	}
+
+	n = t.mutable(n)
+
+	if t.comparer.Compare(node.value, n.value) < 0 {
+		n.left = t.insert(n.left, node)
+	} else {
+		n.right = t.insert(n.right, node)
+	}
+
+	return t.update(n)
+}
+
+// remove deletes value, which must be present, from the subtree at n.
+func (t *Treap) remove(n *treapNode, value interface{}) *treapNode {
+	result := t.comparer.Compare(value, n.value)
+
+	if result == 0 {
+		return t.merge(n.left, n.right)
+	}
+
+	n = t.mutable(n)
+
+	if result < 0 {
+		n.left = t.remove(n.left, value)
+	} else {
+		n.right = t.remove(n.right, value)
+	}
+
+	return t.update(n)
+}
+
+// split partitions the subtree at n into the values less than value, the
+// node equal to it if any, and the values greater. The middle node is
+// returned unchanged and still holds its old children.
+func (t *Treap) split(n *treapNode, value interface{}) (*treapNode, *treapNode, *treapNode) {
+	if n == nil {
+		return nil, nil, nil
+	}
+
+	result := t.comparer.Compare(value, n.value)
+
+	if result == 0 {
+		return n.left, n, n.right
+	}
+
+	n = t.mutable(n)
+
+	if result < 0 {
+		left, middle, right := t.split(n.left, value)
+		n.left = right
+
+		return left, middle, t.update(n)
+	}
+
+	left, middle, right := t.split(n.right, value)
+	n.right = left
+
+	return t.update(n), middle, right
+}
+
+// merge concatenates two subtrees where every value of left is less than
+// every value of right.
+func (t *Treap) merge(left, right *treapNode) *treapNode {
+	if left == nil {
+		return right
+	}
+
+	if right == nil {
+		return left
+	}
+
+	if left.priority > right.priority {
+		left = t.mutable(left)
+		left.right = t.merge(left.right, right)
+
+		return t.update(left)
+	}
+
+	right = t.mutable(right)
+	right.left = t.merge(left, right.left)
+
+	return t.update(right)
+}
+
+// join is merge with the value of middle placed between left and right.
+// The children of middle are ignored.
+func (t *Treap) join(left, middle, right *treapNode) *treapNode {
+	node := t.mutable(middle)
+	node.left, node.right = nil, nil
+
+	return t.merge(t.merge(left, t.update(node)), right)
+}
+
+func (t *Treap) union(a, b *treapNode) *treapNode {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if a.priority < b.priority {
+		a, b = b, a
+	}
+
+	left, _, right := t.split(b, a.value)
+	left = t.union(a.left, left)
+	right = t.union(a.right, right)
+
+	return t.join(left, a, right)
+}
+
+func (t *Treap) intersection(a, b *treapNode) *treapNode {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	left, middle, right := t.split(b, a.value)
+	left = t.intersection(a.left, left)
+	right = t.intersection(a.right, right)
+
+	if middle == nil {
+		return t.merge(left, right)
+	}
+
+	return t.join(left, a, right)
+}
+
+func (t *Treap) difference(a, b *treapNode) *treapNode {
+	if a == nil || b == nil {
+		return a
+	}
+
+	left, _, right := t.split(a, b.value)
+
+	return t.merge(t.difference(left, b.left), t.difference(right, b.right))
+}
+
+// build returns a treap of the sorted values, dropping duplicates, by
+// building the Cartesian tree of random priorities in one pass.
+func (t *Treap) build(values []interface{}) *treapNode {
+	var stack []*treapNode
+
+	for i, value := range values {
+		if i > 0 && t.comparer.Compare(values[i-1], value) == 0 {
+			continue
+		}
+
+		node := &treapNode{value: value, priority: rand.Uint64(), owner: t.owner}
+
+		var last *treapNode
+
+		for len(stack) > 0 && stack[len(stack)-1].priority < node.priority {
+			last = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+
+		node.left = last
+
+		if len(stack) > 0 {
+			stack[len(stack)-1].right = node
+		}
+
+		stack = append(stack, node)
+	}
+
+	if len(stack) == 0 {
+		return nil
+	}
+
+	var size func(n *treapNode) int
+
+	size = func(n *treapNode) int {
+		if n == nil {
+			return 0
+		}
+
+		n.size = size(n.left) + size(n.right) + 1
+
+		return n.size
+	}
+
+	size(stack[0])
+
+	return stack[0]
+}
+
+func treapMin(n *treapNode) *treapNode {
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n
+}
+
+func treapMax(n *treapNode) *treapNode {
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n
+}
@@ -0,0 +1,385 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/kucuny/gods/godstest"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type TreapTestSuite struct {
+	suite.Suite
+}
+
+func newTreap(values ...int) *Treap {
+	t := NewTreap(IntegerComparer)
+
+	for _, value := range values {
+		t.Insert(value)
+	}
+
+	return t
+}
+
+func ints(values []interface{}) []int {
+	result := make([]int, len(values))
+
+	for i, value := range values {
+		result[i] = value.(int)
+	}
+
+	return result
+}
+
+func (suite *TreapTestSuite) TestTreap() {
+	t := newTreap(5, 3, 8, 1)
+
+	suite.False(t.Insert(5))
+	suite.Equal(4, t.Len())
+	suite.Equal([]interface{}{1, 3, 5, 8}, t.Values())
+	suite.True(t.Contains(3))
+	suite.True(t.Delete(3))
+	suite.False(t.Delete(3))
+	suite.False(t.Contains(3))
+
+	first, _ := t.First()
+	last, _ := t.Last()
+	suite.Equal(1, first)
+	suite.Equal(8, last)
+	suite.NoError(t.Validate())
+
+	t.Clear()
+	suite.True(t.IsEmpty())
+
+	_, ok := t.First()
+	suite.False(ok)
+	_, ok = t.Last()
+	suite.False(ok)
+}
+
+func (suite *TreapTestSuite) TestTreapSplit() {
+	t := newTreap(1, 2, 3, 4, 5, 6)
+
+	left, right := t.Split(4)
+	suite.Equal([]interface{}{1, 2, 3}, left.Values())
+	suite.Equal([]interface{}{4, 5, 6}, right.Values())
+	suite.NoError(left.Validate())
+	suite.NoError(right.Validate())
+
+	left, right = t.Split(7)
+	suite.Equal(6, left.Len())
+	suite.True(right.IsEmpty())
+
+	left, right = t.Split(0)
+	suite.True(left.IsEmpty())
+	suite.Equal(6, right.Len())
+
+	// The results share nodes with t, so writes to one must not leak into
+	// the others.
+	left, right = t.Split(4)
+	left.Insert(0)
+	right.Delete(5)
+	t.Delete(2)
+	t.Insert(7)
+
+	suite.Equal([]interface{}{0, 1, 2, 3}, left.Values())
+	suite.Equal([]interface{}{4, 6}, right.Values())
+	suite.Equal([]interface{}{1, 3, 4, 5, 6, 7}, t.Values())
+
+	for _, treap := range []*Treap{t, left, right} {
+		suite.NoError(treap.Validate())
+	}
+}
+
+func (suite *TreapTestSuite) TestTreapMergeAndJoin() {
+	left, right := newTreap(1, 2, 3), newTreap(5, 6)
+
+	merged, err := left.Merge(right)
+	suite.NoError(err)
+	suite.Equal([]interface{}{1, 2, 3, 5, 6}, merged.Values())
+	suite.NoError(merged.Validate())
+
+	_, err = right.Merge(left)
+	suite.ErrorIs(err, errTreapOrder)
+
+	joined, err := left.Join(4, right)
+	suite.NoError(err)
+	suite.Equal([]interface{}{1, 2, 3, 4, 5, 6}, joined.Values())
+	suite.NoError(joined.Validate())
+
+	_, err = left.Join(3, right)
+	suite.ErrorIs(err, errTreapOrder)
+	_, err = left.Join(5, right)
+	suite.ErrorIs(err, errTreapOrder)
+
+	empty := NewTreap(IntegerComparer)
+	merged, err = empty.Merge(right)
+	suite.NoError(err)
+	suite.Equal([]interface{}{5, 6}, merged.Values())
+
+	joined, err = empty.Join(1, empty)
+	suite.NoError(err)
+	suite.Equal([]interface{}{1}, joined.Values())
+
+	merged.Insert(7)
+	joined.Insert(2)
+	suite.Equal([]interface{}{5, 6}, right.Values())
+	suite.Equal([]interface{}{1, 2, 3}, left.Values())
+}
+
+func (suite *TreapTestSuite) TestTreapSetOperations() {
+	a, b := newTreap(1, 2, 3, 4, 5), newTreap(4, 5, 6, 7)
+
+	suite.Equal([]interface{}{1, 2, 3, 4, 5, 6, 7}, a.Union(b).Values())
+	suite.Equal([]interface{}{4, 5}, a.Intersection(b).Values())
+	suite.Equal([]interface{}{1, 2, 3}, a.Difference(b).Values())
+	suite.Equal([]interface{}{6, 7}, b.Difference(a).Values())
+
+	suite.Equal(a.Values(), a.Union(a).Values())
+	suite.Equal(a.Values(), a.Intersection(a).Values())
+	suite.True(a.Difference(a).IsEmpty())
+
+	empty := NewTreap(IntegerComparer)
+	suite.Equal(a.Values(), a.Union(empty).Values())
+	suite.True(a.Intersection(empty).IsEmpty())
+	suite.Equal(a.Values(), a.Difference(empty).Values())
+
+	suite.Equal([]interface{}{1, 2, 3, 4, 5}, a.Values())
+	suite.Equal([]interface{}{4, 5, 6, 7}, b.Values())
+}
+
+func (suite *TreapTestSuite) TestTreapRandomSetOperations() {
+	random := rand.New(rand.NewSource(1))
+
+	for round := 0; round < 50; round++ {
+		a, b := NewTreap(IntegerComparer), NewTreap(IntegerComparer)
+		inA, inB := make(map[int]bool), make(map[int]bool)
+
+		for i := 0; i < random.Intn(200); i++ {
+			value := random.Intn(300)
+			a.Insert(value)
+			inA[value] = true
+		}
+
+		for i := 0; i < random.Intn(200); i++ {
+			value := random.Intn(300)
+			b.Insert(value)
+			inB[value] = true
+		}
+
+		var union, intersection, difference []int
+
+		for value := 0; value < 300; value++ {
+			if inA[value] || inB[value] {
+				union = append(union, value)
+			}
+
+			if inA[value] && inB[value] {
+				intersection = append(intersection, value)
+			}
+
+			if inA[value] && !inB[value] {
+				difference = append(difference, value)
+			}
+		}
+
+		for _, check := range []struct {
+			result   *Treap
+			expected []int
+		}{
+			{a.Union(b), union},
+			{a.Intersection(b), intersection},
+			{a.Difference(b), difference},
+		} {
+			suite.NoError(check.result.Validate())
+			suite.Equal(len(check.expected), check.result.Len())
+
+			if len(check.expected) > 0 {
+				suite.Equal(check.expected, ints(check.result.Values()))
+			}
+		}
+
+		suite.Equal(len(inA), a.Len())
+		suite.Equal(len(inB), b.Len())
+	}
+}
+
+func (suite *TreapTestSuite) TestTreapCloneAndLoad() {
+	t := NewTreap(IntegerComparer)
+	t.Load([]interface{}{9, 2, 4, 2, 7})
+	suite.Equal([]interface{}{2, 4, 7, 9}, t.Values())
+	suite.NoError(t.Validate())
+
+	clone := t.Clone()
+	clone.Insert(1)
+	t.Delete(9)
+
+	suite.Equal([]interface{}{2, 4, 7}, t.Values())
+	suite.Equal([]interface{}{1, 2, 4, 7, 9}, clone.Values())
+
+	values := make([]interface{}, 1000)
+
+	for i := range values {
+		values[i] = i
+	}
+
+	t.Load(values)
+	suite.Equal(1000, t.Len())
+	suite.NoError(t.Validate())
+
+	t.Load(nil)
+	suite.True(t.IsEmpty())
+}
+
+func (suite *TreapTestSuite) TestTreapEncoding() {
+	t := NewTreap(IntegerComparer, gods.WithElement[int](), gods.WithCodec(gods.IntCodec))
+	t.Load([]interface{}{3, 1, 2})
+
+	data, err := json.Marshal(t)
+	suite.NoError(err)
+	suite.JSONEq(`[1,2,3]`, string(data))
+
+	decoded := NewTreap(IntegerComparer, gods.WithElement[int]())
+	suite.NoError(json.Unmarshal([]byte(`[3,3,1]`), decoded))
+	suite.Equal([]interface{}{1, 3}, decoded.Values())
+
+	data, err = t.MarshalBinary()
+	suite.NoError(err)
+
+	bst := NewBinarySearchTree(IntegerComparer, gods.WithCodec(gods.IntCodec))
+	suite.NoError(bst.UnmarshalBinary(data))
+	suite.Equal([]interface{}{1, 2, 3}, bst.Values())
+
+	var zero Treap
+	suite.Error(json.Unmarshal(data, &zero))
+
+	suite.Equal("Treap[1 2 3]", t.String())
+	suite.Equal("Treap[1 ...2 more]", fmt.Sprintf("%.1v", t))
+}
+
+func TestTreapTestSuite(t *testing.T) {
+	suite.Run(t, new(TreapTestSuite))
+}
+
+func TestTreapConformance(t *testing.T) {
+	suite.Run(t, &godstest.SetSuite{
+		New: func() gods.Set { return NewTreap(IntegerComparer) },
+	})
+}
+
+func FuzzTreap(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 5, 0, 15, 3, 7, 1, 5, 4, 10, 2, 7, 5, 0})
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 5, 2, 3, 2, 1, 1, 4, 3, 0, 9})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		treap := NewTreap(IntegerComparer)
+		model := make(map[int]bool)
+
+		other := NewTreap(IntegerComparer)
+		otherModel := make(map[int]bool)
+
+		check := func(treap *Treap, model map[int]bool) {
+			if err := treap.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			expected := make([]int, 0, len(model))
+
+			for value := range model {
+				expected = append(expected, value)
+			}
+
+			sort.Ints(expected)
+
+			values := ints(treap.Values())
+
+			if fmt.Sprint(values) != fmt.Sprint(expected) {
+				t.Fatalf("treap holds %v, model %v", values, expected)
+			}
+		}
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := int(ops[i+1])
+
+			switch ops[i] % 6 {
+			case 0:
+				if treap.Insert(value) == model[value] {
+					t.Fatalf("insert %d disagrees with model", value)
+				}
+
+				model[value] = true
+			case 1:
+				if treap.Delete(value) != model[value] {
+					t.Fatalf("delete %d disagrees with model", value)
+				}
+
+				delete(model, value)
+			case 2:
+				other.Insert(value)
+				otherModel[value] = true
+			case 3:
+				// Split, keep one side as the treap and the other as other.
+				left, right := treap.Split(value)
+				treap, other = left, right
+				model, otherModel = make(map[int]bool), make(map[int]bool)
+
+				for _, v := range left.Values() {
+					model[v.(int)] = true
+				}
+
+				for _, v := range right.Values() {
+					otherModel[v.(int)] = true
+				}
+			case 4:
+				union := treap.Union(other)
+				unionModel := make(map[int]bool)
+
+				for v := range model {
+					unionModel[v] = true
+				}
+
+				for v := range otherModel {
+					unionModel[v] = true
+				}
+
+				check(union, unionModel)
+
+				difference := treap.Difference(other)
+				differenceModel := make(map[int]bool)
+
+				for v := range model {
+					if !otherModel[v] {
+						differenceModel[v] = true
+					}
+				}
+
+				check(difference, differenceModel)
+
+				intersectionModel := make(map[int]bool)
+
+				for v := range model {
+					if otherModel[v] {
+						intersectionModel[v] = true
+					}
+				}
+
+				treap, model = treap.Intersection(other), intersectionModel
+			case 5:
+				if merged, err := treap.Merge(other); err == nil {
+					treap = merged
+
+					for v := range otherModel {
+						model[v] = true
+					}
+				}
+			}
+
+			check(treap, model)
+			check(other, otherModel)
+		}
+	})
+}
@@ -0,0 +1,578 @@
+// Package trie implements a radix tree: a trie over string or []byte keys
+// where every chain of nodes with a single child is compressed into one
+// edge.
+package trie
+
+import (
+	"fmt"
+	"github.com/kucuny/gods"
+	"io"
+	"slices"
+	"sort"
+)
+
+type node struct {
+	prefix   string
+	leaf     bool
+	value    interface{}
+	children []*node
+}
+
+// child returns the index of the child whose edge starts with b, or where
+// such a child would be inserted.
+func (n *node) child(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+
+	return i, i < len(n.children) && n.children[i].prefix[0] == b
+}
+
+func (n *node) insertChild(i int, child *node) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+var _ gods.Container = (*RadixTree)(nil)
+
+// RadixTree maps string keys to values. Keys are compared byte by byte, so
+// iteration is in lexicographic byte order, and Insert, Get and Delete take
+// O(len(key)) regardless of the number of keys. The Bytes variants take
+// []byte keys and prefixes and look them up without copying.
+type RadixTree struct {
+	root     node
+	count    int
+	mutex    gods.Locker
+	element  gods.Decoder
+	keyCodec gods.Codec
+	codec    gods.Codec
+}
+
+func NewRadixTree(options ...gods.Option) *RadixTree {
+	opts := gods.NewOptions(options...)
+	keyCodec, codec := opts.Codecs()
+
+	return &RadixTree{
+		count:    0,
+		mutex:    opts.Locker(),
+		element:  opts.ElementDecoder(),
+		keyCodec: keyCodec,
+		codec:    codec,
+	}
+}
+
+func (t *RadixTree) Len() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.count
+}
+
+func (t *RadixTree) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+func (t *RadixTree) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.root = node{}
+	t.count = 0
+}
+
+// Insert maps key to value and reports whether key was new.
+func (t *RadixTree) Insert(key string, value interface{}) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	n, search := &t.root, key
+
+	for search != "" {
+		i, ok := n.child(search[0])
+
+		if !ok {
+			n.insertChild(i, &node{prefix: search, leaf: true, value: value})
+			t.count++
+
+			return true
+		}
+
+		c := n.children[i]
+		common := commonPrefix(c.prefix, search)
+
+		if common < len(c.prefix) {
+			// The key leaves the edge part way along: split the edge.
+			split := &node{prefix: c.prefix[:common], children: []*node{c}}
+			c.prefix = c.prefix[common:]
+			n.children[i] = split
+
+			if common == len(search) {
+				split.leaf, split.value = true, value
+			} else {
+				j, _ := split.child(search[common])
+				split.insertChild(j, &node{prefix: search[common:], leaf: true, value: value})
+			}
+
+			t.count++
+
+			return true
+		}
+
+		n, search = c, search[common:]
+	}
+
+	added := !n.leaf
+	n.leaf, n.value = true, value
+
+	if added {
+		t.count++
+	}
+
+	return added
+}
+
+// InsertBytes is Insert for a []byte key. The tree keeps a copy of the key.
+func (t *RadixTree) InsertBytes(key []byte, value interface{}) bool {
+	return t.Insert(string(key), value)
+}
+
+func (t *RadixTree) Get(key string) (interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return get(&t.root, key)
+}
+
+// GetBytes is Get for a []byte key. It does not copy the key.
+func (t *RadixTree) GetBytes(key []byte) (interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return get(&t.root, key)
+}
+
+func (t *RadixTree) Contains(key string) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+func (t *RadixTree) ContainsBytes(key []byte) bool {
+	_, ok := t.GetBytes(key)
+	return ok
+}
+
+func (t *RadixTree) Delete(key string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return remove(t, key)
+}
+
+func (t *RadixTree) DeleteBytes(key []byte) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return remove(t, key)
+}
+
+// LongestPrefix returns the longest key that is a prefix of s, and its
+// value.
+func (t *RadixTree) LongestPrefix(s string) (string, interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return longestPrefix(&t.root, s)
+}
+
+// LongestPrefixBytes is LongestPrefix for a []byte. The key it returns is a
+// slice of s.
+func (t *RadixTree) LongestPrefixBytes(s []byte) ([]byte, interface{}, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return longestPrefix(&t.root, s)
+}
+
+// WalkPrefix calls fn in key order for every key starting with prefix until
+// fn returns false.
+func (t *RadixTree) WalkPrefix(prefix string, fn func(key string, value interface{}) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	walkPrefix(&t.root, prefix, fn)
+}
+
+// WalkPrefixBytes is WalkPrefix for a []byte prefix. Keys are still passed
+// to fn as strings.
+func (t *RadixTree) WalkPrefixBytes(prefix []byte, fn func(key string, value interface{}) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	walkPrefix(&t.root, prefix, fn)
+}
+
+// Each calls fn in key order for every key until fn returns false.
+func (t *RadixTree) Each(fn func(key string, value interface{}) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	walk(&t.root, "", fn)
+}
+
+func (t *RadixTree) Keys() []string {
+	keys := make([]string, 0, t.Len())
+
+	t.Each(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys
+}
+
+func (t *RadixTree) Values() []interface{} {
+	values := make([]interface{}, 0, t.Len())
+
+	t.Each(func(key string, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+func (t *RadixTree) Format(f fmt.State, verb rune) {
+	gods.FormatEntries(f, verb, "RadixTree", t.Len(), t.each)
+}
+
+func (t *RadixTree) String() string {
+	return fmt.Sprint(t)
+}
+
+// MarshalJSON encodes the tree as an object with keys in sorted order.
+func (t *RadixTree) MarshalJSON() ([]byte, error) {
+	keys := make([]interface{}, 0, t.Len())
+	values := make([]interface{}, 0, t.Len())
+
+	t.Each(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+
+	return gods.MarshalObject(keys, values)
+}
+
+// UnmarshalJSON replaces the contents of the tree. A zero RadixTree is
+// initialized with the default options first.
+func (t *RadixTree) UnmarshalJSON(data []byte) error {
+	if t.mutex == nil {
+		*t = *NewRadixTree()
+	}
+
+	keys, values, err := gods.UnmarshalObject(data, gods.DecodeKeyAs[string], t.element)
+
+	if err != nil {
+		return err
+	}
+
+	loaded := NewRadixTree(gods.WithLock(gods.NoLock))
+
+	for i, key := range keys {
+		loaded.Insert(key.(string), values[i])
+	}
+
+	t.replace(loaded)
+
+	return nil
+}
+
+// WriteTo streams the entries in key order.
+func (t *RadixTree) WriteTo(w io.Writer) (int64, error) {
+	return gods.WriteEntries(w, gods.KindMap, t.keyCodec, t.codec, t.each)
+}
+
+// ReadFrom replaces the contents of the tree with a stream written by
+// WriteTo. The tree is left unchanged if the stream is invalid.
+func (t *RadixTree) ReadFrom(r io.Reader) (int64, error) {
+	if t.mutex == nil {
+		*t = *NewRadixTree()
+	}
+
+	loaded := NewRadixTree(gods.WithLock(gods.NoLock))
+	var err error
+
+	n, readErr := gods.ReadEntries(r, gods.KindMap, t.keyCodec, t.codec, func(key, value interface{}) {
+		s, ok := key.(string)
+
+		if !ok && err == nil {
+			err = fmt.Errorf("trie: key %v is a %T, not a string", key, key)
+		}
+
+		loaded.Insert(s, value)
+	})
+
+	if readErr != nil {
+		return n, readErr
+	}
+
+	if err != nil {
+		return n, err
+	}
+
+	t.replace(loaded)
+
+	return n, nil
+}
+
+func (t *RadixTree) MarshalBinary() ([]byte, error) {
+	return gods.MarshalBinary(t)
+}
+
+func (t *RadixTree) UnmarshalBinary(data []byte) error {
+	return gods.UnmarshalBinary(t, data)
+}
+
+func (t *RadixTree) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+func (t *RadixTree) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
+
+// Validate checks that edges are non-empty and sorted, that no two edges
+// of a node share a first byte, that every node other than the root either
+// holds a value or branches, and the count.
+func (t *RadixTree) Validate() error {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	count, err := validate(&t.root, "")
+
+	if err != nil {
+		return err
+	}
+
+	if count != t.count {
+		return fmt.Errorf("trie: count is %d but %d keys are reachable", t.count, count)
+	}
+
+	return nil
+}
+
+func validate(n *node, key string) (int, error) {
+	count := 0
+
+	if n.leaf {
+		count++
+	}
+
+	for i, c := range n.children {
+		if c.prefix == "" {
+			return 0, fmt.Errorf("trie: %q has a child with an empty edge", key)
+		}
+
+		if i > 0 && n.children[i-1].prefix[0] >= c.prefix[0] {
+			return 0, fmt.Errorf("trie: edges of %q are not sorted by first byte", key)
+		}
+
+		if !c.leaf && len(c.children) < 2 {
+			return 0, fmt.Errorf("trie: %q neither holds a value nor branches", key+c.prefix)
+		}
+
+		below, err := validate(c, key+c.prefix)
+
+		if err != nil {
+			return 0, err
+		}
+
+		count += below
+	}
+
+	return count, nil
+}
+
+func (t *RadixTree) replace(loaded *RadixTree) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.root, t.count = loaded.root, loaded.count
+}
+
+// each adapts Each to the untyped callbacks of the gods helpers.
+func (t *RadixTree) each(fn func(key, value interface{}) bool) {
+	t.Each(func(key string, value interface{}) bool {
+		return fn(key, value)
+	})
+}
+
+// key is the types lookups accept. Both index and slice to bytes, and
+// comparing a []byte converted to a string does not copy it.
+type key interface {
+	~string | ~[]byte
+}
+
+func hasPrefix[K key](s K, prefix string) bool {
+	return len(s) >= len(prefix) && string(s[:len(prefix)]) == prefix
+}
+
+func get[K key](n *node, key K) (interface{}, bool) {
+	for len(key) > 0 {
+		i, ok := n.child(key[0])
+
+		if !ok || !hasPrefix(key, n.children[i].prefix) {
+			return nil, false
+		}
+
+		n, key = n.children[i], key[len(n.children[i].prefix):]
+	}
+
+	if !n.leaf {
+		return nil, false
+	}
+
+	return n.value, true
+}
+
+// remove deletes key from t. It must be called with the write lock held.
+func remove[K key](t *RadixTree, key K) bool {
+	if len(key) == 0 {
+		if !t.root.leaf {
+			return false
+		}
+
+		t.root.leaf, t.root.value = false, nil
+	} else if !removeBelow(&t.root, key) {
+		return false
+	}
+
+	t.count--
+
+	return true
+}
+
+// removeBelow removes key, which is not empty, from below n and compacts
+// the child it passed through.
+func removeBelow[K key](n *node, key K) bool {
+	i, ok := n.child(key[0])
+
+	if !ok || !hasPrefix(key, n.children[i].prefix) {
+		return false
+	}
+
+	c := n.children[i]
+
+	if rest := key[len(c.prefix):]; len(rest) == 0 {
+		if !c.leaf {
+			return false
+		}
+
+		c.leaf, c.value = false, nil
+	} else if !removeBelow(c, rest) {
+		return false
+	}
+
+	if !c.leaf {
+		switch len(c.children) {
+		case 0:
+			n.children = slices.Delete(n.children, i, i+1)
+		case 1:
+			only := c.children[0]
+			only.prefix = c.prefix + only.prefix
+			n.children[i] = only
+		}
+	}
+
+	return true
+}
+
+func longestPrefix[K key](n *node, s K) (K, interface{}, bool) {
+	var key K
+	var value interface{}
+	var found bool
+
+	depth := 0
+
+	for {
+		if n.leaf {
+			key, value, found = s[:depth], n.value, true
+		}
+
+		if depth == len(s) {
+			break
+		}
+
+		i, ok := n.child(s[depth])
+
+		if !ok || !hasPrefix(s[depth:], n.children[i].prefix) {
+			break
+		}
+
+		n, depth = n.children[i], depth+len(n.children[i].prefix)
+	}
+
+	return key, value, found
+}
+
+func walkPrefix[K key](n *node, prefix K, fn func(key string, value interface{}) bool) {
+	search, depth := prefix, 0
+
+	for len(search) > 0 {
+		i, ok := n.child(search[0])
+
+		if !ok {
+			return
+		}
+
+		c := n.children[i]
+
+		// The prefix ends part way along this edge.
+		if len(search) <= len(c.prefix) {
+			if string(search) != c.prefix[:len(search)] {
+				return
+			}
+
+			walk(c, string(prefix[:depth]), fn)
+
+			return
+		}
+
+		if !hasPrefix(search, c.prefix) {
+			return
+		}
+
+		n, search, depth = c, search[len(c.prefix):], depth+len(c.prefix)
+	}
+
+	walk(n, string(prefix[:depth-len(n.prefix)]), fn)
+}
+
+// walk visits the subtree of n in key order, where base is the key of the
+// parent of n.
+func walk(n *node, base string, fn func(key string, value interface{}) bool) bool {
+	key := base + n.prefix
+
+	if n.leaf && !fn(key, n.value) {
+		return false
+	}
+
+	for _, c := range n.children {
+		if !walk(c, key, fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return i
+}
@@ -0,0 +1,400 @@
+package trie
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kucuny/gods"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type RadixTreeTestSuite struct {
+	suite.Suite
+	tree *RadixTree
+}
+
+func (suite *RadixTreeTestSuite) SetupTest() {
+	suite.tree = NewRadixTree()
+
+	for i, key := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"} {
+		suite.True(suite.tree.Insert(key, i))
+	}
+}
+
+func (suite *RadixTreeTestSuite) walkPrefix(prefix string) []string {
+	var keys []string
+
+	suite.tree.WalkPrefix(prefix, func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys
+}
+
+func (suite *RadixTreeTestSuite) TestRadixTree() {
+	suite.Equal(7, suite.tree.Len())
+	suite.Equal([]string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"}, suite.tree.Keys())
+	suite.Equal([]interface{}{0, 1, 2, 3, 4, 5, 6}, suite.tree.Values())
+	suite.NoError(suite.tree.Validate())
+
+	value, ok := suite.tree.Get("rubicon")
+	suite.True(ok)
+	suite.Equal(5, value)
+
+	for _, missing := range []string{"", "r", "rom", "roman", "romanes", "rubicons", "x"} {
+		suite.False(suite.tree.Contains(missing), missing)
+	}
+
+	suite.False(suite.tree.Insert("ruber", "red"))
+	value, _ = suite.tree.Get("ruber")
+	suite.Equal("red", value)
+
+	suite.True(suite.tree.Insert("rom", 7))
+	suite.True(suite.tree.Insert("", 8))
+	suite.Equal(9, suite.tree.Len())
+	suite.Equal([]string{"", "rom", "romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"}, suite.tree.Keys())
+	suite.NoError(suite.tree.Validate())
+
+	suite.tree.Clear()
+	suite.True(suite.tree.IsEmpty())
+	suite.Empty(suite.tree.Keys())
+}
+
+func (suite *RadixTreeTestSuite) TestRadixTreeDelete() {
+	suite.False(suite.tree.Delete("rub"))
+	suite.False(suite.tree.Delete("rubiconx"))
+	suite.False(suite.tree.Delete(""))
+
+	suite.True(suite.tree.Delete("rubicon"))
+	suite.False(suite.tree.Delete("rubicon"))
+	suite.NoError(suite.tree.Validate())
+
+	suite.True(suite.tree.Delete("rubicundus"))
+	suite.True(suite.tree.Delete("romulus"))
+	suite.NoError(suite.tree.Validate())
+	suite.Equal([]string{"romane", "romanus", "rubens", "ruber"}, suite.tree.Keys())
+
+	suite.tree.Insert("", "root")
+	suite.True(suite.tree.Delete(""))
+	suite.False(suite.tree.Contains(""))
+
+	for _, key := range suite.tree.Keys() {
+		suite.True(suite.tree.Delete(key))
+		suite.NoError(suite.tree.Validate())
+	}
+
+	suite.True(suite.tree.IsEmpty())
+}
+
+func (suite *RadixTreeTestSuite) TestRadixTreeLongestPrefix() {
+	routes := NewRadixTree()
+	routes.Insert("/", "root")
+	routes.Insert("/api/", "api")
+	routes.Insert("/api/users", "users")
+	routes.Insert("/static/", "static")
+
+	for _, check := range []struct {
+		path, key string
+		value     interface{}
+	}{
+		{"/api/users/42", "/api/users", "users"},
+		{"/api/users", "/api/users", "users"},
+		{"/api/user", "/api/", "api"},
+		{"/api", "/", "root"},
+		{"/static/app.js", "/static/", "static"},
+		{"/", "/", "root"},
+	} {
+		key, value, ok := routes.LongestPrefix(check.path)
+		suite.True(ok, check.path)
+		suite.Equal(check.key, key, check.path)
+		suite.Equal(check.value, value, check.path)
+	}
+
+	_, _, ok := routes.LongestPrefix("api")
+	suite.False(ok)
+
+	_, _, ok = NewRadixTree().LongestPrefix("")
+	suite.False(ok)
+}
+
+func (suite *RadixTreeTestSuite) TestRadixTreeWalkPrefix() {
+	suite.Equal([]string{"rubens", "ruber", "rubicon", "rubicundus"}, suite.walkPrefix("rub"))
+	suite.Equal([]string{"rubicon", "rubicundus"}, suite.walkPrefix("rubic"))
+	suite.Equal([]string{"romane", "romanus"}, suite.walkPrefix("roman"))
+	suite.Equal([]string{"romanus"}, suite.walkPrefix("romanu"))
+	suite.Equal([]string{"rubicon"}, suite.walkPrefix("rubicon"))
+	suite.Equal(suite.tree.Keys(), suite.walkPrefix(""))
+	suite.Nil(suite.walkPrefix("rubicons"))
+	suite.Nil(suite.walkPrefix("ra"))
+	suite.Nil(suite.walkPrefix("x"))
+
+	var first []string
+
+	suite.tree.WalkPrefix("r", func(key string, value interface{}) bool {
+		first = append(first, key)
+		return len(first) < 3
+	})
+
+	suite.Equal([]string{"romane", "romanus", "romulus"}, first)
+}
+
+func (suite *RadixTreeTestSuite) TestRadixTreeBytes() {
+	tree := NewRadixTree()
+	tree.Insert(string([]byte{0xff, 0x00}), 1)
+	tree.Insert(string([]byte{0x00}), 2)
+	tree.Insert(string([]byte{0xff}), 3)
+
+	suite.Equal([]string{"\x00", "\xff", "\xff\x00"}, tree.Keys())
+	suite.NoError(tree.Validate())
+
+	suite.True(tree.InsertBytes([]byte("/api/"), 4))
+	suite.False(tree.InsertBytes([]byte{0xff}, 5))
+	suite.True(tree.ContainsBytes([]byte("/api/")))
+	suite.False(tree.ContainsBytes([]byte("/api")))
+
+	value, ok := tree.GetBytes([]byte{0xff})
+	suite.True(ok)
+	suite.Equal(5, value)
+
+	path := []byte("/api/users")
+	key, value, ok := tree.LongestPrefixBytes(path)
+	suite.True(ok)
+	suite.Equal([]byte("/api/"), key)
+	suite.Equal(4, value)
+
+	_, _, ok = tree.LongestPrefixBytes([]byte("/ap"))
+	suite.False(ok)
+
+	var walked []string
+
+	tree.WalkPrefixBytes([]byte{0xff}, func(key string, value interface{}) bool {
+		walked = append(walked, key)
+		return true
+	})
+
+	suite.Equal([]string{"\xff", "\xff\x00"}, walked)
+
+	// Looking up a []byte does not copy it into a string.
+	suite.Zero(testing.AllocsPerRun(100, func() {
+		tree.GetBytes(path)
+		tree.ContainsBytes(path)
+		tree.LongestPrefixBytes(path)
+	}))
+
+	suite.False(tree.DeleteBytes([]byte("/api")))
+	suite.True(tree.DeleteBytes([]byte("/api/")))
+	suite.True(tree.DeleteBytes([]byte{0xff}))
+	suite.Equal([]string{"\x00", "\xff\x00"}, tree.Keys())
+	suite.NoError(tree.Validate())
+}
+
+func (suite *RadixTreeTestSuite) TestRadixTreeEncoding() {
+	tree := NewRadixTree(gods.WithElement[int]())
+	tree.Insert("b", 2)
+	tree.Insert("a", 1)
+	tree.Insert("ab", 3)
+
+	data, err := json.Marshal(tree)
+	suite.NoError(err)
+	suite.Equal(`{"a":1,"ab":3,"b":2}`, string(data))
+
+	decoded := NewRadixTree(gods.WithElement[int]())
+	decoded.Insert("stale", 0)
+	suite.NoError(json.Unmarshal(data, decoded))
+	suite.Equal([]string{"a", "ab", "b"}, decoded.Keys())
+	suite.Equal([]interface{}{1, 3, 2}, decoded.Values())
+
+	var zero RadixTree
+	suite.NoError(json.Unmarshal(data, &zero))
+	suite.Equal(3, zero.Len())
+
+	data, err = tree.MarshalBinary()
+	suite.NoError(err)
+
+	binary := NewRadixTree()
+	suite.NoError(binary.UnmarshalBinary(data))
+	suite.Equal(tree.Keys(), binary.Keys())
+	suite.Equal(tree.Values(), binary.Values())
+	suite.Error(binary.UnmarshalBinary(data[:len(data)-1]))
+	suite.Equal(3, binary.Len())
+
+	suite.Equal("RadixTree[a:1 ab:3 b:2]", tree.String())
+	suite.Equal("RadixTree[a:1 ...2 more]", fmt.Sprintf("%.1v", tree))
+}
+
+func (suite *RadixTreeTestSuite) TestRadixTreeRandom() {
+	random := rand.New(rand.NewSource(1))
+	tree := NewRadixTree()
+	model := make(map[string]int)
+
+	key := func() string {
+		var b strings.Builder
+
+		for i := random.Intn(6); i > 0; i-- {
+			b.WriteByte("abc"[random.Intn(3)])
+		}
+
+		return b.String()
+	}
+
+	for i := 0; i < 5000; i++ {
+		k := key()
+		_, present := model[k]
+
+		switch random.Intn(6) {
+		case 0:
+			suite.Equal(!present, tree.Insert(k, i))
+			model[k] = i
+		case 1:
+			suite.Equal(!present, tree.InsertBytes([]byte(k), i))
+			model[k] = i
+		case 2:
+			suite.Equal(present, tree.Delete(k))
+			delete(model, k)
+		case 3:
+			suite.Equal(present, tree.DeleteBytes([]byte(k)))
+			delete(model, k)
+		case 4:
+			value, ok := tree.Get(k)
+			suite.Equal(present, ok)
+
+			if present {
+				suite.Equal(model[k], value)
+			}
+		case 5:
+			value, ok := tree.GetBytes([]byte(k))
+			suite.Equal(present, ok)
+
+			if present {
+				suite.Equal(model[k], value)
+			}
+		}
+	}
+
+	suite.NoError(tree.Validate())
+
+	for i := 0; i < 100; i++ {
+		prefix := key()
+
+		var expected []string
+
+		for k := range model {
+			if strings.HasPrefix(k, prefix) {
+				expected = append(expected, k)
+			}
+		}
+
+		sort.Strings(expected)
+
+		var found []string
+
+		tree.WalkPrefix(prefix, func(key string, value interface{}) bool {
+			found = append(found, key)
+			return true
+		})
+
+		suite.Equal(expected, found, prefix)
+
+		var foundBytes []string
+
+		tree.WalkPrefixBytes([]byte(prefix), func(key string, value interface{}) bool {
+			foundBytes = append(foundBytes, key)
+			return true
+		})
+
+		suite.Equal(expected, foundBytes, prefix)
+
+		longest, ok := "", false
+
+		for k := range model {
+			if strings.HasPrefix(prefix, k) && (!ok || len(k) > len(longest)) {
+				longest, ok = k, true
+			}
+		}
+
+		actual, _, exists := tree.LongestPrefix(prefix)
+		suite.Equal(ok, exists, prefix)
+		suite.Equal(longest, actual, prefix)
+
+		actualBytes, _, exists := tree.LongestPrefixBytes([]byte(prefix))
+		suite.Equal(ok, exists, prefix)
+		suite.Equal(longest, string(actualBytes), prefix)
+	}
+}
+
+func TestRadixTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(RadixTreeTestSuite))
+}
+
+func FuzzRadixTree(f *testing.F) {
+	f.Add([]byte{0, 'a', 'b', 0, 0, 'a', 0, 1, 'a', 'b', 0, 2, 'a', 0})
+	f.Add([]byte{0, 'x', 0, 0, 'x', 'y', 0, 1, 'x', 0, 3, 'x', 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tree := NewRadixTree()
+		model := make(map[string]bool)
+
+		// Each operation is an opcode followed by a zero terminated key.
+		for len(data) > 1 {
+			op := data[0]
+			end := 1
+
+			for end < len(data) && data[end] != 0 {
+				end++
+			}
+
+			key := string(data[1:end])
+			data = data[min(end+1, len(data)):]
+
+			switch op % 4 {
+			case 0:
+				if tree.Insert(key, nil) == model[key] {
+					t.Fatalf("insert %q disagrees with model", key)
+				}
+
+				model[key] = true
+			case 1:
+				if tree.Delete(key) != model[key] {
+					t.Fatalf("delete %q disagrees with model", key)
+				}
+
+				delete(model, key)
+			case 2:
+				if tree.Contains(key) != model[key] {
+					t.Fatalf("contains %q disagrees with model", key)
+				}
+			case 3:
+				count := 0
+
+				for k := range model {
+					if strings.HasPrefix(k, key) {
+						count++
+					}
+				}
+
+				found := 0
+
+				tree.WalkPrefix(key, func(string, interface{}) bool {
+					found++
+					return true
+				})
+
+				if found != count {
+					t.Fatalf("%d keys start with %q, walked %d", count, key, found)
+				}
+			}
+
+			if err := tree.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if tree.Len() != len(model) {
+				t.Fatalf("len is %d, model has %d", tree.Len(), len(model))
+			}
+		}
+	})
+}